@@ -0,0 +1,177 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemVer_Bump(t *testing.T) {
+	tests := map[string]struct {
+		version string
+		part    BumpPart
+		preID   string
+		want    string
+		wantErr string
+	}{
+		"major":                        {version: "1.4.2", part: BumpMajor, want: "2.0.0"},
+		"minor":                        {version: "1.4.2", part: BumpMinor, want: "1.5.0"},
+		"patch":                        {version: "1.4.2", part: BumpPatch, want: "1.4.3"},
+		"drops_build_metadata":         {version: "1.4.2+build.7", part: BumpPatch, want: "1.4.3"},
+		"major_releases_prerelease":    {version: "2.0.0-rc.2", part: BumpMajor, want: "2.0.0"},
+		"major_of_minor_prerelease":    {version: "2.1.0-rc.2", part: BumpMajor, want: "3.0.0"},
+		"minor_releases_prerelease":    {version: "1.5.0-rc.2", part: BumpMinor, want: "1.5.0"},
+		"minor_of_patch_prerelease":    {version: "1.5.1-rc.2", part: BumpMinor, want: "1.6.0"},
+		"patch_releases_prerelease":    {version: "1.5.1-rc.2", part: BumpPatch, want: "1.5.1"},
+		"minor_with_pre":               {version: "1.4.2", part: BumpMinor, preID: "rc", want: "1.5.0-rc.0"},
+		"major_with_pre":               {version: "1.4.2", part: BumpMajor, preID: "beta", want: "2.0.0-beta.0"},
+		"prerelease_increment":         {version: "1.5.0-rc.1", part: BumpPrerelease, want: "1.5.0-rc.2"},
+		"prerelease_same_id":           {version: "1.5.0-rc.1", part: BumpPrerelease, preID: "rc", want: "1.5.0-rc.2"},
+		"prerelease_numeric_only":      {version: "1.5.0-1", part: BumpPrerelease, want: "1.5.0-2"},
+		"prerelease_without_number":    {version: "1.5.0-rc", part: BumpPrerelease, want: "1.5.0-rc.0"},
+		"prerelease_switch_id":         {version: "1.5.0-beta.3", part: BumpPrerelease, preID: "rc", want: "1.5.0-rc.0"},
+		"prerelease_of_release":        {version: "1.4.2", part: BumpPrerelease, want: "1.4.3-rc.0"},
+		"prerelease_of_release_custom": {version: "1.4.2", part: BumpPrerelease, preID: "alpha", want: "1.4.3-alpha.0"},
+		"backwards_prerelease_id":      {version: "1.5.0-rc.1", part: BumpPrerelease, preID: "beta", wantErr: "would go backwards"},
+		"unknown_part":                 {version: "1.4.2", part: "micro", wantErr: "unsupported bump part 'micro'"},
+		"invalid_pre_id":               {version: "1.4.2", part: BumpMinor, preID: "rc_1", wantErr: "invalid prerelease identifier"},
+		"dotted_pre_id":                {version: "1.4.2", part: BumpMinor, preID: "rc.1", wantErr: "single identifier"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := MustParseSemVer(tt.version).Bump(tt.part, tt.preID)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+const testBumpManifest = `# Release manifest - bumped by CI
+manifest_version: "1.0"
+
+project:
+  name: "bump-app"
+  version: "1.4.2" # current release
+
+schemas:
+  postgres_main: "47"
+
+apis:
+  # Public REST API
+  rest_v1: v1.15
+  grpc: '2.0.0-rc.1'
+
+components:
+  redis: 7.2.4
+`
+
+func TestBumpManifest(t *testing.T) {
+	tests := map[string]struct {
+		dimension string
+		part      BumpPart
+		preID     string
+		wantDim   string
+		wantOld   string
+		wantNew   string
+		wantLine  string
+	}{
+		"project_default":    {part: BumpMinor, wantDim: "project.version", wantOld: "1.4.2", wantNew: "1.5.0", wantLine: `  version: "1.5.0" # current release`},
+		"project_alias":      {dimension: "project", part: BumpPatch, wantDim: "project.version", wantOld: "1.4.2", wantNew: "1.4.3", wantLine: `  version: "1.4.3" # current release`},
+		"schema_stays_short": {dimension: "schemas.postgres_main", part: BumpMajor, wantDim: "schemas.postgres_main", wantOld: "47", wantNew: "48", wantLine: `  postgres_main: "48"`},
+		"api_keeps_prefix":   {dimension: "apis.rest_v1", part: BumpMinor, wantDim: "apis.rest_v1", wantOld: "v1.15", wantNew: "v1.16", wantLine: `  rest_v1: v1.16`},
+		"api_patch_expands":  {dimension: "apis.rest_v1", part: BumpPatch, wantDim: "apis.rest_v1", wantOld: "v1.15", wantNew: "v1.15.1", wantLine: `  rest_v1: v1.15.1`},
+		"single_quoted":      {dimension: "apis.grpc", part: BumpPrerelease, wantDim: "apis.grpc", wantOld: "2.0.0-rc.1", wantNew: "2.0.0-rc.2", wantLine: `  grpc: '2.0.0-rc.2'`},
+		"component_with_pre": {dimension: "components.redis", part: BumpMinor, preID: "rc", wantDim: "components.redis", wantOld: "7.2.4", wantNew: "7.3.0-rc.0", wantLine: `  redis: 7.3.0-rc.0`},
+		"project_prerelease": {dimension: "project.version", part: BumpPrerelease, wantDim: "project.version", wantOld: "1.4.2", wantNew: "1.4.3-rc.0", wantLine: `  version: "1.4.3-rc.0" # current release`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "versions.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(testBumpManifest), 0o640))
+
+			result, err := BumpManifest(path, tt.dimension, tt.part, tt.preID)
+			require.NoError(t, err)
+			assert.Equal(t, &BumpResult{Dimension: tt.wantDim, Old: tt.wantOld, New: tt.wantNew}, result)
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			// Only the bumped line changes; comments, blank lines and quoting survive
+			oldLines := strings.Split(testBumpManifest, "\n")
+			newLines := strings.Split(string(data), "\n")
+			require.Len(t, newLines, len(oldLines))
+			changed := 0
+			for i := range oldLines {
+				if oldLines[i] != newLines[i] {
+					changed++
+					assert.Equal(t, tt.wantLine, newLines[i])
+				}
+			}
+			assert.Equal(t, 1, changed)
+
+			stat, err := os.Stat(path)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0o640), stat.Mode().Perm())
+
+			_, err = New(WithManifestPath(path))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBumpManifest_Errors(t *testing.T) {
+	tests := map[string]struct {
+		filename  string
+		content   string
+		dimension string
+		part      BumpPart
+		preID     string
+		noFile    bool
+		wantErr   string
+	}{
+		"backwards":         {dimension: "apis.grpc", part: BumpPrerelease, preID: "alpha", wantErr: "would go backwards"},
+		"missing_entry":     {dimension: "apis.graphql", part: BumpMinor, wantErr: "'apis.graphql' not found"},
+		"invalid_dimension": {dimension: "custom.region", part: BumpMinor, wantErr: "invalid bump dimension 'custom.region'"},
+		"project_name":      {dimension: "project.name", part: BumpMinor, wantErr: "invalid bump dimension"},
+		"not_a_version":     {content: strings.Replace(testBumpManifest, "7.2.4", "latest", 1), dimension: "components.redis", part: BumpMinor, wantErr: "is not a version"},
+		"json_manifest":     {filename: "versions.json", content: `{"manifest_version": "1.0", "project": {"name": "a", "version": "1.0.0"}}`, part: BumpMinor, wantErr: "cannot bump json manifest"},
+		"missing_file":      {noFile: true, part: BumpMinor, wantErr: ErrMsgBumpManifest},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			filename, content := tt.filename, tt.content
+			if filename == "" {
+				filename = "versions.yaml"
+			}
+			if content == "" {
+				content = testBumpManifest
+			}
+			path := filepath.Join(t.TempDir(), filename)
+			if !tt.noFile {
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+			}
+
+			_, err := BumpManifest(path, tt.dimension, tt.part, tt.preID)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+
+			if !tt.noFile {
+				data, readErr := os.ReadFile(path)
+				require.NoError(t, readErr)
+				assert.Equal(t, content, string(data), "manifest must be unchanged on error")
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package version
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const changelogTestHash = "0123456789abcdef0123456789abcdef01234567"
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := map[string]struct {
+		message string
+		want    ConventionalCommit
+	}{
+		"feature":          {message: "feat: add diff command", want: ConventionalCommit{Type: "feat", Description: "add diff command"}},
+		"scope":            {message: "fix(cli): handle empty tags\n\nDetails.", want: ConventionalCommit{Type: "fix", Scope: "cli", Description: "handle empty tags"}},
+		"bang":             {message: "feat(api)!: drop v1", want: ConventionalCommit{Type: "feat", Scope: "api", Description: "drop v1", Breaking: true}},
+		"footer":           {message: "refactor: rename fields\n\nBREAKING CHANGE: Info.Foo is now Info.Bar", want: ConventionalCommit{Type: "refactor", Description: "rename fields", Breaking: true}},
+		"hyphen_footer":    {message: "chore: bump deps\n\nBREAKING-CHANGE: requires Go 1.24", want: ConventionalCommit{Type: "chore", Description: "bump deps", Breaking: true}},
+		"upper_case_type":  {message: "Feat: shout", want: ConventionalCommit{Type: "feat", Description: "shout"}},
+		"not_conventional": {message: "Merge branch 'main'\n\nfeat: not a header", want: ConventionalCommit{Description: "Merge branch 'main'"}},
+		"missing_space":    {message: "fix:typo", want: ConventionalCommit{Description: "fix:typo"}},
+		"surrounding_ws":   {message: "\n  docs: update README  \n", want: ConventionalCommit{Type: "docs", Description: "update README"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.want.Hash = changelogTestHash
+			assert.Equal(t, tt.want, ParseConventionalCommit(changelogTestHash, tt.message))
+		})
+	}
+}
+
+func TestConventionalCommit_ChangelogSection(t *testing.T) {
+	tests := map[string]struct {
+		message string
+		want    string
+	}{
+		"feat":             {message: "feat: x", want: ChangelogAdded},
+		"fix":              {message: "fix: x", want: ChangelogFixed},
+		"perf":             {message: "perf: x", want: ChangelogChanged},
+		"deprecate":        {message: "deprecate: x", want: ChangelogDeprecated},
+		"remove":           {message: "remove: x", want: ChangelogRemoved},
+		"security":         {message: "security: x", want: ChangelogSecurity},
+		"chore":            {message: "chore: x", want: ""},
+		"breaking_chore":   {message: "chore!: x", want: ChangelogChanged},
+		"not_conventional": {message: "x", want: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseConventionalCommit("", tt.message).ChangelogSection())
+		})
+	}
+}
+
+func TestReleaseNotes_SuggestedBump(t *testing.T) {
+	tests := map[string]struct {
+		messages []string
+		want     BumpPart
+	}{
+		"none":     {want: BumpPatch},
+		"fixes":    {messages: []string{"fix: a", "docs: b"}, want: BumpPatch},
+		"feature":  {messages: []string{"fix: a", "feat: b"}, want: BumpMinor},
+		"breaking": {messages: []string{"feat: a", "fix!: b"}, want: BumpMajor},
+		"footer":   {messages: []string{"chore: a\n\nBREAKING CHANGE: b"}, want: BumpMajor},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			notes := &ReleaseNotes{}
+			for _, message := range tt.messages {
+				notes.Commits = append(notes.Commits, ParseConventionalCommit("", message))
+			}
+			assert.Equal(t, tt.want, notes.SuggestedBump())
+		})
+	}
+}
+
+func TestReleaseNotes_Section(t *testing.T) {
+	notes := &ReleaseNotes{}
+	for _, message := range []string{
+		"fix(loader): trim tags",
+		"feat(cli): add changelog command",
+		"docs: explain changelog",
+		"Merge pull request #12",
+		"security: validate git binary",
+		"feat!: rename Info.Foo",
+		"perf: cache manifests",
+	} {
+		notes.Commits = append(notes.Commits, ParseConventionalCommit("", message))
+	}
+
+	want := `## [1.5.0] - 2025-06-01
+
+### Added
+- **cli:** add changelog command
+- **BREAKING:** rename Info.Foo
+
+### Changed
+- cache manifests
+
+### Fixed
+- **loader:** trim tags
+
+### Security
+- validate git binary
+`
+	assert.Equal(t, want, notes.Section("1.5.0", time.Date(2025, 6, 1, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "## [0.1.0] - 2025-06-01\n", (&ReleaseNotes{}).Section("0.1.0", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestParseCommitLog(t *testing.T) {
+	other := strings.Repeat("f", 40)
+	output := changelogTestHash + "\x1ffeat: newest\n\nbody\n\x1e\n" +
+		"not-a-hash\x1ffix: skipped\x1e\n" +
+		other + "\x1ffix: oldest\n\x1e"
+
+	commits := parseCommitLog(output)
+	require.Len(t, commits, 2)
+	assert.Equal(t, other, commits[0].Hash)
+	assert.Equal(t, "oldest", commits[0].Description)
+	assert.Equal(t, changelogTestHash, commits[1].Hash)
+	assert.Equal(t, "newest", commits[1].Description)
+	assert.Empty(t, parseCommitLog(""))
+}
+
+func TestPrependChangelog(t *testing.T) {
+	section := "## [1.1.0] - 2025-06-01\n\n### Added\n- thing\n"
+	released := "## [1.0.0] - 2025-01-01\n\n### Added\n- first\n"
+
+	tests := map[string]struct {
+		existing string
+		want     string
+		wantErr  bool
+	}{
+		"before_previous_release": {
+			existing: ChangelogHeader + "\n## [Unreleased]\n\n- pending\n\n" + released,
+			want:     ChangelogHeader + "\n## [Unreleased]\n\n- pending\n\n" + section + "\n" + released,
+		},
+		"without_unreleased": {
+			existing: ChangelogHeader + "\n" + released,
+			want:     ChangelogHeader + "\n" + section + "\n" + released,
+		},
+		"appended_without_releases": {
+			existing: ChangelogHeader + "\n## [Unreleased]\n",
+			want:     ChangelogHeader + "\n## [Unreleased]\n\n" + section,
+		},
+		"release_exists": {
+			existing: ChangelogHeader + "\n## [1.1.0] - 2025-05-01\n",
+			wantErr:  true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ChangelogDefaultPath)
+			require.NoError(t, os.WriteFile(path, []byte(tt.existing), 0o644))
+
+			err := PrependChangelog(path, section)
+			data, readErr := os.ReadFile(path)
+			require.NoError(t, readErr)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "already has a section for ## [1.1.0]")
+				assert.Equal(t, tt.existing, string(data), "file must be left untouched")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestPrependChangelog_CreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ChangelogDefaultPath)
+	section := "## [0.1.0] - 2025-06-01\n"
+
+	require.NoError(t, PrependChangelog(path, section))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, ChangelogHeader+"\n"+section, string(data))
+}
+
+// TestReadReleaseNotes runs against a scratch repository and is skipped when
+// git is unavailable.
+func TestReadReleaseNotes(t *testing.T) {
+	if getGitBinary() == "" {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command(getGitBinary(), args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	commit := func(message string) {
+		git("commit", "-q", "--allow-empty", "-m", message)
+	}
+
+	git("init", "-q", "-b", "main")
+	t.Chdir(dir)
+	commit("feat: initial import")
+
+	notes, err := ReadReleaseNotes()
+	require.NoError(t, err)
+	assert.Empty(t, notes.PreviousTag)
+	require.Len(t, notes.Commits, 1)
+	assert.Equal(t, "initial import", notes.Commits[0].Description)
+
+	git("tag", "-a", "v1.0.0", "-m", "release")
+	_, err = ReadReleaseNotes()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrMsgNoCommits)
+
+	commit("fix: first")
+	commit("feat(cli): second\n\nBREAKING CHANGE: flags renamed")
+
+	notes, err = ReadReleaseNotes()
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", notes.PreviousTag)
+	require.Len(t, notes.Commits, 2)
+	assert.Equal(t, "first", notes.Commits[0].Description)
+	assert.Equal(t, "second", notes.Commits[1].Description)
+	assert.True(t, notes.Commits[1].Breaking)
+	assert.Equal(t, BumpMajor, notes.SuggestedBump())
+}
+
+func TestReadReleaseNotes_NotARepository(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(t.TempDir()))
+
+	_, err := ReadReleaseNotes()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrMsgReadCommits)
+}
+
+func TestReadReleaseNotes_GitNotAvailable(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := ReadReleaseNotes()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrMsgReadCommits)
+	assert.Contains(t, err.Error(), ErrMsgGitNotAvailable)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/itsatony/go-version"
+)
+
+// runChangelog executes the changelog mode: it groups the conventional
+// commits since the last tag into a Keep a Changelog section for the next
+// project version. Without -write the section is printed and the suggested
+// bump reported on stderr; with -write it is prepended to the changelog and
+// project.version in the manifest is bumped.
+func runChangelog(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("changelog", flag.ContinueOnError)
+	manifest := fs.String("manifest", "", "Path to the manifest file (default: the first of "+defaultManifests+
+		"); -write only supports YAML manifests and defaults to "+defaultYAMLManifests)
+	changelog := fs.String("changelog", version.ChangelogDefaultPath, "Path to the Keep a Changelog file")
+	bump := fs.String("bump", "", "Override the suggested bump: major, minor, patch or prerelease")
+	write := fs.Bool("write", false, "Prepend the section to the changelog and bump project.version")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(positional, " "))
+	}
+
+	path := findManifest(*manifest, defaultManifestFiles)
+	if *write {
+		path = findManifest(*manifest, yamlManifestFiles)
+	}
+
+	// The loader falls back to defaults for a missing manifest, but there is
+	// no project.version to bump then
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	info, err := version.New(
+		version.WithManifestPath(path),
+		version.WithoutGitInfo(),
+		version.WithoutBuildInfo(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	current, err := info.Project.SemVer()
+	if err != nil {
+		return fmt.Errorf("project.version in %s: %w", path, err)
+	}
+
+	notes, err := version.ReadReleaseNotes()
+	if err != nil {
+		return err
+	}
+	part := notes.SuggestedBump()
+	if *bump != "" {
+		part = version.BumpPart(*bump)
+	}
+	next, err := current.Bump(part, "")
+	if err != nil {
+		return err
+	}
+	section := notes.Section(next.String(), time.Now())
+
+	if !*write {
+		fmt.Fprintf(stderr, "Suggested bump: %s (%s -> %s, %d commits %s)\n",
+			part, info.Project.Version, next, len(notes.Commits), previousRelease(notes))
+		_, err := io.WriteString(stdout, section)
+		return err
+	}
+
+	// The changelog goes first: it refuses to add a release twice, which
+	// leaves the manifest untouched
+	if err := version.PrependChangelog(*changelog, section); err != nil {
+		return err
+	}
+	result, err := version.BumpManifest(path, version.BumpDefaultDimension, part, "")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "%s: %s -> %s (%s)\nUpdated %s\n", result.Dimension, result.Old, result.New, part, *changelog)
+	return err
+}
+
+// previousRelease describes where the release notes start.
+func previousRelease(notes *version.ReleaseNotes) string {
+	if notes.PreviousTag == "" {
+		return "without a previous tag"
+	}
+	return "since " + notes.PreviousTag
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/itsatony/go-version"
+)
+
+// Diff output formats
+const (
+	diffFormatText     = "text"
+	diffFormatJSON     = "json"
+	diffFormatMarkdown = "markdown"
+)
+
+const (
+	// diffHTTPTimeout bounds fetching a /version payload
+	diffHTTPTimeout = 10 * time.Second
+
+	// diffMaxPayloadSize bounds the size of a fetched /version payload
+	diffMaxPayloadSize = 10 << 20
+)
+
+// runDiff executes the diff mode: it compares two manifests or /version
+// payloads (files or http(s) URLs) and prints the changes. With -url the old
+// side is fetched from the URL and compared against the manifest.
+func runDiff(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	url := fs.String("url", "", "URL of a /version endpoint to use as the old side")
+	manifest := fs.String("manifest", "", "Manifest to compare against -url (default: the first of "+defaultManifests+")")
+	format := fs.String("format", diffFormatText, "Output format: text, json or markdown")
+	failOnDowngrade := fs.Bool("fail-on-downgrade", false, "Exit with code 4 if a version was downgraded")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+
+	var oldSource, newSource string
+	switch {
+	case *url != "" && len(positional) <= 1:
+		oldSource, newSource = *url, findManifest(*manifest, defaultManifestFiles)
+		if len(positional) == 1 {
+			newSource = positional[0]
+		}
+	case *url == "" && len(positional) == 2:
+		oldSource, newSource = positional[0], positional[1]
+	default:
+		return fmt.Errorf("diff requires two manifests or payloads, or -url and at most one manifest")
+	}
+
+	oldInfo, err := loadDiffSource(oldSource)
+	if err != nil {
+		return err
+	}
+	newInfo, err := loadDiffSource(newSource)
+	if err != nil {
+		return err
+	}
+
+	changes := version.Diff(oldInfo, newInfo)
+	if err := writeChanges(stdout, changes, *format); err != nil {
+		return err
+	}
+
+	if *failOnDowngrade && changes.HasDowngrades() {
+		return &exitError{code: exitCodeDowngrade, err: fmt.Errorf("%s downgrades %s", newSource, oldSource)}
+	}
+	return nil
+}
+
+// loadDiffSource loads the version information of a diff operand: an http(s)
+// URL of a /version endpoint, a saved /version JSON payload, or a manifest.
+func loadDiffSource(source string) (*version.Info, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return fetchInfo(source)
+	}
+
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", source, err)
+	}
+	if isInfoPayload(source, data) {
+		var info version.Info
+		if err := json.Unmarshal(data, &info); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+		}
+		return &info, nil
+	}
+
+	info, err := version.New(
+		version.WithManifestPath(source),
+		version.WithoutGitInfo(),
+		version.WithoutBuildInfo(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", source, err)
+	}
+	return info, nil
+}
+
+// isInfoPayload reports whether a file holds a /version JSON payload rather
+// than a JSON manifest, which always has a manifest_version.
+func isInfoPayload(path string, data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	if !strings.EqualFold(filepath.Ext(path), ".json") && !bytes.HasPrefix(trimmed, []byte("{")) {
+		return false
+	}
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &probe); err != nil {
+		return false
+	}
+	_, isManifest := probe["manifest_version"]
+	return !isManifest
+}
+
+// fetchInfo fetches a /version payload.
+func fetchInfo(url string) (*version.Info, error) {
+	client := &http.Client{Timeout: diffHTTPTimeout}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %s: %w", url, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+
+	var info version.Info
+	if err := json.NewDecoder(io.LimitReader(resp.Body, diffMaxPayloadSize)).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to parse response of %s: %w", url, err)
+	}
+	return &info, nil
+}
+
+// writeChanges writes changes in the given diff output format.
+func writeChanges(w io.Writer, changes version.Changes, format string) error {
+	switch format {
+	case diffFormatText:
+		return writeChangesText(w, changes)
+	case diffFormatJSON:
+		if changes == nil {
+			changes = version.Changes{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(changes)
+	case diffFormatMarkdown:
+		return writeChangesMarkdown(w, changes)
+	default:
+		return fmt.Errorf("unknown diff format %q: use text, json or markdown", format)
+	}
+}
+
+func writeChangesText(w io.Writer, changes version.Changes) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range changes {
+		fmt.Fprintf(tw, "  %s\t%s.%s\t%s\n", c.Kind, c.Dimension, c.Name, changeValue(c))
+	}
+	return tw.Flush()
+}
+
+func writeChangesMarkdown(w io.Writer, changes version.Changes) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes")
+		return err
+	}
+	var buf bytes.Buffer
+	buf.WriteString("| Change | Dimension | Name | Old | New | Bump |\n")
+	buf.WriteString("|--------|-----------|------|-----|-----|------|\n")
+	for _, c := range changes {
+		fmt.Fprintf(&buf, "| %s | %s | %s | %s | %s | %s |\n",
+			c.Kind, c.Dimension, markdownCell(c.Name), markdownCell(c.Old), markdownCell(c.New), markdownCell(string(c.Bump)))
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// changeValue describes the values of a change for the text output.
+func changeValue(c version.Change) string {
+	switch c.Kind {
+	case version.ChangeAdded:
+		return c.New
+	case version.ChangeRemoved:
+		return c.Old
+	}
+	value := c.Old + " -> " + c.New
+	if c.Bump != "" {
+		value += " (" + string(c.Bump) + ")"
+	}
+	return value
+}
+
+// markdownCell escapes a value for a markdown table cell.
+func markdownCell(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return strings.ReplaceAll(value, "|", `\|`)
+}
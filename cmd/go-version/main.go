@@ -1,14 +1,20 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
-	"sort"
-	"text/tabwriter"
+	"os/exec"
+	"path/filepath"
+	"strings"
 
 	"github.com/itsatony/go-version"
+	"github.com/itsatony/go-version/internal/manifesttmpl"
 )
 
 const (
@@ -16,11 +22,44 @@ const (
 	usage   = `go-version - Display version information
 
 Usage:
-  go-version [options]
-
-Options:
+  go-version <command> [options] [arguments]
+  go-version [options]              (same as: go-version show [options])
+
+Commands:
+  show      Show version information (default command)
+  get       Print a single value, e.g. get schemas.postgres_main (exit code 3 if missing)
+  validate  Check the manifest and optional version constraints
+  init      Create versions.yaml from the annotated template
+  bump      Bump a version in versions.yaml in place
+  sbom      Write a software bill of materials
+  inspect   Show version information of another Go binary
+  ldflags   Print -ldflags injecting git and build information
+  build     Run go build with those ldflags injected
+  diff      Compare two manifests or /version payloads
+  changelog Draft the next release section from conventional commits
+  help      Show this help message
+
+  go-version show [-format name|template] [-json|-compact|-schemas|-apis|-components|-git|-build|-modules] [-manifest path]
+  go-version get <path> [-manifest path]
+  go-version validate [-manifest path] ["<dimension> <constraint>" ...]
+  go-version init [-name project] [-manifest path] [-force]
+  go-version bump major|minor|patch|prerelease [-pre id] [-dimension name] [-manifest path]
+  go-version sbom [-format cyclonedx|spdx] [-manifest path]
+  go-version inspect [-format name|template] [-json|-compact] <binary>
+  go-version ldflags
+  go-version build -- [go build flags] [packages]
+  go-version diff [-format text|json|markdown] [-fail-on-downgrade] <old> <new>
+  go-version diff -url <url> [-manifest path] [options]
+  go-version changelog [-write] [-bump part] [-changelog path] [-manifest path]
+
+Show options:
   -manifest string
-        Path to versions.yaml manifest file (default: versions.yaml)
+        Path to the manifest file (default: the first of versions.yaml,
+        versions.yml, versions.json and versions.toml that exists)
+  -format string
+        Output format: text, compact, json, yaml, env, dotenv, github-output, otel,
+        schemas, apis, components, git, build, modules, or a Go template such as
+        '{{.Project.Version}}-{{.Git.Commit | short}}' (overrides the flags below)
   -json
         Output in JSON format
   -compact
@@ -35,6 +74,8 @@ Options:
         Show only git information
   -build
         Show only build information
+  -modules
+        Show only Go module dependencies compiled into the binary
   -help
         Show this help message
 
@@ -42,9 +83,29 @@ Examples:
   # Show all version information
   go-version
 
+  # Print a single value for scripts
+  go-version get project.version
+  go-version get git.commit
+
+  # Fail the build if the manifest is invalid or a constraint is not met
+  go-version validate "schemas.postgres_main >=45" "apis.rest_v1 ^1.15"
+
+  # Start a new manifest named after the module in go.mod
+  go-version init
+
   # Show version in JSON format
   go-version -json
 
+  # Custom output with a Go template
+  go-version -format '{{.Project.Version}}-{{.Git.Commit | short}}'
+
+  # Export as environment variables or GitHub Actions step outputs
+  go-version -format env
+  go-version -format github-output >> "$GITHUB_OUTPUT"
+
+  # Tag OpenTelemetry tracers and meters like the /version output
+  export OTEL_RESOURCE_ATTRIBUTES="$(go-version -format otel)"
+
   # Use custom manifest file
   go-version -manifest ./config/versions.yaml
 
@@ -54,33 +115,159 @@ Examples:
   # Show compact format
   go-version -compact
 
+  # Show which version of a dependency is compiled in
+  go-version -modules | grep golang.org/x/net
+
   # Combine JSON with custom manifest
   go-version -json -manifest ./versions.yaml
+
+  # Software bill of materials (Go modules and manifest components)
+  go-version sbom -format spdx > sbom.spdx.json
+
+  # Inspect a release artifact without running it
+  go-version inspect ./dist/server-linux-amd64
+
+  # Build with git and build information injected (SOURCE_DATE_EPOCH fixes the build time)
+  go-version build -- -o bin/server ./cmd/server
+  go build -ldflags "$(go-version ldflags) -s -w" ./cmd/server
+
+  # Review what a release changes compared to production
+  go-version diff -url https://api.example.com/version -fail-on-downgrade
+  go-version diff -format markdown release-1.4.yaml versions.yaml
+
+  # Preview the next release from conventional commits, then apply it
+  go-version changelog
+  go-version changelog -write
+
+  # Bump versions in versions.yaml (comments and key order are kept)
+  go-version bump minor
+  go-version bump prerelease -pre rc
+  go-version bump minor -dimension apis.rest_v1
 `
 )
 
+// Output flags of the show command, which also runs when no command is given.
+// They are bound by showFlags.
 var (
-	manifestPath   = flag.String("manifest", "versions.yaml", "Path to versions.yaml manifest file")
-	jsonOutput     = flag.Bool("json", false, "Output in JSON format")
-	compactMode    = flag.Bool("compact", false, "Show compact single-line format")
-	schemasOnly    = flag.Bool("schemas", false, "Show only database schema versions")
-	apisOnly       = flag.Bool("apis", false, "Show only API versions")
-	componentsOnly = flag.Bool("components", false, "Show only component versions")
-	gitOnly        = flag.Bool("git", false, "Show only git information")
-	buildOnly      = flag.Bool("build", false, "Show only build information")
-	showHelp       = flag.Bool("help", false, "Show help message")
+	manifestPath   = new(string)
+	jsonOutput     = new(bool)
+	compactMode    = new(bool)
+	schemasOnly    = new(bool)
+	apisOnly       = new(bool)
+	componentsOnly = new(bool)
+	gitOnly        = new(bool)
+	buildOnly      = new(bool)
+	modulesOnly    = new(bool)
+	format         = new(string)
+	showHelp       = new(bool)
 )
 
+// showFlags returns the flag set of the show command bound to the output flags,
+// which are reset to their defaults.
+func showFlags() *flag.FlagSet {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	fs.Usage = func() { flag.Usage() }
+	fs.StringVar(manifestPath, "manifest", "", "Path to the manifest file (default: the first of "+defaultManifests+")")
+	fs.BoolVar(jsonOutput, "json", false, "Output in JSON format")
+	fs.BoolVar(compactMode, "compact", false, "Show compact single-line format")
+	fs.BoolVar(schemasOnly, "schemas", false, "Show only database schema versions")
+	fs.BoolVar(apisOnly, "apis", false, "Show only API versions")
+	fs.BoolVar(componentsOnly, "components", false, "Show only component versions")
+	fs.BoolVar(gitOnly, "git", false, "Show only git information")
+	fs.BoolVar(buildOnly, "build", false, "Show only build information")
+	fs.BoolVar(modulesOnly, "modules", false, "Show only Go module dependencies")
+	fs.StringVar(format, "format", "", "Output format name or Go template")
+	fs.BoolVar(showHelp, "help", false, "Show help message")
+	return fs
+}
+
+// goCommand is the go tool run by the build command
+const goCommand = "go"
+
+// Exit codes
+const (
+	// exitCodeError is returned for all failures without a more specific code
+	exitCodeError = 1
+
+	// exitCodeNotFound is returned by get when the requested value does not exist
+	exitCodeNotFound = 3
+
+	// exitCodeDowngrade is returned by diff -fail-on-downgrade when a version went down
+	exitCodeDowngrade = 4
+)
+
+// exitError is an error that requests a specific exit code.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// commands maps subcommand names to their implementations. Invocations that
+// start with a flag (or have no arguments) run show, so the original
+// flag-only usage keeps working.
+var commands = map[string]func(args []string) error{
+	"show":      runShow,
+	"get":       func(args []string) error { return runGet(args, os.Stdout) },
+	"validate":  func(args []string) error { return runValidate(args, os.Stdout) },
+	"init":      func(args []string) error { return runInit(args, os.Stdout) },
+	"bump":      func(args []string) error { return runBump(args, os.Stdout) },
+	"sbom":      func(args []string) error { return runSBOM(args, os.Stdout) },
+	"inspect":   func(args []string) error { return runInspect(args, os.Stdout) },
+	"ldflags":   func(args []string) error { return runLdflags(args, os.Stdout) },
+	"build":     runBuild,
+	"diff":      func(args []string) error { return runDiff(args, os.Stdout) },
+	"changelog": func(args []string) error { return runChangelog(args, os.Stdout, os.Stderr) },
+	"help": func([]string) error {
+		flag.Usage()
+		return nil
+	},
+}
+
 func main() {
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "%s\n", usage)
 	}
-	flag.Parse()
 
-	if err := run(); err != nil {
+	if err := dispatch(os.Args[1:]); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
+	}
+}
+
+// dispatch runs the command named by the first argument, or show if the
+// arguments start with a flag.
+func dispatch(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return runShow(args)
 	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q (run '%s help' for usage)", args[0], appName)
+	}
+	return cmd(args[1:])
+}
+
+// exitCode returns the process exit code for err.
+func exitCode(err error) int {
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return exitCodeError
+}
+
+// runShow executes the show command.
+func runShow(args []string) error {
+	if err := showFlags().Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	return run()
 }
 
 // run executes the main CLI logic and returns any error.
@@ -101,187 +288,521 @@ func run() error {
 		return fmt.Errorf(
 			"failed to load version information: %w\nMake sure %s exists or use -manifest to specify a different file",
 			err,
-			*manifestPath,
+			manifestName(*manifestPath),
 		)
 	}
 
 	info := version.MustGet()
 
-	// Handle different output modes
+	return render(os.Stdout, info, outputFormat())
+}
+
+// outputFormat returns the format selected by -format or, failing that, by
+// the output mode flags.
+func outputFormat() string {
 	switch {
+	case *format != "":
+		return *format
 	case *jsonOutput:
-		outputJSON(info)
+		return formatJSON
 	case *compactMode:
-		fmt.Println(info.String())
+		return formatCompact
 	case *schemasOnly:
-		outputSchemas(info)
+		return formatSchemas
 	case *apisOnly:
-		outputAPIs(info)
+		return formatAPIs
 	case *componentsOnly:
-		outputComponents(info)
+		return formatComponents
 	case *gitOnly:
-		outputGit(info)
+		return formatGit
 	case *buildOnly:
-		outputBuild(info)
+		return formatBuild
+	case *modulesOnly:
+		return formatModules
 	default:
-		outputFull(info)
+		return formatText
 	}
+}
 
-	return nil
+// loadInfo loads version information for commands that do not use the singleton.
+func loadInfo(manifest string) (*version.Info, error) {
+	info, err := version.New(
+		version.WithManifestPath(manifest),
+		version.WithGitInfo(),
+		version.WithBuildInfo(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to load version information: %w\nMake sure %s exists or use -manifest to specify a different file",
+			err,
+			manifestName(manifest),
+		)
+	}
+	return info, nil
+}
+
+// defaultManifests lists the manifests the loader looks for without -manifest
+const defaultManifests = version.ManifestFilenameYAML + ", " + version.ManifestFilenameYML + ", " +
+	version.ManifestFilenameJSON + " or " + version.ManifestFilenameTOML
+
+// defaultYAMLManifests lists the manifests bump and changelog -write look for
+// without -manifest
+const defaultYAMLManifests = version.ManifestFilenameYAML + " or " + version.ManifestFilenameYML
+
+// defaultManifestFiles are the manifests the loader looks for without
+// -manifest, in order
+var defaultManifestFiles = []string{
+	version.ManifestFilenameYAML, version.ManifestFilenameYML,
+	version.ManifestFilenameJSON, version.ManifestFilenameTOML,
+}
+
+// yamlManifestFiles are the default manifests that bump and changelog -write
+// can rewrite: only YAML manifests are supported
+var yamlManifestFiles = defaultManifestFiles[:2]
+
+// findManifest returns path, or if it is empty the first of names that
+// exists. Falls back to the first name, so errors name the expected file.
+func findManifest(path string, names []string) string {
+	if path != "" {
+		return path
+	}
+	for _, name := range names {
+		if _, err := os.Stat(name); err == nil {
+			return name
+		}
+	}
+	return names[0]
+}
+
+// manifestName names the manifest at path in messages, or the default
+// manifests if path is empty.
+func manifestName(path string) string {
+	if path == "" {
+		return defaultManifests
+	}
+	return path
 }
 
-// outputJSON outputs version info as JSON
-func outputJSON(info *version.Info) {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(info); err != nil {
-		fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
-		os.Exit(1)
+// parseInterspersed parses flags that may appear before, between or after
+// positional arguments and returns the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
 	}
 }
 
-// outputFull displays complete version information in human-readable format
-func outputFull(info *version.Info) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+// runGet executes the get command: it prints the value at a dotted path of
+// the JSON output (e.g. project.version, schemas.postgres_main, git.commit).
+// Objects and arrays are printed as JSON. A missing value exits with
+// exitCodeNotFound.
+func runGet(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	manifest := fs.String("manifest", "", "Path to the manifest file (default: the first of "+defaultManifests+")")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || positional[0] == "" {
+		return fmt.Errorf("get requires exactly one path, e.g. schemas.postgres_main")
+	}
+	path := positional[0]
 
-	fmt.Fprintf(w, "Project Information:\n")
-	fmt.Fprintf(w, "  Name:\t%s\n", info.Project.Name)
-	fmt.Fprintf(w, "  Version:\t%s\n", info.Project.Version)
-	fmt.Fprintf(w, "\n")
+	info, err := loadInfo(*manifest)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return err
+	}
 
-	fmt.Fprintf(w, "Git Information:\n")
-	fmt.Fprintf(w, "  Commit:\t%s\n", info.Git.Commit)
-	if info.Git.Tag != "" {
-		fmt.Fprintf(w, "  Tag:\t%s\n", info.Git.Tag)
+	value, ok := lookupPath(doc, path)
+	if !ok {
+		return &exitError{code: exitCodeNotFound, err: fmt.Errorf("%s not found", path)}
 	}
-	fmt.Fprintf(w, "  Tree State:\t%s\n", info.Git.TreeState)
-	if info.Git.CommitTime != "" {
-		fmt.Fprintf(w, "  Commit Time:\t%s\n", info.Git.CommitTime)
+	switch v := value.(type) {
+	case string, json.Number, bool:
+		_, err = fmt.Fprintln(stdout, v)
+		return err
+	default:
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
 	}
-	fmt.Fprintf(w, "\n")
+}
 
-	fmt.Fprintf(w, "Build Information:\n")
-	fmt.Fprintf(w, "  Time:\t%s\n", info.Build.Time)
-	if info.Build.User != "" {
-		fmt.Fprintf(w, "  User:\t%s\n", info.Build.User)
+// lookupPath resolves a dotted path in decoded JSON. Keys that contain dots
+// themselves (e.g. custom keys) match before the path is split further.
+func lookupPath(value interface{}, path string) (interface{}, bool) {
+	for path != "" {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok := obj[path]; ok {
+			return v, v != nil
+		}
+		key, rest, found := strings.Cut(path, ".")
+		if !found {
+			return nil, false
+		}
+		if value, ok = obj[key]; !ok {
+			return nil, false
+		}
+		path = rest
 	}
-	fmt.Fprintf(w, "  Go Version:\t%s\n", info.Build.GoVersion)
-	fmt.Fprintf(w, "\n")
+	return value, value != nil
+}
 
-	if len(info.GetSchemas()) > 0 {
-		fmt.Fprintf(w, "Database Schemas:\n")
-		printSortedMap(w, info.GetSchemas())
-		fmt.Fprintf(w, "\n")
+// runValidate executes the validate command: it loads the manifest in strict
+// mode and checks each "<dimension> <constraint>" argument, e.g.
+// "schemas.postgres_main >=45". All checks are reported; the command fails if
+// the manifest is invalid or any check fails.
+func runValidate(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	manifest := fs.String("manifest", "", "Path to the manifest file (default: the first of "+defaultManifests+")")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
 	}
 
-	if len(info.GetAPIs()) > 0 {
-		fmt.Fprintf(w, "API Versions:\n")
-		printSortedMap(w, info.GetAPIs())
-		fmt.Fprintf(w, "\n")
+	validators := make([]version.Validator, len(positional))
+	for i, arg := range positional {
+		if validators[i], err = constraintCheck(arg); err != nil {
+			return err
+		}
 	}
 
-	if len(info.GetComponents()) > 0 {
-		fmt.Fprintf(w, "Component Versions:\n")
-		printSortedMap(w, info.GetComponents())
-		fmt.Fprintf(w, "\n")
+	info, err := version.New(
+		version.WithManifestPath(*manifest),
+		version.WithStrictMode(),
+		version.WithoutGitInfo(),
+		version.WithoutBuildInfo(),
+	)
+	if err != nil {
+		return fmt.Errorf("%s is invalid: %w", manifestName(*manifest), err)
+	}
+	if *manifest != "" {
+		fmt.Fprintf(stdout, "%s: ", *manifest)
+	}
+	fmt.Fprintf(stdout, "valid manifest for %s %s\n", info.Project.Name, info.Project.Version)
+
+	failed := 0
+	for i, validator := range validators {
+		if err := validator.Validate(context.Background(), info); err != nil {
+			failed++
+			// Indent hints and other continuation lines under the check
+			fmt.Fprintf(stdout, "  FAIL  %s: %s\n", positional[i], strings.ReplaceAll(err.Error(), "\n", "\n        "))
+			continue
+		}
+		fmt.Fprintf(stdout, "  ok    %s\n", positional[i])
 	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(validators))
+	}
+	return nil
+}
 
-	if len(info.GetCustom()) > 0 {
-		fmt.Fprintf(w, "Custom Metadata:\n")
-		printSortedCustomMap(w, info.GetCustom())
+// constraintCheck turns a "<dimension> <constraint>" argument into a validator.
+// Dimensions are project (the project version) or <schemas|apis|components>.<name>.
+func constraintCheck(arg string) (version.Validator, error) {
+	dimension, constraint, _ := strings.Cut(strings.TrimSpace(arg), " ")
+	constraint = strings.TrimSpace(constraint)
+	if constraint == "" {
+		return nil, fmt.Errorf("invalid check %q: expected \"<dimension> <constraint>\", e.g. \"apis.rest_v1 ^1.15\"", arg)
 	}
 
-	w.Flush()
+	section, name, _ := strings.Cut(dimension, ".")
+	var validator version.Validator
+	var err error
+	switch {
+	case section == "project" && (name == "" || name == "version"):
+		var c *version.Constraint
+		if c, err = version.ParseConstraint(constraint); err == nil {
+			validator = version.ValidatorFunc(func(_ context.Context, info *version.Info) error {
+				v, err := version.ParseSemVer(info.Project.Version)
+				if err != nil {
+					return fmt.Errorf("invalid project version %q: %w", info.Project.Version, err)
+				}
+				return c.Validate(v)
+			})
+		}
+	case section == "schemas" && name != "":
+		validator, err = version.NewSchemaConstraintValidator(name, constraint)
+	case section == "apis" && name != "":
+		validator, err = version.NewAPIConstraintValidator(name, constraint)
+	case section == "components" && name != "":
+		validator, err = version.NewComponentConstraintValidator(name, constraint)
+	default:
+		return nil, fmt.Errorf("invalid check %q: dimension must be project or <schemas|apis|components>.<name>", arg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid check %q: %w", arg, err)
+	}
+	return validator, nil
 }
 
-// outputSchemas displays only database schema versions
-func outputSchemas(info *version.Info) {
-	if len(info.GetSchemas()) == 0 {
-		fmt.Println("No database schemas defined")
-		return
+// runInit executes the init command: it writes the annotated manifest
+// template, named after the module in the nearest go.mod.
+func runInit(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	name := fs.String("name", "", "Project name (default: detected from go.mod)")
+	manifest := fs.String("manifest", "versions.yaml", "Path of the manifest to create")
+	force := fs.Bool("force", false, "Overwrite an existing manifest")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	projectName := *name
+	if projectName == "" {
+		projectName = detectProjectName(filepath.Dir(*manifest))
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if *force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(*manifest, flags, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%s already exists (use -force to overwrite)", *manifest)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(manifesttmpl.Render(projectName)); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "Database Schemas:\n")
-	printSortedMap(w, info.GetSchemas())
-	w.Flush()
+	_, err = fmt.Fprintf(stdout, "Created %s for project %s\n", *manifest, projectName)
+	return err
 }
 
-// outputAPIs displays only API versions
-func outputAPIs(info *version.Info) {
-	if len(info.GetAPIs()) == 0 {
-		fmt.Println("No API versions defined")
-		return
+// detectProjectName names a project after the module path in the nearest
+// go.mod at or above dir, skipping a major version suffix (/v2). Without a
+// go.mod the directory name is used.
+func detectProjectName(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	for d := abs; ; d = filepath.Dir(d) {
+		if data, err := os.ReadFile(filepath.Join(d, "go.mod")); err == nil {
+			if name := moduleName(data); name != "" {
+				return name
+			}
+			break
+		}
+		if filepath.Dir(d) == d {
+			break
+		}
 	}
+	return filepath.Base(abs)
+}
+
+// moduleName returns the last element of the module path declared in go.mod
+// data, ignoring a major version suffix.
+func moduleName(gomod []byte) string {
+	for _, line := range strings.Split(string(gomod), "\n") {
+		line, _, _ = strings.Cut(line, "//")
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "module" {
+			continue
+		}
+		elems := strings.Split(strings.Trim(fields[1], "\"`"), "/")
+		name := elems[len(elems)-1]
+		if len(elems) > 1 && isMajorVersionSuffix(name) {
+			name = elems[len(elems)-2]
+		}
+		return name
+	}
+	return ""
+}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "API Versions:\n")
-	printSortedMap(w, info.GetAPIs())
-	w.Flush()
+// isMajorVersionSuffix reports whether s is a module major version suffix such as "v2".
+func isMajorVersionSuffix(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
 }
 
-// outputComponents displays only component versions
-func outputComponents(info *version.Info) {
-	if len(info.GetComponents()) == 0 {
-		fmt.Println("No component versions defined")
-		return
+// runSBOM executes the sbom mode: it writes a software bill of materials for
+// the manifest components and the Go modules compiled into this binary.
+func runSBOM(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("sbom", flag.ContinueOnError)
+	format := fs.String("format", string(version.SBOMFormatCycloneDX), "SBOM format: cyclonedx or spdx")
+	manifest := fs.String("manifest", "", "Path to the manifest file (default: the first of "+defaultManifests+")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	info, err := loadInfo(*manifest)
+	if err != nil {
+		return err
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "Component Versions:\n")
-	printSortedMap(w, info.GetComponents())
-	w.Flush()
+	sbom, err := info.SBOM(version.SBOMFormat(*format))
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(sbom)
+	return err
 }
 
-// outputGit displays only git information
-func outputGit(info *version.Info) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "Git Information:\n")
-	fmt.Fprintf(w, "  Commit:\t%s\n", info.Git.Commit)
-	if info.Git.Tag != "" {
-		fmt.Fprintf(w, "  Tag:\t%s\n", info.Git.Tag)
+// runInspect executes the inspect mode: it reads the version information of
+// another Go binary without executing it and prints it like the default mode.
+func runInspect(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "Output in JSON format")
+	compact := fs.Bool("compact", false, "Show compact single-line format")
+	outFormat := fs.String("format", "", "Output format name or Go template")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("inspect requires exactly one binary path, got %d arguments", fs.NArg())
+	}
+
+	info, err := version.Inspect(fs.Arg(0))
+	if err != nil {
+		return err
 	}
-	fmt.Fprintf(w, "  Tree State:\t%s\n", info.Git.TreeState)
-	if info.Git.CommitTime != "" {
-		fmt.Fprintf(w, "  Commit Time:\t%s\n", info.Git.CommitTime)
+
+	switch {
+	case *outFormat != "":
+		return render(stdout, info, *outFormat)
+	case *asJSON:
+		return render(stdout, info, formatJSON)
+	case *compact:
+		return render(stdout, info, formatCompact)
+	default:
+		return render(stdout, info, formatText)
 	}
-	w.Flush()
 }
 
-// outputBuild displays only build information
-func outputBuild(info *version.Info) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "Build Information:\n")
-	fmt.Fprintf(w, "  Time:\t%s\n", info.Build.Time)
-	if info.Build.User != "" {
-		fmt.Fprintf(w, "  User:\t%s\n", info.Build.User)
+// runLdflags executes the ldflags mode: it prints the -X flags injecting
+// git and build information of the working directory's repository.
+func runLdflags(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("ldflags", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	flags, err := version.ReadLdflags(version.GitSourceAuto)
+	if err != nil {
+		return err
 	}
-	fmt.Fprintf(w, "  Go Version:\t%s\n", info.Build.GoVersion)
-	w.Flush()
+	_, err = fmt.Fprintln(stdout, flags.String())
+	return err
 }
 
-// printSortedMap prints a map in sorted order by keys
-func printSortedMap(w *tabwriter.Writer, m map[string]string) {
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
+// runBuild executes the build mode: it runs go build with the arguments
+// following "--" and the ldflags of runLdflags injected. An -ldflags argument
+// given by the caller is kept and applied after the injected flags, so its -X
+// flags win. The exit code of go build is passed through.
+func runBuild(args []string) error {
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+
+	flags, err := version.ReadLdflags(version.GitSourceAuto)
+	if err != nil {
+		return err
+	}
+	ldflags, rest := mergeLdflags(flags.String(), args)
+
+	cmd := exec.Command(goCommand, append([]string{"build", "-ldflags", ldflags}, rest...)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var runErr *exec.ExitError
+		if errors.As(err, &runErr) {
+			return &exitError{code: runErr.ExitCode(), err: fmt.Errorf("go build failed: %w", err)}
+		}
+		return fmt.Errorf("failed to run go build: %w", err)
 	}
-	sort.Strings(keys)
+	return nil
+}
 
-	for _, k := range keys {
-		fmt.Fprintf(w, "  %s:\t%s\n", k, m[k])
+// mergeLdflags removes -ldflags arguments from args and appends their values
+// to injected. Both the "-ldflags value" and "-ldflags=value" forms (with one
+// or two dashes) are recognized.
+func mergeLdflags(injected string, args []string) (string, []string) {
+	ldflags := injected
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "ldflags" {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+		if value != "" {
+			ldflags += " " + value
+		}
 	}
+	return strings.TrimSpace(ldflags), rest
 }
 
-// printSortedCustomMap prints a custom metadata map in sorted order
-func printSortedCustomMap(w *tabwriter.Writer, m map[string]interface{}) {
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
+// runBump executes the bump mode: it bumps one version in the manifest in
+// place and prints the old and new version. Flags may precede or follow the
+// part argument.
+func runBump(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("bump", flag.ContinueOnError)
+	pre := fs.String("pre", "", "Prerelease identifier, e.g. rc (starts or switches the prerelease)")
+	dimension := fs.String("dimension", version.BumpDefaultDimension, "Version to bump: project or <schemas|apis|components>.<name>")
+	manifest := fs.String("manifest", "", "Path to the YAML manifest to bump (default: "+defaultYAMLManifests+
+		"; JSON and TOML manifests are not supported)")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return fmt.Errorf("bump requires a part: major, minor, patch or prerelease")
 	}
-	sort.Strings(keys)
+	if len(positional) > 1 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(positional[1:], " "))
+	}
+	part := version.BumpPart(positional[0])
 
-	for _, k := range keys {
-		fmt.Fprintf(w, "  %s:\t%v\n", k, m[k])
+	result, err := version.BumpManifest(findManifest(*manifest, yamlManifestFiles), *dimension, part, *pre)
+	if err != nil {
+		return err
 	}
+	_, err = fmt.Fprintf(stdout, "%s: %s -> %s\n", result.Dimension, result.Old, result.New)
+	return err
 }
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
-	"text/tabwriter"
 
 	"github.com/itsatony/go-version"
 )
@@ -23,7 +25,7 @@ project:
 
 // resetFlags resets all flag variables to their default values for testing
 func resetFlags() {
-	*manifestPath = "versions.yaml"
+	*manifestPath = ""
 	*jsonOutput = false
 	*compactMode = false
 	*schemasOnly = false
@@ -31,10 +33,30 @@ func resetFlags() {
 	*componentsOnly = false
 	*gitOnly = false
 	*buildOnly = false
+	*modulesOnly = false
+	*format = ""
 	*showHelp = false
 	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 }
 
+// renderStdout renders info in the given format to stdout
+func renderStdout(t *testing.T, info *version.Info, format string) {
+	t.Helper()
+	if err := render(os.Stdout, info, format); err != nil {
+		t.Errorf("render %q failed: %v", format, err)
+	}
+}
+
+// renderManifest loads an embedded manifest and renders it in the given format to stdout
+func renderManifest(t *testing.T, manifest, format string) {
+	t.Helper()
+	info, err := version.New(version.WithEmbedded([]byte(manifest)), version.WithoutGitInfo())
+	if err != nil {
+		t.Fatalf("load manifest failed: %v", err)
+	}
+	renderStdout(t, info, format)
+}
+
 // captureOutput runs a function and captures its stdout output
 func captureOutput(f func()) string {
 	old := os.Stdout
@@ -70,7 +92,7 @@ func TestOutputFull(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputFull(info)
+		renderStdout(t, info, formatText)
 	})
 
 	// Verify output contains expected sections
@@ -128,7 +150,7 @@ func TestOutputJSON(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputJSON(info)
+		renderStdout(t, info, formatJSON)
 	})
 
 	// Verify JSON is valid
@@ -197,7 +219,7 @@ func TestOutputSchemas(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputSchemas(info)
+		renderStdout(t, info, formatSchemas)
 	})
 
 	if !strings.Contains(output, "Database Schemas:") {
@@ -231,7 +253,7 @@ func TestOutputAPIs(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputAPIs(info)
+		renderStdout(t, info, formatAPIs)
 	})
 
 	if !strings.Contains(output, "API Versions:") {
@@ -262,7 +284,7 @@ func TestOutputComponents(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputComponents(info)
+		renderStdout(t, info, formatComponents)
 	})
 
 	if !strings.Contains(output, "Component Versions:") {
@@ -294,7 +316,7 @@ func TestOutputGit(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputGit(info)
+		renderStdout(t, info, formatGit)
 	})
 
 	if !strings.Contains(output, "Git Information:") {
@@ -326,7 +348,7 @@ func TestOutputBuild(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputBuild(info)
+		renderStdout(t, info, formatBuild)
 	})
 
 	if !strings.Contains(output, "Build Information:") {
@@ -366,7 +388,7 @@ func TestOutputSchemasEmpty(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputSchemas(info)
+		renderStdout(t, info, formatSchemas)
 	})
 
 	if !strings.Contains(output, "No database schemas defined") {
@@ -400,7 +422,7 @@ func TestOutputAPIsEmpty(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputAPIs(info)
+		renderStdout(t, info, formatAPIs)
 	})
 
 	if !strings.Contains(output, "No API versions defined") {
@@ -434,7 +456,7 @@ func TestOutputComponentsEmpty(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputComponents(info)
+		renderStdout(t, info, formatComponents)
 	})
 
 	if !strings.Contains(output, "No component versions defined") {
@@ -445,16 +467,14 @@ func TestOutputComponentsEmpty(t *testing.T) {
 func TestPrintSortedMap(t *testing.T) {
 	resetFlags()
 
-	testMap := map[string]string{
-		"zebra":  "3",
-		"alpha":  "1",
-		"middle": "2",
-	}
+	manifest := minimalManifestYAML + `schemas:
+  zebra: "3"
+  alpha: "1"
+  middle: "2"
+`
 
 	output := captureOutput(func() {
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		printSortedMap(w, testMap)
-		w.Flush()
+		renderManifest(t, manifest, formatSchemas)
 	})
 
 	// Verify alphabetical ordering
@@ -474,16 +494,14 @@ func TestPrintSortedMap(t *testing.T) {
 func TestPrintSortedCustomMap(t *testing.T) {
 	resetFlags()
 
-	testMap := map[string]interface{}{
-		"string_val": "test",
-		"int_val":    42,
-		"bool_val":   true,
-	}
+	manifest := minimalManifestYAML + `custom:
+  string_val: "test"
+  int_val: 42
+  bool_val: true
+`
 
 	output := captureOutput(func() {
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		printSortedCustomMap(w, testMap)
-		w.Flush()
+		renderManifest(t, manifest, formatText)
 	})
 
 	if !strings.Contains(output, "string_val") {
@@ -561,7 +579,7 @@ func TestMainJSONOutput(t *testing.T) {
 		); err == nil {
 			if *jsonOutput {
 				info := version.MustGet()
-				outputJSON(info)
+				renderStdout(t, info, formatJSON)
 			}
 		}
 	})
@@ -589,7 +607,7 @@ func TestOutputWithCustomMetadata(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputFull(info)
+		renderStdout(t, info, formatText)
 	})
 
 	// Check for custom metadata
@@ -649,7 +667,7 @@ func TestOutputGitWithTag(t *testing.T) {
 
 		info := version.MustGet()
 		// Check if git tag is available in output
-		outputGit(info)
+		renderStdout(t, info, formatGit)
 	})
 
 	// Should have git information section
@@ -675,7 +693,7 @@ func TestOutputBuildWithUser(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputBuild(info)
+		renderStdout(t, info, formatBuild)
 	})
 
 	// Should have build information
@@ -747,6 +765,13 @@ func TestAllOutputModes(t *testing.T) {
 			},
 			expected: "Build Information:",
 		},
+		{
+			name: "modules mode",
+			flagFunc: func() {
+				*modulesOnly = true
+			},
+			expected: "Modules:",
+		},
 	}
 
 	for _, tt := range tests {
@@ -772,25 +797,7 @@ func TestAllOutputModes(t *testing.T) {
 
 				info := version.MustGet()
 
-				// Simulate main's switch statement
-				switch {
-				case *jsonOutput:
-					outputJSON(info)
-				case *compactMode:
-					fmt.Println(info.String())
-				case *schemasOnly:
-					outputSchemas(info)
-				case *apisOnly:
-					outputAPIs(info)
-				case *componentsOnly:
-					outputComponents(info)
-				case *gitOnly:
-					outputGit(info)
-				case *buildOnly:
-					outputBuild(info)
-				default:
-					outputFull(info)
-				}
+				renderStdout(t, info, outputFormat())
 			})
 
 			if !strings.Contains(output, tt.expected) {
@@ -828,7 +835,7 @@ func TestOutputFullWithMinimalData(t *testing.T) {
 		}
 
 		info := version.MustGet()
-		outputFull(info)
+		renderStdout(t, info, formatText)
 	})
 
 	// Should have basic sections even without optional data
@@ -959,3 +966,1221 @@ func TestRunJSONMode(t *testing.T) {
 		t.Errorf("Expected valid JSON output: %v", err)
 	}
 }
+
+func TestRunSBOM(t *testing.T) {
+	testManifest := filepath.Join("testdata", "test-versions.yaml")
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "default cyclonedx", args: []string{"-manifest", testManifest}, expected: `"bomFormat": "CycloneDX"`},
+		{name: "spdx", args: []string{"-format", "spdx", "-manifest", testManifest}, expected: `"spdxVersion": "SPDX-2.3"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runSBOM(tt.args, &buf); err != nil {
+				t.Fatalf("runSBOM failed: %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.expected) {
+				t.Errorf("Expected output to contain %q", tt.expected)
+			}
+			if !json.Valid(buf.Bytes()) {
+				t.Error("Expected valid JSON output")
+			}
+		})
+	}
+}
+
+func TestRunSBOMErrors(t *testing.T) {
+	testManifest := filepath.Join("testdata", "test-versions.yaml")
+
+	var buf bytes.Buffer
+	if err := runSBOM([]string{"-format", "swid", "-manifest", testManifest}, &buf); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+	if err := runSBOM([]string{"-unknown-flag"}, &buf); err == nil {
+		t.Error("Expected error for unknown flag")
+	}
+}
+
+func TestRunInspect(t *testing.T) {
+	// The test binary itself is a Go binary linking go-version
+	binary, err := os.Executable()
+	if err != nil {
+		t.Skipf("cannot locate test binary: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "full", args: []string{binary}, expected: "Go Version:"},
+		{name: "json", args: []string{"-json", binary}, expected: `"go_version": "go`},
+		{name: "compact", args: []string{"-compact", binary}, expected: " ("},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runInspect(tt.args, &buf); err != nil {
+				t.Fatalf("runInspect failed: %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.expected) {
+				t.Errorf("Expected output to contain %q, got:\n%s", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestRunInspectErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no binary", args: nil},
+		{name: "too many arguments", args: []string{"a", "b"}},
+		{name: "not a go binary", args: []string{filepath.Join("testdata", "test-versions.yaml")}},
+		{name: "unknown flag", args: []string{"-unknown-flag", "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runInspect(tt.args, &buf); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestRunBump(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		contains string
+	}{
+		{name: "minor", args: []string{"minor"}, expected: "project.version: 1.0.0 -> 1.1.0\n", contains: `version: "1.1.0"`},
+		{name: "flags after part", args: []string{"prerelease", "-pre", "beta"}, expected: "project.version: 1.0.0 -> 1.0.1-beta.0\n", contains: `version: "1.0.1-beta.0"`},
+		{name: "flags before part", args: []string{"-dimension", "project", "major"}, expected: "project.version: 1.0.0 -> 2.0.0\n", contains: `version: "2.0.0"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "versions.yaml")
+			if err := os.WriteFile(path, []byte(minimalManifestYAML), 0o644); err != nil {
+				t.Fatalf("Failed to write manifest: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := runBump(append(tt.args, "-manifest", path), &buf); err != nil {
+				t.Fatalf("runBump failed: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Expected output %q, got %q", tt.expected, buf.String())
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read manifest: %v", err)
+			}
+			if !strings.Contains(string(data), tt.contains) {
+				t.Errorf("Expected manifest to contain %q, got:\n%s", tt.contains, data)
+			}
+		})
+	}
+}
+
+func TestRunBumpDefaultManifest(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "versions.yml")
+	if err := os.WriteFile(path, []byte(minimalManifestYAML), 0o644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	t.Chdir(dir)
+
+	var buf bytes.Buffer
+	if err := runBump([]string{"patch"}, &buf); err != nil {
+		t.Fatalf("runBump failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	if !strings.Contains(string(data), `version: "1.0.1"`) {
+		t.Errorf("Expected bumped versions.yml, got:\n%s", data)
+	}
+}
+
+func TestRunBumpErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "versions.yaml")
+	if err := os.WriteFile(path, []byte(minimalManifestYAML), 0o644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing part", args: []string{"-manifest", path}},
+		{name: "unknown part", args: []string{"micro", "-manifest", path}},
+		{name: "extra argument", args: []string{"minor", "patch", "-manifest", path}},
+		{name: "unknown dimension", args: []string{"minor", "-dimension", "apis.rest_v1", "-manifest", path}},
+		{name: "missing manifest", args: []string{"minor", "-manifest", filepath.Join(t.TempDir(), "missing.yaml")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runBump(tt.args, &buf); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	testManifest := filepath.Join("testdata", "test-versions.yaml")
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "flags alias show", args: []string{"-manifest", testManifest, "-compact"}, expected: "cli-test-app 1.2.3"},
+		{name: "show command", args: []string{"show", "-manifest", testManifest, "-schemas"}, expected: "postgres_main"},
+		{name: "get command", args: []string{"get", "apis.grpc", "-manifest", testManifest}, expected: "1.2.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			version.Reset()
+			defer version.Reset()
+
+			var err error
+			output := captureOutput(func() {
+				err = dispatch(tt.args)
+			})
+			if err != nil {
+				t.Fatalf("dispatch failed: %v", err)
+			}
+			if !strings.Contains(output, tt.expected) {
+				t.Errorf("Expected output to contain %q, got:\n%s", tt.expected, output)
+			}
+		})
+	}
+
+	if err := dispatch([]string{"deploy"}); err == nil || !strings.Contains(err.Error(), "unknown command") {
+		t.Errorf("Expected unknown command error, got: %v", err)
+	}
+}
+
+func TestRunGet(t *testing.T) {
+	testManifest := filepath.Join("testdata", "test-versions.yaml")
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "project version", path: "project.version", expected: "1.2.3\n"},
+		{name: "schema", path: "schemas.postgres_main", expected: "45\n"},
+		{name: "custom", path: "custom.region", expected: "us-test-1\n"},
+		{name: "object as json", path: "apis", expected: "{\n  \"grpc\": \"1.2.0\",\n  \"rest_v1\": \"1.15.0\"\n}\n"},
+		{name: "bool", path: "build.cgo_enabled", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runGet([]string{"-manifest", testManifest, tt.path}, &buf); err != nil {
+				t.Fatalf("runGet failed: %v", err)
+			}
+			if tt.expected == "" {
+				if got := buf.String(); got != "true\n" && got != "false\n" {
+					t.Errorf("Expected a boolean, got %q", got)
+				}
+				return
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestRunGetDefaultManifestLookup(t *testing.T) {
+	dir := t.TempDir()
+	manifest := `{"manifest_version": "1.0", "project": {"name": "json-app", "version": "2.0.0"}}`
+	if err := os.WriteFile(filepath.Join(dir, version.ManifestFilenameJSON), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	var buf bytes.Buffer
+	if err := runGet([]string{"project.name"}, &buf); err != nil {
+		t.Fatalf("runGet failed: %v", err)
+	}
+	if buf.String() != "json-app\n" {
+		t.Errorf("Expected the versions.json project, got %q", buf.String())
+	}
+}
+
+func TestRunGetErrors(t *testing.T) {
+	testManifest := filepath.Join("testdata", "test-versions.yaml")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+	}{
+		{name: "missing schema", args: []string{"schemas.mysql", "-manifest", testManifest}, wantCode: exitCodeNotFound},
+		{name: "missing section", args: []string{"nothing.here", "-manifest", testManifest}, wantCode: exitCodeNotFound},
+		{name: "path below scalar", args: []string{"project.version.major", "-manifest", testManifest}, wantCode: exitCodeNotFound},
+		{name: "no path", args: []string{"-manifest", testManifest}, wantCode: exitCodeError},
+		{name: "two paths", args: []string{"project.name", "project.version", "-manifest", testManifest}, wantCode: exitCodeError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runGet(tt.args, &buf)
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			if code := exitCode(err); code != tt.wantCode {
+				t.Errorf("Expected exit code %d, got %d", tt.wantCode, code)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("Expected no output, got %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestLookupPath(t *testing.T) {
+	doc := map[string]interface{}{
+		"custom": map[string]interface{}{"feature.flags": "on", "nested": map[string]interface{}{"key": "value"}},
+		"null":   nil,
+	}
+
+	tests := []struct {
+		path   string
+		want   interface{}
+		wantOK bool
+	}{
+		{path: "custom.feature.flags", want: "on", wantOK: true},
+		{path: "custom.nested.key", want: "value", wantOK: true},
+		{path: "custom.missing", wantOK: false},
+		{path: "null", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := lookupPath(doc, tt.path)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("lookupPath(%q) = %v, %v; want %v, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRunValidate(t *testing.T) {
+	testManifest := filepath.Join("testdata", "test-versions.yaml")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		expected []string
+	}{
+		{name: "manifest only", args: []string{"-manifest", testManifest}, expected: []string{"valid manifest for cli-test-app 1.2.3"}},
+		{
+			name:     "passing checks",
+			args:     []string{"-manifest", testManifest, "schemas.postgres_main >=45", "apis.rest_v1 ^1.15", "components.auth_service ~2.1.0", "project >=1.0 <2.0"},
+			expected: []string{"ok    schemas.postgres_main >=45", "ok    project >=1.0 <2.0"},
+		},
+		{
+			name:     "failing check",
+			args:     []string{"apis.rest_v1 ^2", "schemas.redis_cache >=3", "-manifest", testManifest},
+			wantErr:  true,
+			expected: []string{"FAIL  apis.rest_v1 ^2", "ok    schemas.redis_cache >=3"},
+		},
+		{name: "missing entry", args: []string{"-manifest", testManifest, "schemas.mysql >=1"}, wantErr: true, expected: []string{"FAIL  schemas.mysql >=1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runValidate(tt.args, &buf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runValidate error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunValidateErrors(t *testing.T) {
+	testManifest := filepath.Join("testdata", "test-versions.yaml")
+	invalidManifest := filepath.Join(t.TempDir(), "versions.yaml")
+	if err := os.WriteFile(invalidManifest, []byte("manifest_version: \"1.0\"\nproject:\n  name: \"\"\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing manifest", args: []string{"-manifest", filepath.Join(t.TempDir(), "missing.yaml")}},
+		{name: "invalid manifest", args: []string{"-manifest", invalidManifest}},
+		{name: "check without constraint", args: []string{"-manifest", testManifest, "apis.rest_v1"}},
+		{name: "unknown dimension", args: []string{"-manifest", testManifest, "custom.region >=1"}},
+		{name: "invalid constraint", args: []string{"-manifest", testManifest, "project >=>1"}},
+		{name: "invalid schema constraint", args: []string{"-manifest", testManifest, "schemas.postgres_main >>1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runValidate(tt.args, &buf); err == nil {
+				t.Errorf("Expected error, output:\n%s", buf.String())
+			}
+		})
+	}
+}
+
+func TestRunInit(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/acme/billing-api/v2\n\ngo 1.24\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	manifest := filepath.Join(dir, "config", "versions.yaml")
+	if err := os.Mkdir(filepath.Dir(manifest), 0o755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runInit([]string{"-manifest", manifest}, &buf); err != nil {
+		t.Fatalf("runInit failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "for project billing-api") {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+	info, err := version.New(version.WithManifestPath(manifest), version.WithStrictMode())
+	if err != nil {
+		t.Fatalf("Generated manifest does not load: %v", err)
+	}
+	if info.Project.Name != "billing-api" {
+		t.Errorf("Expected project name billing-api, got %q", info.Project.Name)
+	}
+
+	if err := runInit([]string{"-manifest", manifest}, &buf); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected already exists error, got: %v", err)
+	}
+
+	if err := runInit([]string{"-manifest", manifest, "-force", "-name", "renamed"}, &buf); err != nil {
+		t.Fatalf("runInit -force failed: %v", err)
+	}
+	data, err := os.ReadFile(manifest)
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	if !strings.Contains(string(data), `name: "renamed"`) {
+		t.Error("Expected -name to override the detected name")
+	}
+}
+
+func TestModuleName(t *testing.T) {
+	tests := []struct {
+		name  string
+		gomod string
+		want  string
+	}{
+		{name: "simple", gomod: "module github.com/acme/app\n", want: "app"},
+		{name: "major version", gomod: "module github.com/acme/app/v3\n", want: "app"},
+		{name: "quoted with comment", gomod: "// header\nmodule \"example.com/tool\" // comment\n", want: "tool"},
+		{name: "single element", gomod: "module app\n", want: "app"},
+		{name: "no module", gomod: "go 1.24\n", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moduleName([]byte(tt.gomod)); got != tt.want {
+				t.Errorf("moduleName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// loadRenderTestInfo loads the test manifest without git and build info and
+// sets a fixed commit, so rendered output is deterministic.
+func loadRenderTestInfo(t *testing.T) *version.Info {
+	t.Helper()
+	info, err := version.New(
+		version.WithManifestPath(filepath.Join("testdata", "test-versions.yaml")),
+		version.WithoutGitInfo(),
+		version.WithoutBuildInfo(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to load test manifest: %v", err)
+	}
+	info.Git.Commit = "0123456789abcdef0123456789abcdef01234567"
+	info.Git.ShortCommit = "0123456"
+	info.Git.TreeState = "clean"
+	return info
+}
+
+func TestRenderTemplates(t *testing.T) {
+	info := loadRenderTestInfo(t)
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{name: "version and short commit", format: "{{.Project.Version}}-{{.Git.Commit | short}}", expected: "1.2.3-0123456\n"},
+		{name: "short commit field", format: "{{.Git.ShortCommit}}", expected: "0123456\n"},
+		{name: "short of non-hash", format: "[{{short \"dev\"}}]", expected: "[]\n"},
+		{name: "trailing newline kept", format: "{{.Project.Name}}\n", expected: "cli-test-app\n"},
+		{name: "upper and lower", format: "{{upper .Project.Name}} {{lower \"ABC\"}}", expected: "CLI-TEST-APP abc\n"},
+		{name: "default for empty", format: "{{.Git.Tag | default \"untagged\"}}", expected: "untagged\n"},
+		{name: "default keeps value", format: "{{.Git.TreeState | default \"unknown\"}}", expected: "clean\n"},
+		{name: "project semver", format: "v{{.Project.SemVer.Major}}.{{.Project.SemVer.Minor}}", expected: "v1.2\n"},
+		{name: "semver func", format: "{{(semver .APIs.rest_v1).Minor}}", expected: "15\n"},
+		{name: "map entries", format: "{{.Schemas.postgres_main}} {{index .Custom \"region\"}}", expected: "45 us-test-1\n"},
+		{name: "json value", format: "{{json .Components}}", expected: `{"auth_service":"2.1.0","notification_service":"1.5.0"}` + "\n"},
+		{name: "info methods", format: "{{.Info.String}}", expected: info.String() + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := render(&buf, info, tt.format); err != nil {
+				t.Fatalf("render failed: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("render(%q) = %q, want %q", tt.format, buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderNamedFormats(t *testing.T) {
+	info := loadRenderTestInfo(t)
+
+	tests := []struct {
+		format   string
+		contains []string
+	}{
+		{format: formatEnv, contains: []string{"GOVERSION_PROJECT_VERSION=1.2.3\n", "GOVERSION_SCHEMAS_POSTGRES_MAIN=45\n", "GOVERSION_GIT_COMMIT=0123456789abcdef0123456789abcdef01234567\n"}},
+		{format: formatDotenv, contains: []string{"GOVERSION_PROJECT_NAME=\"cli-test-app\"\n", "GOVERSION_CUSTOM_REGION=\"us-test-1\"\n"}},
+		{format: formatGitHub, contains: []string{"project_version=1.2.3\n", "apis_rest_v1=1.15.0\n", "git_tree_state=clean\n"}},
+		{format: formatYAML, contains: []string{"project:\n  name: cli-test-app\n  version: 1.2.3\n", "schemas:\n  postgres_main: \"45\"\n"}},
+		{format: formatJSON, contains: []string{"\"project\": {\n    \"name\": \"cli-test-app\""}},
+		{format: formatCompact, contains: []string{info.String() + "\n"}},
+		{format: formatOTel, contains: []string{"service.name=cli-test-app,service.version=1.2.3,vcs.repository.ref.revision=0123456789abcdef0123456789abcdef01234567,", ",app.schema.postgres_main=45,"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := render(&buf, info, tt.format); err != nil {
+				t.Fatalf("render failed: %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, buf.String())
+				}
+			}
+			if tt.format == formatEnv && strings.Contains(buf.String(), "MODULES") {
+				t.Error("Expected modules to be left out of env output")
+			}
+		})
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	info := loadRenderTestInfo(t)
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{name: "unknown format", format: "xml", expected: "unknown format"},
+		{name: "parse error", format: "{{.Project.Version", expected: "invalid format template"},
+		{name: "missing field", format: "{{.Project.Nope}}", expected: "invalid format template"},
+		{name: "invalid semver", format: "{{(semver .Custom.region).Major}}", expected: "invalid format template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := render(&buf, info, tt.format)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got: %v", tt.expected, err)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("Expected no output on error, got %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		flagFunc func()
+		expected string
+	}{
+		{name: "default", flagFunc: func() {}, expected: formatText},
+		{name: "json", flagFunc: func() { *jsonOutput = true }, expected: formatJSON},
+		{name: "compact", flagFunc: func() { *compactMode = true }, expected: formatCompact},
+		{name: "format wins", flagFunc: func() { *jsonOutput = true; *format = formatEnv }, expected: formatEnv},
+		{name: "json before schemas", flagFunc: func() { *jsonOutput = true; *schemasOnly = true }, expected: formatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			defer resetFlags()
+			tt.flagFunc()
+			if got := outputFormat(); got != tt.expected {
+				t.Errorf("outputFormat() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunShowFormat(t *testing.T) {
+	resetFlags()
+	version.Reset()
+	defer version.Reset()
+	defer resetFlags()
+
+	var runErr error
+	output := captureOutput(func() {
+		runErr = dispatch([]string{"show", "-manifest", filepath.Join("testdata", "test-versions.yaml"), "-format", "{{.Project.Name}}@{{.Project.Version}}"})
+	})
+	if runErr != nil {
+		t.Fatalf("show -format failed: %v", runErr)
+	}
+	if output != "cli-test-app@1.2.3\n" {
+		t.Errorf("Unexpected output %q", output)
+	}
+}
+
+func TestFlattenVars(t *testing.T) {
+	info := loadRenderTestInfo(t)
+	info.Build.Tags = []string{"netgo", "osusergo"}
+
+	vars, err := flattenVars(newTemplateData(info))
+	if err != nil {
+		t.Fatalf("flattenVars failed: %v", err)
+	}
+
+	got := make(map[string]string, len(vars))
+	for i, v := range vars {
+		if i > 0 && vars[i-1].Name >= v.Name {
+			t.Errorf("Variables not sorted: %q before %q", vars[i-1].Name, v.Name)
+		}
+		got[v.Name] = v.Value
+	}
+
+	expected := map[string]string{
+		"project_name":            "cli-test-app",
+		"schemas_postgres_main":   "45",
+		"components_auth_service": "2.1.0",
+		"custom_environment":      "test",
+		"build_tags":              "netgo,osusergo",
+	}
+	for name, value := range expected {
+		if got[name] != value {
+			t.Errorf("%s = %q, want %q", name, got[name], value)
+		}
+	}
+}
+
+func TestJoinVarName(t *testing.T) {
+	tests := []struct {
+		prefix, key, want string
+	}{
+		{prefix: "", key: "project", want: "project"},
+		{prefix: "apis", key: "rest-v1", want: "apis_rest_v1"},
+		{prefix: "custom", key: "Build.ID", want: "custom_build_id"},
+	}
+
+	for _, tt := range tests {
+		if got := joinVarName(tt.prefix, tt.key); got != tt.want {
+			t.Errorf("joinVarName(%q, %q) = %q, want %q", tt.prefix, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestDotenvQuote(t *testing.T) {
+	tests := []struct {
+		value, want string
+	}{
+		{value: "1.2.3", want: `"1.2.3"`},
+		{value: `say "hi"`, want: `"say \"hi\""`},
+		{value: "$HOME", want: `"\$HOME"`},
+		{value: "a\nb", want: `"a\nb"`},
+		{value: `C:\dir`, want: `"C:\\dir"`},
+	}
+
+	for _, tt := range tests {
+		if got := dotenvQuote(tt.value); got != tt.want {
+			t.Errorf("dotenvQuote(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestGithubOutputLine(t *testing.T) {
+	tests := []struct {
+		name, key, value, want string
+	}{
+		{name: "single line", key: "version", value: "1.2.3", want: "version=1.2.3\n"},
+		{name: "multi line", key: "notes", value: "a\nb", want: "notes<<GOVERSION_EOF\na\nb\nGOVERSION_EOF\n"},
+		{name: "delimiter in value", key: "notes", value: "x\nGOVERSION_EOF", want: "notes<<GOVERSION_EOF_\nx\nGOVERSION_EOF\nGOVERSION_EOF_\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := githubOutputLine(tt.key, tt.value); got != tt.want {
+				t.Errorf("githubOutputLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunLdflags(t *testing.T) {
+	t.Setenv(version.EnvSourceDateEpoch, "1700000000")
+
+	var buf bytes.Buffer
+	if err := runLdflags(nil, &buf); err != nil {
+		t.Fatalf("runLdflags failed: %v", err)
+	}
+	output := buf.String()
+	for _, want := range []string{
+		"-X " + version.PackageImportPath + ".BuildTime=2023-11-14T22:13:20Z",
+		"-X " + version.PackageImportPath + ".GitCommit=",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+	if strings.Count(output, "\n") != 1 {
+		t.Errorf("Expected a single line, got: %q", output)
+	}
+}
+
+func TestRunLdflagsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		epoch string
+	}{
+		{name: "unexpected argument", args: []string{"./cmd/app"}},
+		{name: "invalid SOURCE_DATE_EPOCH", epoch: "tomorrow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(version.EnvSourceDateEpoch, tt.epoch)
+			var buf bytes.Buffer
+			if err := runLdflags(tt.args, &buf); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestMergeLdflags(t *testing.T) {
+	const injected = "-X a.B=c"
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantLdflags string
+		wantRest    []string
+	}{
+		{name: "no ldflags", args: []string{"-o", "bin/app", "./cmd/app"}, wantLdflags: injected, wantRest: []string{"-o", "bin/app", "./cmd/app"}},
+		{name: "separate value", args: []string{"-o", "bin/app", "-ldflags", "-s -w", "./cmd/app"}, wantLdflags: injected + " -s -w", wantRest: []string{"-o", "bin/app", "./cmd/app"}},
+		{name: "equals value", args: []string{"-ldflags=-s", "."}, wantLdflags: injected + " -s", wantRest: []string{"."}},
+		{name: "double dash", args: []string{"--ldflags", "-X a.B=d", "."}, wantLdflags: injected + " -X a.B=d", wantRest: []string{"."}},
+		{name: "repeated", args: []string{"-ldflags=-s", "-ldflags=-w"}, wantLdflags: injected + " -s -w", wantRest: []string{}},
+		{name: "empty value", args: []string{"-ldflags=", "."}, wantLdflags: injected, wantRest: []string{"."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ldflags, rest := mergeLdflags(injected, tt.args)
+			if ldflags != tt.wantLdflags {
+				t.Errorf("ldflags = %q, want %q", ldflags, tt.wantLdflags)
+			}
+			if strings.Join(rest, "|") != strings.Join(tt.wantRest, "|") {
+				t.Errorf("rest = %q, want %q", rest, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestRunBuild(t *testing.T) {
+	// testing.Short cannot be used here: resetFlags replaces flag.CommandLine
+	if _, err := exec.LookPath(goCommand); err != nil {
+		t.Skip("go command not available")
+	}
+	t.Setenv(version.EnvSourceDateEpoch, "1700000000")
+
+	out := filepath.Join(t.TempDir(), "go-version")
+	if err := runBuild([]string{"--", "-o", out, "-ldflags", "-X " + version.PackageImportPath + ".BuildUser=override", "."}); err != nil {
+		t.Fatalf("runBuild failed: %v", err)
+	}
+
+	info, err := version.Inspect(out)
+	if err != nil {
+		t.Fatalf("Failed to inspect built binary: %v", err)
+	}
+	if info.Build.Time != "2023-11-14T22:13:20Z" {
+		t.Errorf("Expected injected build time, got %q", info.Build.Time)
+	}
+	if info.Build.User != "override" {
+		t.Errorf("Expected caller's -ldflags to win, got build user %q", info.Build.User)
+	}
+
+	err = runBuild([]string{"--", "-o", out, "./does-not-exist"})
+	if err == nil {
+		t.Fatal("Expected go build to fail")
+	}
+	if code := exitCode(err); code == 0 {
+		t.Errorf("Expected non-zero exit code, got %d", code)
+	}
+}
+
+const diffOldManifestYAML = `manifest_version: "1.0"
+project:
+  name: "diff-app"
+  version: "1.4.2"
+schemas:
+  main: "45"
+apis:
+  rest: "v1.15"
+  legacy: "1.0.0"
+`
+
+const diffNewManifestYAML = `manifest_version: "1.0"
+project:
+  name: "diff-app"
+  version: "1.5.0"
+schemas:
+  main: "44"
+apis:
+  rest: "v1.16"
+  graphql: "0.1.0"
+`
+
+// writeDiffManifests writes the old and new diff test manifests and returns their paths
+func writeDiffManifests(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.yaml")
+	newPath := filepath.Join(dir, "new.yaml")
+	if err := os.WriteFile(oldPath, []byte(diffOldManifestYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newPath, []byte(diffNewManifestYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return oldPath, newPath
+}
+
+func TestRunDiff(t *testing.T) {
+	oldPath, newPath := writeDiffManifests(t)
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name: "text",
+			args: []string{oldPath, newPath},
+			expected: []string{
+				"  upgraded    project.version  1.4.2 -> 1.5.0 (minor)\n",
+				"  downgraded  schemas.main     45 -> 44 (major)\n",
+				"  added       apis.graphql     0.1.0\n",
+				"  removed     apis.legacy      1.0.0\n",
+			},
+		},
+		{
+			name:     "json",
+			args:     []string{"-format", "json", oldPath, newPath},
+			expected: []string{`"kind": "downgraded"`, `"bump": "minor"`},
+		},
+		{
+			name: "markdown",
+			args: []string{oldPath, newPath, "-format", "markdown"},
+			expected: []string{
+				"| Change | Dimension | Name | Old | New | Bump |\n",
+				"| added | apis | graphql | - | 0.1.0 | - |\n",
+			},
+		},
+		{name: "no changes", args: []string{oldPath, oldPath}, expected: []string{"No changes\n"}},
+		{name: "no changes json", args: []string{"-format", "json", oldPath, oldPath}, expected: []string{"[]\n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runDiff(tt.args, &buf); err != nil {
+				t.Fatalf("runDiff failed: %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunDiffFailOnDowngrade(t *testing.T) {
+	oldPath, newPath := writeDiffManifests(t)
+
+	var buf bytes.Buffer
+	err := runDiff([]string{"-fail-on-downgrade", oldPath, newPath}, &buf)
+	if code := exitCode(err); err == nil || code != exitCodeDowngrade {
+		t.Fatalf("Expected exit code %d, got %d (%v)", exitCodeDowngrade, code, err)
+	}
+	if !strings.Contains(buf.String(), "downgraded") {
+		t.Error("Expected changes to be printed before failing")
+	}
+
+	// Reversed, the project version goes down; the double-dash form works too
+	if err := runDiff([]string{"--fail-on-downgrade", newPath, oldPath}, &buf); exitCode(err) != exitCodeDowngrade {
+		t.Fatalf("Expected reverse diff to fail, got %v", err)
+	}
+	if err := runDiff([]string{"-fail-on-downgrade", oldPath, oldPath}, &buf); err != nil {
+		t.Errorf("Expected no error without downgrades, got %v", err)
+	}
+}
+
+func TestRunDiffPayloads(t *testing.T) {
+	oldPath, newPath := writeDiffManifests(t)
+	deployed, err := version.New(version.WithManifestPath(oldPath), version.WithoutGitInfo(), version.WithoutBuildInfo())
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(deployed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	payloadPath := filepath.Join(t.TempDir(), "deployed.json")
+	if err := os.WriteFile(payloadPath, payload, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "url against manifest flag", args: []string{"-url", server.URL + "/version", "-manifest", newPath}},
+		{name: "url against positional manifest", args: []string{"-url", server.URL + "/version", newPath}},
+		{name: "url operand", args: []string{server.URL + "/version", newPath}},
+		{name: "saved payload", args: []string{payloadPath, newPath}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runDiff(tt.args, &buf); err != nil {
+				t.Fatalf("runDiff failed: %v", err)
+			}
+			if !strings.Contains(buf.String(), "project.version  1.4.2 -> 1.5.0 (minor)") {
+				t.Errorf("Unexpected output:\n%s", buf.String())
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := runDiff([]string{"-url", server.URL + "/missing", newPath}, &buf); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected 404 error, got %v", err)
+	}
+
+	// Without -manifest the default lookup applies, not only versions.yaml
+	data, err := os.ReadFile(newPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "versions.yml"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	buf.Reset()
+	if err := runDiff([]string{"-url", server.URL + "/version"}, &buf); err != nil {
+		t.Fatalf("runDiff with default manifest failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "project.version  1.4.2 -> 1.5.0 (minor)") {
+		t.Errorf("Unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestRunDiffErrors(t *testing.T) {
+	oldPath, newPath := writeDiffManifests(t)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no arguments", args: nil},
+		{name: "one manifest", args: []string{oldPath}},
+		{name: "url and two manifests", args: []string{"-url", "http://localhost/version", oldPath, newPath}},
+		{name: "missing file", args: []string{oldPath, filepath.Join(t.TempDir(), "missing.yaml")}},
+		{name: "unknown format", args: []string{"-format", "xml", oldPath, newPath}},
+		{name: "invalid url", args: []string{"-url", "http://[::1", newPath}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := runDiff(tt.args, &buf); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestIsInfoPayload(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		data string
+		want bool
+	}{
+		{name: "payload", path: "deployed.json", data: `{"project": {"name": "a", "version": "1.0.0"}, "git": {}}`, want: true},
+		{name: "payload without extension", path: "deployed", data: ` {"project": {}}`, want: true},
+		{name: "json manifest", path: "versions.json", data: `{"manifest_version": "1.0", "project": {}}`, want: false},
+		{name: "yaml manifest", path: "versions.yaml", data: "manifest_version: \"1.0\"\n", want: false},
+		{name: "invalid json", path: "broken.json", data: `{"project":`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isInfoPayload(tt.path, []byte(tt.data)); got != tt.want {
+				t.Errorf("isInfoPayload() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newChangelogRepo creates a git repository with a tagged release and the
+// given commits after it, changes into it and returns the manifest path.
+// Skipped when git is unavailable.
+func newChangelogRepo(t *testing.T, messages ...string) string {
+	t.Helper()
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command(gitPath, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	manifest := filepath.Join(dir, "versions.yaml")
+	if err := os.WriteFile(manifest, []byte(minimalManifestYAML), 0o644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	git("init", "-q", "-b", "main")
+	git("add", "versions.yaml")
+	git("commit", "-q", "-m", "chore: release 1.0.0")
+	git("tag", "v1.0.0")
+	for _, message := range messages {
+		git("commit", "-q", "--allow-empty", "-m", message)
+	}
+	t.Chdir(dir)
+	return manifest
+}
+
+func TestRunChangelog(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		args     []string
+		expected []string
+		notes    string
+	}{
+		{
+			name:     "features",
+			messages: []string{"fix(loader): trim tags", "feat: add changelog command", "docs: explain"},
+			expected: []string{"## [1.1.0] - ", "### Added\n- add changelog command\n", "### Fixed\n- **loader:** trim tags\n"},
+			notes:    "Suggested bump: minor (1.0.0 -> 1.1.0, 3 commits since v1.0.0)\n",
+		},
+		{
+			name:     "breaking",
+			messages: []string{"feat(api)!: drop v1"},
+			expected: []string{"## [2.0.0] - ", "- **BREAKING:** **api:** drop v1\n"},
+			notes:    "Suggested bump: major (1.0.0 -> 2.0.0, 1 commits since v1.0.0)\n",
+		},
+		{
+			name:     "bump override",
+			messages: []string{"fix: typo"},
+			args:     []string{"-bump", "minor"},
+			expected: []string{"## [1.1.0] - ", "### Fixed\n- typo\n"},
+			notes:    "Suggested bump: minor (1.0.0 -> 1.1.0, 1 commits since v1.0.0)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := newChangelogRepo(t, tt.messages...)
+
+			var stdout, stderr bytes.Buffer
+			if err := runChangelog(append(tt.args, "-manifest", manifest), &stdout, &stderr); err != nil {
+				t.Fatalf("runChangelog failed: %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, stdout.String())
+				}
+			}
+			if stderr.String() != tt.notes {
+				t.Errorf("Expected %q on stderr, got %q", tt.notes, stderr.String())
+			}
+			if _, err := os.Stat(version.ChangelogDefaultPath); !os.IsNotExist(err) {
+				t.Error("Expected no changelog to be written without -write")
+			}
+		})
+	}
+}
+
+func TestRunChangelogWrite(t *testing.T) {
+	manifest := newChangelogRepo(t, "feat: add changelog command")
+
+	var stdout, stderr bytes.Buffer
+	if err := runChangelog([]string{"-write", "-manifest", manifest}, &stdout, &stderr); err != nil {
+		t.Fatalf("runChangelog failed: %v", err)
+	}
+	expected := "project.version: 1.0.0 -> 1.1.0 (minor)\nUpdated CHANGELOG.md\n"
+	if stdout.String() != expected {
+		t.Errorf("Expected output %q, got %q", expected, stdout.String())
+	}
+
+	data, err := os.ReadFile(manifest)
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	if !strings.Contains(string(data), `version: "1.1.0"`) {
+		t.Errorf("Expected bumped manifest, got:\n%s", data)
+	}
+	data, err = os.ReadFile(version.ChangelogDefaultPath)
+	if err != nil {
+		t.Fatalf("Failed to read changelog: %v", err)
+	}
+	if !strings.HasPrefix(string(data), version.ChangelogHeader) || !strings.Contains(string(data), "## [1.1.0] - ") {
+		t.Errorf("Expected new changelog with the 1.1.0 section, got:\n%s", data)
+	}
+
+	// A second release from the same commits gets the next version
+	stdout.Reset()
+	if err := runChangelog([]string{"-write", "-manifest", manifest, "-bump", "patch"}, &stdout, &stderr); err != nil {
+		t.Fatalf("second runChangelog failed: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "1.1.0 -> 1.1.1") {
+		t.Errorf("Expected second bump, got %q", stdout.String())
+	}
+}
+
+func TestRunChangelogDefaultManifest(t *testing.T) {
+	manifest := newChangelogRepo(t, "fix: typo")
+	yml := filepath.Join(filepath.Dir(manifest), "versions.yml")
+	if err := os.Rename(manifest, yml); err != nil {
+		t.Fatalf("Failed to rename manifest: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := runChangelog([]string{"-write"}, &stdout, &stderr); err != nil {
+		t.Fatalf("runChangelog failed: %v", err)
+	}
+	data, err := os.ReadFile(yml)
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	if !strings.Contains(string(data), `version: "1.0.1"`) {
+		t.Errorf("Expected bumped versions.yml, got:\n%s", data)
+	}
+}
+
+func TestRunChangelogErrors(t *testing.T) {
+	manifest := newChangelogRepo(t, "fix: typo")
+	released := filepath.Join(t.TempDir(), "released.md")
+	if err := os.WriteFile(released, []byte("## [1.0.1] - 2025-01-01\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write changelog: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "unknown bump", args: []string{"-bump", "micro", "-manifest", manifest}},
+		{name: "extra argument", args: []string{"minor", "-manifest", manifest}},
+		{name: "missing manifest", args: []string{"-manifest", filepath.Join(t.TempDir(), "missing.yaml")}},
+		{name: "already released", args: []string{"-write", "-changelog", released, "-manifest", manifest}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if err := runChangelog(tt.args, &stdout, &stderr); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+
+	data, err := os.ReadFile(manifest)
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	if string(data) != minimalManifestYAML {
+		t.Errorf("Expected manifest to be left untouched, got:\n%s", data)
+	}
+
+	// HEAD is the tagged release
+	var stdout, stderr bytes.Buffer
+	if err := runChangelog([]string{"-manifest", newChangelogRepo(t)}, &stdout, &stderr); err == nil {
+		t.Error("Expected error without commits since the tag")
+	}
+}
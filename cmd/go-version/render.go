@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/itsatony/go-version"
+)
+
+// Named output formats
+const (
+	formatText       = "text"
+	formatCompact    = "compact"
+	formatJSON       = "json"
+	formatYAML       = "yaml"
+	formatEnv        = "env"
+	formatDotenv     = "dotenv"
+	formatGitHub     = "github-output"
+	formatSchemas    = "schemas"
+	formatAPIs       = "apis"
+	formatComponents = "components"
+	formatGit        = "git"
+	formatBuild      = "build"
+	formatModules    = "modules"
+	formatOTel       = "otel"
+)
+
+// githubOutputDelimiter delimits multi-line values in the github-output format
+const githubOutputDelimiter = "GOVERSION_EOF"
+
+// namedFormats are the named output formats. Text formats are rendered by
+// Info.WriteText, either in full or limited to section; the others are
+// templates defined in formatTemplates.
+var namedFormats = map[string]struct {
+	text    bool
+	section version.InfoSection
+}{
+	formatText:       {text: true},
+	formatCompact:    {},
+	formatJSON:       {},
+	formatYAML:       {},
+	formatEnv:        {},
+	formatDotenv:     {},
+	formatGitHub:     {},
+	formatSchemas:    {text: true, section: version.SectionSchemas},
+	formatAPIs:       {text: true, section: version.SectionAPIs},
+	formatComponents: {text: true, section: version.SectionComponents},
+	formatGit:        {text: true, section: version.SectionGit},
+	formatBuild:      {text: true, section: version.SectionBuild},
+	formatModules:    {text: true, section: version.SectionModules},
+	formatOTel:       {},
+}
+
+// formatTemplates defines the template-based named formats. Template lines
+// start with "{{-" so that only explicit strings produce newlines.
+var formatTemplates = template.Must(template.New("formats").Funcs(templateFuncs).Parse(`
+{{- define "compact"}}{{.Info}}{{"\n"}}{{end}}
+
+{{- define "json"}}{{jsonIndent .}}{{"\n"}}{{end}}
+
+{{- define "yaml"}}{{yaml .}}{{end}}
+
+{{- define "env"}}
+{{- range vars .}}` + version.DefaultEnvOverridePrefix + `_{{upper .Name}}={{.Value}}{{"\n"}}{{end}}
+{{- end}}
+
+{{- define "dotenv"}}
+{{- range vars .}}` + version.DefaultEnvOverridePrefix + `_{{upper .Name}}={{dotenv .Value}}{{"\n"}}{{end}}
+{{- end}}
+
+{{- define "otel"}}{{.Info.ResourceAttributes ""}}{{"\n"}}{{end}}
+
+{{- define "github-output"}}{{range vars .}}{{githubOutput .Name .Value}}{{end}}{{end}}
+`))
+
+// templateData is the value output templates are executed with. Its fields
+// mirror the JSON output; Info gives access to all Info methods.
+type templateData struct {
+	Project     version.ProjectVersion
+	Environment string
+	Git         version.GitInfo
+	Build       version.BuildInfo
+	Schemas     map[string]string
+	APIs        map[string]string
+	Components  map[string]string
+	Custom      map[string]interface{}
+	Modules     []version.Module
+	Info        *version.Info
+}
+
+// MarshalJSON encodes the data like the JSON output, so {{json .}} matches -json.
+func (d templateData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Info)
+}
+
+// newTemplateData builds the template data for info.
+func newTemplateData(info *version.Info) templateData {
+	return templateData{
+		Project:     info.Project,
+		Environment: info.Environment(),
+		Git:         info.Git,
+		Build:       info.Build,
+		Schemas:     info.GetSchemas(),
+		APIs:        info.GetAPIs(),
+		Components:  info.GetComponents(),
+		Custom:      info.GetCustom(),
+		Modules:     info.GetModules(),
+		Info:        info,
+	}
+}
+
+// templateFuncs are available in named formats and -format templates.
+var templateFuncs = template.FuncMap{
+	"short":        version.ShortCommit,
+	"upper":        strings.ToUpper,
+	"lower":        strings.ToLower,
+	"join":         strings.Join,
+	"default":      defaultValue,
+	"json":         toJSON,
+	"jsonIndent":   toJSONIndent,
+	"yaml":         toYAML,
+	"semver":       version.ParseSemVer,
+	"vars":         flattenVars,
+	"dotenv":       dotenvQuote,
+	"githubOutput": githubOutputLine,
+}
+
+// render writes info in the given format: the name of a named format or a
+// text/template such as '{{.Project.Version}}-{{.Git.Commit | short}}'.
+// Output of custom templates always ends with a newline.
+func render(w io.Writer, info *version.Info, format string) error {
+	if format == "" {
+		format = formatText
+	}
+	data := newTemplateData(info)
+
+	if named, ok := namedFormats[format]; ok {
+		if named.text {
+			return info.WriteText(w, named.section)
+		}
+		return formatTemplates.ExecuteTemplate(w, format, data)
+	}
+
+	if !strings.Contains(format, "{{") {
+		return fmt.Errorf("unknown format %q: use one of %s or a Go template", format, strings.Join(formatNames(), ", "))
+	}
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(format)
+	if err != nil {
+		return fmt.Errorf("invalid format template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("invalid format template: %w", err)
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// formatNames returns the sorted names of the named formats.
+func formatNames() []string {
+	names := make([]string, 0, len(namedFormats))
+	for name := range namedFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// defaultValue returns value, or def if value is empty: {{.Git.Tag | default "untagged"}}.
+func defaultValue(def, value interface{}) interface{} {
+	if value == nil || fmt.Sprint(value) == "" {
+		return def
+	}
+	return value
+}
+
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+func toJSONIndent(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	return string(data), err
+}
+
+// toYAML renders v like the yaml format and /version?format=yaml.
+func toYAML(v interface{}) (string, error) {
+	data, err := version.MarshalYAML(v)
+	return string(data), err
+}
+
+// variable is a flattened output value for the env, dotenv and github-output formats.
+type variable struct {
+	// Name is the lower snake case path, e.g. "schemas_postgres_main"
+	Name  string
+	Value string
+}
+
+// flattenVars flattens the JSON output into variables sorted by name. Nested
+// objects join their keys with "_", lists of scalars are comma-separated.
+// Modules and override sources are left out.
+func flattenVars(data templateData) ([]variable, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	delete(doc, version.DimensionModules)
+	delete(doc, version.FieldPathOverrides)
+
+	var vars []variable
+	var walk func(prefix string, value interface{})
+	walk = func(prefix string, value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, child := range v {
+				walk(joinVarName(prefix, key), child)
+			}
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				switch item.(type) {
+				case map[string]interface{}, []interface{}:
+					return
+				}
+				items = append(items, fmt.Sprint(item))
+			}
+			vars = append(vars, variable{Name: prefix, Value: strings.Join(items, ",")})
+		case nil:
+		default:
+			vars = append(vars, variable{Name: prefix, Value: fmt.Sprint(v)})
+		}
+	}
+	walk("", doc)
+
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+	return vars, nil
+}
+
+// joinVarName appends key to a variable name, replacing characters other
+// than letters and digits with "_".
+func joinVarName(prefix, key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '_'
+		}
+	}, key)
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
+
+// dotenvQuote double-quotes a value for .env files.
+func dotenvQuote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// githubOutputLine formats one $GITHUB_OUTPUT entry, using the heredoc form
+// for multi-line values.
+func githubOutputLine(name, value string) string {
+	if !strings.ContainsAny(value, "\r\n") {
+		return name + "=" + value + "\n"
+	}
+	delimiter := githubOutputDelimiter
+	for strings.Contains(value, delimiter) {
+		delimiter += "_"
+	}
+	return name + "<<" + delimiter + "\n" + value + "\n" + delimiter + "\n"
+}
@@ -0,0 +1,151 @@
+package version
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const diffOldManifest = `manifest_version: "1.0"
+project:
+  name: "diff-app"
+  version: "1.4.2"
+schemas:
+  postgres_main: "45"
+  redis: "3"
+apis:
+  rest_v1: "v1.15"
+  grpc: "2.0.0-rc.1"
+  legacy: "1.0.0"
+components:
+  worker: "latest"
+  cache: "1.0.0+build.1"
+`
+
+const diffNewManifest = `manifest_version: "1.0"
+project:
+  name: "diff-app"
+  version: "1.5.0"
+schemas:
+  postgres_main: "47"
+  redis: "3"
+apis:
+  rest_v1: "v1.14.9"
+  grpc: "2.0.0"
+  graphql: "0.1.0"
+components:
+  worker: "edge"
+  cache: "1.0.0+build.2"
+`
+
+func loadDiffInfo(t *testing.T, manifest string, modules ...Module) *Info {
+	t.Helper()
+	info, err := New(WithEmbedded([]byte(manifest)), WithoutGitInfo(), WithoutBuildInfo())
+	require.NoError(t, err)
+	info.modules = modules
+	return info
+}
+
+func TestDiff(t *testing.T) {
+	oldInfo := loadDiffInfo(t, diffOldManifest,
+		Module{Path: "go.uber.org/zap", Version: "v1.26.0"},
+		Module{Path: "golang.org/x/net", Version: "v0.20.0"},
+		Module{Path: "example.com/fork", Version: "v1.0.0"},
+	)
+	newInfo := loadDiffInfo(t, diffNewManifest,
+		Module{Path: "go.uber.org/zap", Version: "v1.27.0"},
+		Module{Path: "example.com/fork", Version: "v1.0.0", Replace: &Module{Path: "../fork", Version: "v0.9.0"}},
+		Module{Path: "gopkg.in/yaml.v3", Version: "v3.0.1"},
+	)
+
+	expected := Changes{
+		{Dimension: DimensionProject, Name: "version", Kind: ChangeUpgraded, Old: "1.4.2", New: "1.5.0", Bump: BumpMinor},
+		{Dimension: DimensionSchemas, Name: "postgres_main", Kind: ChangeUpgraded, Old: "45", New: "47", Bump: BumpMajor},
+		{Dimension: DimensionAPIs, Name: "graphql", Kind: ChangeAdded, New: "0.1.0"},
+		{Dimension: DimensionAPIs, Name: "grpc", Kind: ChangeUpgraded, Old: "2.0.0-rc.1", New: "2.0.0", Bump: BumpPrerelease},
+		{Dimension: DimensionAPIs, Name: "legacy", Kind: ChangeRemoved, Old: "1.0.0"},
+		{Dimension: DimensionAPIs, Name: "rest_v1", Kind: ChangeDowngraded, Old: "v1.15", New: "v1.14.9", Bump: BumpMinor},
+		{Dimension: DimensionComponents, Name: "cache", Kind: ChangeModified, Old: "1.0.0+build.1", New: "1.0.0+build.2"},
+		{Dimension: DimensionComponents, Name: "worker", Kind: ChangeModified, Old: "latest", New: "edge"},
+		{Dimension: DimensionModules, Name: "example.com/fork", Kind: ChangeDowngraded, Old: "v1.0.0", New: "v0.9.0", Bump: BumpMajor},
+		{Dimension: DimensionModules, Name: "go.uber.org/zap", Kind: ChangeUpgraded, Old: "v1.26.0", New: "v1.27.0", Bump: BumpMinor},
+		{Dimension: DimensionModules, Name: "golang.org/x/net", Kind: ChangeRemoved, Old: "v0.20.0"},
+		{Dimension: DimensionModules, Name: "gopkg.in/yaml.v3", Kind: ChangeAdded, New: "v3.0.1"},
+	}
+
+	changes := Diff(oldInfo, newInfo)
+	assert.Equal(t, expected, changes)
+	assert.True(t, changes.HasDowngrades())
+}
+
+func TestDiff_EdgeCases(t *testing.T) {
+	info := loadDiffInfo(t, diffOldManifest)
+
+	tests := map[string]struct {
+		a, b      *Info
+		wantLen   int
+		wantKind  ChangeKind
+		wantFirst string
+	}{
+		"identical":        {a: info, b: loadDiffInfo(t, diffOldManifest), wantLen: 0},
+		"nil_old":          {a: nil, b: info, wantLen: 9, wantKind: ChangeAdded, wantFirst: "name"},
+		"nil_new":          {a: info, b: nil, wantLen: 9, wantKind: ChangeRemoved, wantFirst: "name"},
+		"both_nil":         {wantLen: 0},
+		"modules_one_side": {a: loadDiffInfo(t, diffOldManifest, Module{Path: "go.uber.org/zap", Version: "v1.27.0"}), b: info, wantLen: 0},
+		"project_name":     {a: info, b: loadDiffInfo(t, diffOldManifest[:len(`manifest_version: "1.0"`)]+"\nproject:\n  name: other\n  version: 1.4.2\n"), wantLen: 8, wantKind: ChangeModified, wantFirst: "name"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			changes := Diff(tt.a, tt.b)
+			require.Len(t, changes, tt.wantLen)
+			assert.False(t, changes.HasDowngrades())
+			if tt.wantLen > 0 {
+				assert.Equal(t, tt.wantKind, changes[0].Kind)
+				assert.Equal(t, tt.wantFirst, changes[0].Name)
+			}
+		})
+	}
+}
+
+func TestDiff_CompareVersions(t *testing.T) {
+	tests := map[string]struct {
+		old, new string
+		wantKind ChangeKind
+		wantBump BumpPart
+	}{
+		"major_up":          {old: "1.9.9", new: "2.0.0", wantKind: ChangeUpgraded, wantBump: BumpMajor},
+		"patch_down":        {old: "1.2.3", new: "1.2.2", wantKind: ChangeDowngraded, wantBump: BumpPatch},
+		"prerelease_up":     {old: "1.0.0-alpha.2", new: "1.0.0-alpha.10", wantKind: ChangeUpgraded, wantBump: BumpPrerelease},
+		"release_of_pre":    {old: "2.0.0-rc.1", new: "2.0.0", wantKind: ChangeUpgraded, wantBump: BumpPrerelease},
+		"short_forms":       {old: "45", new: "46", wantKind: ChangeUpgraded, wantBump: BumpMajor},
+		"same_precedence":   {old: "v1.15", new: "1.15.0", wantKind: ChangeModified},
+		"not_semver":        {old: "latest", new: "1.0.0", wantKind: ChangeModified},
+		"build_metadata":    {old: "1.0.0+a", new: "1.0.0+b", wantKind: ChangeModified},
+		"prefix_major_down": {old: "v3.0.0", new: "v2.9.0", wantKind: ChangeDowngraded, wantBump: BumpMajor},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			kind, bump := compareVersions(tt.old, tt.new)
+			assert.Equal(t, tt.wantKind, kind)
+			assert.Equal(t, tt.wantBump, bump)
+		})
+	}
+}
+
+func TestChanges_JSON(t *testing.T) {
+	changes := Changes{
+		{Dimension: DimensionAPIs, Name: "graphql", Kind: ChangeAdded, New: "0.1.0"},
+		{Dimension: DimensionSchemas, Name: "main", Kind: ChangeDowngraded, Old: "2", New: "1", Bump: BumpMajor},
+	}
+
+	data, err := json.Marshal(changes)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"dimension": "apis", "name": "graphql", "kind": "added", "new": "0.1.0"},
+		{"dimension": "schemas", "name": "main", "kind": "downgraded", "old": "2", "new": "1", "bump": "major"}
+	]`, string(data))
+}
@@ -0,0 +1,215 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifestWithEnvironments = `
+manifest_version: "1.0"
+project:
+  name: "env-app"
+  version: "1.2.3"
+schemas:
+  postgres_main: "45"
+apis:
+  rest_v1: "1.15.0"
+custom:
+  region: "us-east-1"
+  limits:
+    rps: 100
+    burst: 200
+environments:
+  staging:
+    project:
+      version: "1.3.0-rc.1"
+    apis:
+      rest_v1: "1.16.0"
+      grpc: "1.0.0"
+    custom:
+      limits:
+        rps: 10
+`
+
+func TestLoadVersionInfo_WithEnvironmentInline(t *testing.T) {
+	info, err := loadVersionInfo(
+		WithEmbedded([]byte(testManifestWithEnvironments)),
+		WithEnvironment("staging"),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "staging", info.Environment())
+	assert.Equal(t, "env-app", info.Project.Name)
+	assert.Equal(t, "1.3.0-rc.1", info.Project.Version)
+	assert.Equal(t, "45", info.GetSchemas()["postgres_main"])
+	assert.Equal(t, "1.16.0", info.GetAPIs()["rest_v1"])
+	assert.Equal(t, "1.0.0", info.GetAPIs()["grpc"])
+
+	custom := info.GetCustom()
+	assert.Equal(t, "us-east-1", custom["region"])
+	limits, ok := custom["limits"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, 10, limits["rps"])
+	assert.Equal(t, 200, limits["burst"], "nested custom maps should be deep-merged")
+}
+
+func TestLoadVersionInfo_WithoutEnvironment(t *testing.T) {
+	t.Setenv(EnvVarEnvironment, "")
+
+	info, err := loadVersionInfo(
+		WithEmbedded([]byte(testManifestWithEnvironments)),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+
+	assert.Empty(t, info.Environment())
+	assert.Equal(t, "1.2.3", info.Project.Version)
+	assert.Equal(t, "1.15.0", info.GetAPIs()["rest_v1"])
+}
+
+func TestLoadVersionInfo_UnknownEnvironmentUsesBase(t *testing.T) {
+	info, err := loadVersionInfo(
+		WithEmbedded([]byte(testManifestWithEnvironments)),
+		WithEnvironment("prod"),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+
+	assert.Empty(t, info.Environment(), "no overlay exists for prod")
+	assert.Equal(t, "1.2.3", info.Project.Version)
+}
+
+func TestLoadVersionInfo_EnvironmentFromEnvVar(t *testing.T) {
+	t.Setenv(EnvVarEnvironment, "staging")
+
+	info, err := loadVersionInfo(
+		WithEmbedded([]byte(testManifestWithEnvironments)),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "staging", info.Environment())
+
+	// WithEnvironment takes precedence over the environment variable
+	info, err = loadVersionInfo(
+		WithEmbedded([]byte(testManifestWithEnvironments)),
+		WithEnvironment("prod"),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+	assert.Empty(t, info.Environment())
+}
+
+func TestLoadVersionInfo_EnvironmentSiblingFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	basePath := filepath.Join(tmpDir, "versions.yaml")
+
+	require.NoError(t, os.WriteFile(basePath, []byte(testManifestWithEnvironments), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "versions.staging.yaml"), []byte(`
+apis:
+  rest_v1: "1.17.0"
+components:
+  cache: "2.0.0"
+`), 0o600))
+
+	info, err := loadVersionInfo(
+		WithManifestPath(basePath),
+		WithEnvironment("staging"),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "staging", info.Environment())
+	assert.Equal(t, "1.3.0-rc.1", info.Project.Version, "inline overlay still applies")
+	assert.Equal(t, "1.17.0", info.GetAPIs()["rest_v1"], "sibling file is applied after the inline block")
+	assert.Equal(t, "2.0.0", info.GetComponents()["cache"])
+}
+
+func TestLoadVersionInfo_EnvironmentSiblingFileOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+	basePath := filepath.Join(tmpDir, "versions.json")
+
+	require.NoError(t, os.WriteFile(basePath, []byte(testManifestJSON), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "versions.prod.json"),
+		[]byte(`{"project": {"version": "9.9.9"}}`), 0o600))
+
+	info, err := loadVersionInfo(
+		WithManifestPath(basePath),
+		WithEnvironment("prod"),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "prod", info.Environment())
+	assert.Equal(t, "9.9.9", info.Project.Version)
+	assert.Equal(t, "json-app", info.Project.Name)
+}
+
+func TestLoadVersionInfo_EnvironmentErrors(t *testing.T) {
+	t.Run("invalid_name", func(t *testing.T) {
+		_, err := loadVersionInfo(
+			WithEmbedded([]byte(testManifestWithEnvironments)),
+			WithEnvironment("../../etc"),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid environment name")
+	})
+
+	t.Run("invalid_sibling_file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		basePath := filepath.Join(tmpDir, "versions.yaml")
+		require.NoError(t, os.WriteFile(basePath, []byte(testManifestWithEnvironments), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "versions.qa.yaml"), []byte("apis: [unclosed"), 0o600))
+
+		_, err := loadVersionInfo(WithManifestPath(basePath), WithEnvironment("qa"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "versions.qa.yaml")
+	})
+
+	t.Run("merged_result_is_validated", func(t *testing.T) {
+		base := []byte(`
+project:
+  name: "app"
+environments:
+  prod:
+    project:
+      version: "1.0.0"
+`)
+		// Base alone lacks a version...
+		_, err := loadVersionInfo(WithEmbedded(base), WithEnvironment("dev"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), ErrMsgProjectVersionRequired)
+
+		// ...but the merged manifest is valid
+		info, err := loadVersionInfo(WithEmbedded(base), WithEnvironment("prod"), WithoutGitInfo())
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", info.Project.Version)
+	})
+}
+
+func TestInfo_EnvironmentJSONRoundTrip(t *testing.T) {
+	info, err := loadVersionInfo(
+		WithEmbedded([]byte(testManifestWithEnvironments)),
+		WithEnvironment("staging"),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+
+	data, err := info.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"environment":"staging"`)
+
+	var decoded Info
+	require.NoError(t, decoded.UnmarshalJSON(data))
+	assert.Equal(t, "staging", decoded.Environment())
+}
+
+func TestEnvironmentManifestPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("config", "versions.prod.yaml"),
+		environmentManifestPath(filepath.Join("config", "versions.yaml"), "prod"))
+	assert.Equal(t, "versions.dev.toml", environmentManifestPath("versions.toml", "dev"))
+	assert.Equal(t, "manifest.dev", environmentManifestPath("manifest", "dev"))
+}
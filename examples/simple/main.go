@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"log"
+	"log/slog"
+	"os"
 
 	"github.com/itsatony/go-version"
 )
@@ -65,16 +67,12 @@ func main() {
 		fmt.Println("\nNo PostgreSQL main schema version defined")
 	}
 
-	// Example: Using with structured logging (zap fields)
-	// In a real application, you would use these fields with zap logger:
-	// logger := zap.NewProduction()
-	// logger = logger.With(info.LogFields()...)
-	// logger.Info("Application started")
-	fmt.Println("\nLog fields for structured logging:")
-	fields := info.LogFields()
-	for _, field := range fields {
-		fmt.Printf("  %s: %v\n", field.Key, field.Interface)
-	}
+	// Example: Using with structured logging (log/slog)
+	// Info implements slog.LogValuer, so it is logged as a group; zap users
+	// get the same values from the versionzap package.
+	fmt.Println("\nStructured log entry:")
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	logger.Info("Application started", "version", info)
 }
 
 // Example output:
@@ -93,10 +91,8 @@ func main() {
 //
 // No PostgreSQL main schema version defined
 //
-// Log fields for structured logging:
-//   version: 0.0.0-dev
-//   git_commit: dev
-//   build_time: unknown
+// Structured log entry:
+// time=... level=INFO msg="Application started" version.project.name=unknown version.project.version=0.0.0-dev version.git.commit=dev ...
 
 // Example with versions.yaml:
 //
@@ -3,6 +3,7 @@ module github.com/itsatony/go-version
 go 1.24.6
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/itsatony/go-cuserr v0.3.0
 	github.com/stretchr/testify v1.11.1
 	go.uber.org/zap v1.27.0
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -391,3 +392,170 @@ func ExampleHealthHandler() {
 	// Start server (example only, not actually starting)
 	// http.ListenAndServe(":8080", Middleware(mux))
 }
+
+func TestHandler_ContentNegotiation(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	require.NoError(t, Initialize(WithEmbedded([]byte(testManifestForOverrides)), WithoutGitInfo()))
+
+	tests := map[string]struct {
+		accept          string
+		query           string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		"no_accept":            {wantStatus: http.StatusOK, wantContentType: HTTPContentTypeJSON, wantBody: `"name":"override-app"`},
+		"wildcard":             {accept: "*/*", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeJSON, wantBody: `"name":"override-app"`},
+		"json":                 {accept: "application/json", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeJSON, wantBody: `"name":"override-app"`},
+		"yaml":                 {accept: "application/yaml", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeYAML, wantBody: "project:\n  name: override-app\n"},
+		"x_yaml":               {accept: "application/x-yaml", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeYAML, wantBody: "name: override-app"},
+		"text":                 {accept: "text/plain", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeText, wantBody: "Project Information:\n  Name:     override-app\n"},
+		"prometheus":           {accept: "text/plain; version=0.0.4", wantStatus: http.StatusOK, wantContentType: HTTPContentTypePrometheus, wantBody: `build_info{name="override-app"`},
+		"quality_order":        {accept: "application/json;q=0.5, application/yaml;q=0.9", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeYAML},
+		"browser":              {accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeJSON},
+		"wildcard_excludes":    {accept: "*/*, application/json;q=0", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeYAML},
+		"not_acceptable":       {accept: "text/html", wantStatus: http.StatusNotAcceptable},
+		"all_excluded":         {accept: "application/json;q=0", wantStatus: http.StatusNotAcceptable},
+		"query_overrides":      {accept: "text/html", query: "?format=text", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeText},
+		"query_prometheus":     {query: "?format=prometheus", wantStatus: http.StatusOK, wantContentType: HTTPContentTypePrometheus},
+		"query_unknown":        {query: "?format=xml", wantStatus: http.StatusBadRequest},
+		"section_git":          {query: "?section=git", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeJSON, wantBody: `"tree_state":"unknown"`},
+		"section_schemas_yaml": {accept: "application/yaml", query: "?section=schemas", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeYAML, wantBody: "postgres_main: \"45\"\n"},
+		"section_apis_text":    {query: "?format=text&section=apis", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeText, wantBody: "API Versions:\n  rest_v1:  1.15.0\n"},
+		"section_modules":      {query: "?section=modules", wantStatus: http.StatusOK, wantContentType: HTTPContentTypeJSON},
+		"section_unknown":      {query: "?section=project", wantStatus: http.StatusBadRequest},
+		"section_prometheus":   {query: "?format=prometheus&section=git", wantStatus: http.StatusBadRequest},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, HTTPPathVersion+tt.query, http.NoBody)
+			if tt.accept != "" {
+				req.Header.Set(HTTPHeaderAccept, tt.accept)
+			}
+			w := httptest.NewRecorder()
+			Handler().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			assert.Equal(t, HTTPHeaderAccept, w.Header().Get(HTTPHeaderVary))
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			}
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestHandler_SectionJSON(t *testing.T) {
+	reg := newTestRegistry(t, "billing", "1.0.0")
+
+	w := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTPPathVersion+"?section=components", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{}`, w.Body.String())
+}
+
+func TestHandler_ConditionalRequests(t *testing.T) {
+	reg := newTestRegistry(t, "billing", "1.0.0")
+	loadedAt := reg.MustGet().LoadedAt().UTC()
+
+	first := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(first, httptest.NewRequest(http.MethodGet, HTTPPathVersion, http.NoBody))
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get(HTTPHeaderETag)
+	require.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+	assert.Equal(t, loadedAt.Format(http.TimeFormat), first.Header().Get(HTTPHeaderLastModified))
+
+	tests := map[string]struct {
+		header     string
+		value      string
+		wantStatus int
+	}{
+		"matching_etag":         {header: HTTPHeaderIfNoneMatch, value: etag, wantStatus: http.StatusNotModified},
+		"weak_etag_in_list":     {header: HTTPHeaderIfNoneMatch, value: `"other", W/` + etag, wantStatus: http.StatusNotModified},
+		"wildcard":              {header: HTTPHeaderIfNoneMatch, value: "*", wantStatus: http.StatusNotModified},
+		"stale_etag":            {header: HTTPHeaderIfNoneMatch, value: `"other"`, wantStatus: http.StatusOK},
+		"modified_since_load":   {header: HTTPHeaderIfModifiedSince, value: loadedAt.Format(http.TimeFormat), wantStatus: http.StatusNotModified},
+		"modified_before_load":  {header: HTTPHeaderIfModifiedSince, value: loadedAt.Add(-time.Minute).Format(http.TimeFormat), wantStatus: http.StatusOK},
+		"invalid_modified_date": {header: HTTPHeaderIfModifiedSince, value: "yesterday", wantStatus: http.StatusOK},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, HTTPPathVersion, http.NoBody)
+			req.Header.Set(tt.header, tt.value)
+			w := httptest.NewRecorder()
+			reg.Handler().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, etag, w.Header().Get(HTTPHeaderETag))
+			if tt.wantStatus == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_ETagPerRepresentation(t *testing.T) {
+	reg := newTestRegistry(t, "billing", "1.0.0")
+
+	etags := map[string]bool{}
+	for _, query := range []string{"", "?format=yaml", "?format=text", "?section=git"} {
+		w := httptest.NewRecorder()
+		reg.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTPPathVersion+query, http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code)
+		etags[w.Header().Get(HTTPHeaderETag)] = true
+	}
+	assert.Len(t, etags, 4)
+}
+
+func TestHandler_ConditionalFollowsReload(t *testing.T) {
+	reg := newTestRegistry(t, "billing", "1.0.0")
+
+	w := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTPPathVersion, http.NoBody))
+	etag := w.Header().Get(HTTPHeaderETag)
+	lastModified := w.Header().Get(HTTPHeaderLastModified)
+
+	// Same content loaded again keeps the ETag
+	unchanged := *reg.MustGet()
+	unchanged.loadedAt = unchanged.loadedAt.Add(time.Hour)
+	reg.instance.Store(&unchanged)
+
+	req := httptest.NewRequest(http.MethodGet, HTTPPathVersion, http.NoBody)
+	req.Header.Set(HTTPHeaderIfNoneMatch, etag)
+	w = httptest.NewRecorder()
+	reg.Handler().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.NotEqual(t, lastModified, w.Header().Get(HTTPHeaderLastModified))
+
+	// A new version changes it
+	reloaded := unchanged
+	reloaded.Project.Version = "1.1.0"
+	reg.instance.Store(&reloaded)
+
+	w = httptest.NewRecorder()
+	reg.Handler().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get(HTTPHeaderETag))
+	assert.Contains(t, w.Body.String(), `"version":"1.1.0"`)
+}
+
+func TestHandler_Head(t *testing.T) {
+	reg := newTestRegistry(t, "billing", "1.0.0")
+
+	get := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(get, httptest.NewRequest(http.MethodGet, HTTPPathVersion, http.NoBody))
+
+	head := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(head, httptest.NewRequest(http.MethodHead, HTTPPathVersion, http.NoBody))
+
+	assert.Equal(t, http.StatusOK, head.Code)
+	assert.Empty(t, head.Body.String())
+	assert.Equal(t, get.Header().Get(HTTPHeaderETag), head.Header().Get(HTTPHeaderETag))
+	assert.Equal(t, HTTPContentTypeJSON, head.Header().Get("Content-Type"))
+	assert.Equal(t, get.Header().Get("Content-Length"), head.Header().Get("Content-Length"))
+}
@@ -0,0 +1,283 @@
+package version
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOptionsTestRegistry returns a registry with build, git and custom values set
+func newOptionsTestRegistry(t *testing.T) *Registry {
+	t.Helper()
+	reg := newTestRegistry(t, "billing", "1.0.0")
+	info := *reg.MustGet()
+	info.Git = GitInfo{Commit: "abc123", RemoteURL: "https://git.internal/billing.git", TreeState: GitTreeStateClean}
+	info.Build = BuildInfo{Time: "2025-06-01T12:00:00Z", User: "ci-runner", GoVersion: "go1.24.6"}
+	info.components = map[string]string{"billing-internal": "2.0.0", "ledger": "1.4.0"}
+	info.custom = map[string]interface{}{"owner": "payments", "region": "eu-west-1"}
+	reg.instance.Store(&info)
+	return reg
+}
+
+func TestHandlerWithOptions_Defaults(t *testing.T) {
+	reg := newOptionsTestRegistry(t)
+
+	plain := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(plain, httptest.NewRequest(http.MethodGet, HTTPPathVersion, http.NoBody))
+	configured := httptest.NewRecorder()
+	reg.HandlerWithOptions().ServeHTTP(configured, httptest.NewRequest(http.MethodGet, HTTPPathVersion, http.NoBody))
+
+	assert.Equal(t, plain.Header(), configured.Header())
+	assert.Equal(t, plain.Body.String(), configured.Body.String())
+}
+
+func TestWithCacheControl(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  []string
+	}{
+		"custom":   {value: "no-cache", want: []string{"no-cache"}},
+		"disabled": {value: "", want: nil},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			reg := newOptionsTestRegistry(t)
+			w := httptest.NewRecorder()
+			reg.HandlerWithOptions(WithCacheControl(tt.value)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTPPathVersion, http.NoBody))
+			assert.Equal(t, tt.want, w.Header().Values("Cache-Control"))
+		})
+	}
+}
+
+func TestWithCORS(t *testing.T) {
+	tests := map[string]struct {
+		origins    []string
+		method     string
+		origin     string
+		preflight  bool
+		wantStatus int
+		wantOrigin string
+	}{
+		"wildcard":           {origins: []string{"*"}, method: http.MethodGet, origin: "https://a.example.com", wantStatus: http.StatusOK, wantOrigin: "*"},
+		"listed_origin":      {origins: []string{"https://status.example.com"}, method: http.MethodGet, origin: "https://status.example.com", wantStatus: http.StatusOK, wantOrigin: "https://status.example.com"},
+		"unlisted_origin":    {origins: []string{"https://status.example.com"}, method: http.MethodGet, origin: "https://evil.example.com", wantStatus: http.StatusOK},
+		"preflight":          {origins: []string{"*"}, method: http.MethodOptions, origin: "https://a.example.com", preflight: true, wantStatus: http.StatusNoContent, wantOrigin: "*"},
+		"preflight_unlisted": {origins: []string{"https://status.example.com"}, method: http.MethodOptions, origin: "https://evil.example.com", preflight: true, wantStatus: http.StatusMethodNotAllowed},
+		"options_no_cors":    {method: http.MethodOptions, origin: "https://a.example.com", preflight: true, wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			reg := newOptionsTestRegistry(t)
+			req := httptest.NewRequest(tt.method, HTTPPathVersion, http.NoBody)
+			req.Header.Set(HTTPHeaderOrigin, tt.origin)
+			if tt.preflight {
+				req.Header.Set(HTTPHeaderCORSRequestMethod, http.MethodGet)
+			}
+			w := httptest.NewRecorder()
+			reg.HandlerWithOptions(WithCORS(tt.origins...)).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantOrigin, w.Header().Get(HTTPHeaderCORSAllowOrigin))
+			if tt.wantOrigin != "" {
+				assert.Contains(t, w.Header().Get(HTTPHeaderCORSExposeHeaders), HTTPHeaderETag)
+			}
+			if tt.wantStatus == http.StatusNoContent {
+				assert.Equal(t, HTTPCORSAllowMethods, w.Header().Get(HTTPHeaderCORSAllowMethods))
+			}
+		})
+	}
+}
+
+func TestWithPrettyJSON(t *testing.T) {
+	reg := newOptionsTestRegistry(t)
+
+	w := httptest.NewRecorder()
+	reg.HandlerWithOptions(WithPrettyJSON()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTPPathVersion, http.NoBody))
+	assert.True(t, strings.HasPrefix(w.Body.String(), "{\n  \"project\": {\n    \"name\": \"billing\""), w.Body.String())
+
+	w = httptest.NewRecorder()
+	reg.HealthHandlerWithOptions(WithPrettyJSON()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTPPathHealth, http.NoBody))
+	assert.True(t, strings.HasPrefix(w.Body.String(), "{\n  \"status\": \"ok\""), w.Body.String())
+}
+
+func TestWithHiddenFields(t *testing.T) {
+	tests := map[string]struct {
+		query  string
+		hidden []string
+		check  func(t *testing.T, body string)
+	}{
+		"build_user_and_custom": {
+			hidden: []string{"build.user", "git.remote_url", "custom"},
+			check: func(t *testing.T, body string) {
+				var doc map[string]interface{}
+				require.NoError(t, json.Unmarshal([]byte(body), &doc))
+				assert.NotContains(t, doc, "custom")
+				assert.NotContains(t, doc["build"], "user")
+				assert.NotContains(t, doc["git"], "remote_url")
+				assert.Equal(t, "abc123", doc["git"].(map[string]interface{})["commit"])
+			},
+		},
+		"single_entries": {
+			hidden: []string{"components.billing-internal", "custom.owner"},
+			check: func(t *testing.T, body string) {
+				assert.NotContains(t, body, "billing-internal")
+				assert.NotContains(t, body, "payments")
+				assert.Contains(t, body, `"ledger":"1.4.0"`)
+				assert.Contains(t, body, `"region":"eu-west-1"`)
+			},
+		},
+		"text_format": {
+			query:  "?format=text",
+			hidden: []string{"build.user", "custom"},
+			check: func(t *testing.T, body string) {
+				assert.NotContains(t, body, "ci-runner")
+				assert.NotContains(t, body, "Custom Metadata")
+				assert.Contains(t, body, "Go Version:")
+			},
+		},
+		"unknown_paths": {
+			hidden: []string{"nothing", "build.nothing", "custom.nothing"},
+			check: func(t *testing.T, body string) {
+				assert.Contains(t, body, "ci-runner")
+				assert.Contains(t, body, "payments")
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			reg := newOptionsTestRegistry(t)
+			w := httptest.NewRecorder()
+			reg.HandlerWithOptions(WithHiddenFields(tt.hidden...)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTPPathVersion+tt.query, http.NoBody))
+			require.Equal(t, http.StatusOK, w.Code)
+			tt.check(t, w.Body.String())
+
+			// The registry's Info is not modified
+			assert.Equal(t, "ci-runner", reg.MustGet().Build.User)
+			assert.Len(t, reg.MustGet().GetCustom(), 2)
+		})
+	}
+}
+
+func TestWithHealthStatus(t *testing.T) {
+	reg := newOptionsTestRegistry(t)
+
+	w := httptest.NewRecorder()
+	reg.HealthHandlerWithOptions(WithHealthStatus("UP", "DOWN")).ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTPPathHealth, http.NoBody))
+	assert.Contains(t, w.Body.String(), `"status":"UP"`)
+
+	failing := newHealthHandler(func() (*Info, error) { return nil, ErrNotInitialized }, newHandlerOptions(WithHealthStatus("UP", "DOWN")))
+	w = httptest.NewRecorder()
+	failing.ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTPPathHealth, http.NoBody))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"DOWN"`)
+}
+
+func TestWithHeaderNames(t *testing.T) {
+	reg := newOptionsTestRegistry(t)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	w := httptest.NewRecorder()
+	reg.MiddlewareWithOptions(next, WithHeaderNames("X-Service-Version", "")).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api", http.NoBody))
+
+	assert.Equal(t, "1.0.0", w.Header().Get("X-Service-Version"))
+	assert.Empty(t, w.Header().Get(HTTPHeaderAppVersion))
+	assert.Empty(t, w.Header().Get(HTTPHeaderGitCommit))
+}
+
+func TestWithAuthorizer(t *testing.T) {
+	authorizer := func(r *http.Request) bool { return r.Header.Get(HTTPHeaderAuthorization) == "Bearer secret" }
+
+	tests := map[string]struct {
+		token     string
+		hidden    []string
+		wantFull  bool
+		wantCache string
+	}{
+		"authorized":   {token: "Bearer secret", wantFull: true, wantCache: HTTPCacheControlPrivate},
+		"unauthorized": {token: "Bearer wrong", wantCache: HTTPCacheControl},
+		"anonymous":    {wantCache: HTTPCacheControl},
+		"hidden_for_both": {
+			token: "Bearer secret", hidden: []string{"custom.region"}, wantFull: true, wantCache: HTTPCacheControlPrivate,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			reg := newOptionsTestRegistry(t)
+			req := httptest.NewRequest(http.MethodGet, HTTPPathVersion, http.NoBody)
+			if tt.token != "" {
+				req.Header.Set(HTTPHeaderAuthorization, tt.token)
+			}
+			w := httptest.NewRecorder()
+			reg.HandlerWithOptions(WithAuthorizer(authorizer), WithHiddenFields(tt.hidden...)).ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantCache, w.Header().Get("Cache-Control"))
+			assert.Contains(t, w.Header().Values(HTTPHeaderVary), HTTPHeaderAuthorization)
+			assert.Equal(t, tt.wantFull, strings.Contains(w.Body.String(), "ci-runner"))
+			assert.Equal(t, tt.wantFull, strings.Contains(w.Body.String(), "git.internal"))
+			assert.NotEqual(t, len(tt.hidden) > 0, strings.Contains(w.Body.String(), "eu-west-1"))
+		})
+	}
+}
+
+func TestWithAuthorizer_ManifestPrivateFields(t *testing.T) {
+	manifest := []byte(`
+project:
+  name: "billing"
+  version: "1.0.0"
+components:
+  billing-internal: "2.0.0"
+  ledger: "1.4.0"
+private:
+  - components.billing-internal
+`)
+	reg, err := NewRegistry(WithEmbedded(manifest), WithoutGitInfo(), WithoutBuildInfo())
+	require.NoError(t, err)
+	handler := reg.HandlerWithOptions(WithAuthorizer(func(r *http.Request) bool {
+		return r.Header.Get(HTTPHeaderAuthorization) != ""
+	}))
+
+	public := httptest.NewRecorder()
+	handler.ServeHTTP(public, httptest.NewRequest(http.MethodGet, HTTPPathVersion+"?format=text", http.NoBody))
+	assert.NotContains(t, public.Body.String(), "billing-internal")
+	assert.Contains(t, public.Body.String(), "ledger")
+
+	req := httptest.NewRequest(http.MethodGet, HTTPPathVersion+"?format=text", http.NoBody)
+	req.Header.Set(HTTPHeaderAuthorization, "Bearer any")
+	full := httptest.NewRecorder()
+	handler.ServeHTTP(full, req)
+	assert.Contains(t, full.Body.String(), "billing-internal")
+	assert.NotEqual(t, public.Header().Get(HTTPHeaderETag), full.Header().Get(HTTPHeaderETag))
+}
+
+func TestWithAuthorizer_HidesModules(t *testing.T) {
+	reg := newOptionsTestRegistry(t)
+	info := *reg.MustGet()
+	info.modules = []Module{{Path: "gopkg.in/yaml.v3", Version: "v3.0.1", Sum: "h1:fxVm/GzAzEWqLHuvctI91KS9hhNmmWOoWu0XTYJS7CA="}}
+	reg.instance.Store(&info)
+	handler := reg.HandlerWithOptions(WithAuthorizer(func(r *http.Request) bool {
+		return r.Header.Get(HTTPHeaderAuthorization) != ""
+	}))
+
+	public := httptest.NewRecorder()
+	handler.ServeHTTP(public, httptest.NewRequest(http.MethodGet, HTTPPathVersion, http.NoBody))
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(public.Body.Bytes(), &doc))
+	assert.NotContains(t, doc, DimensionModules)
+	assert.NotContains(t, public.Body.String(), "gopkg.in/yaml.v3")
+
+	req := httptest.NewRequest(http.MethodGet, HTTPPathVersion, http.NoBody)
+	req.Header.Set(HTTPHeaderAuthorization, "Bearer any")
+	full := httptest.NewRecorder()
+	handler.ServeHTTP(full, req)
+	assert.Contains(t, full.Body.String(), "gopkg.in/yaml.v3")
+}
@@ -146,6 +146,52 @@ func TestInfo_GetComponentVersion(t *testing.T) {
 	}
 }
 
+func TestInfo_GetModuleVersion(t *testing.T) {
+	info := &Info{
+		modules: []Module{
+			{Path: "go.uber.org/zap", Version: "v1.27.0", Sum: "h1:abc="},
+			{Path: "golang.org/x/net", Version: "v0.20.0", Replace: &Module{Path: "golang.org/x/net", Version: "v0.21.0"}},
+			{Path: "example.com/local", Version: "v1.0.0", Replace: &Module{Path: "../local"}},
+		},
+	}
+
+	tests := map[string]struct {
+		path     string
+		expected string
+		found    bool
+	}{
+		"plain_module":          {path: "go.uber.org/zap", expected: "v1.27.0", found: true},
+		"replaced_with_version": {path: "golang.org/x/net", expected: "v0.21.0", found: true},
+		"replaced_with_dir":     {path: "example.com/local", expected: "", found: true},
+		"missing_module":        {path: "github.com/missing/mod", expected: "", found: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, found := info.GetModuleVersion(tt.path)
+			assert.Equal(t, tt.expected, version)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+}
+
+func TestInfo_GetModules_DefensiveCopy(t *testing.T) {
+	info := &Info{
+		modules: []Module{
+			{Path: "golang.org/x/net", Version: "v0.20.0", Replace: &Module{Path: "golang.org/x/net", Version: "v0.21.0"}},
+		},
+	}
+
+	modules := info.GetModules()
+	modules[0].Version = "mutated"
+	modules[0].Replace.Version = "mutated"
+
+	version, _ := info.GetModuleVersion("golang.org/x/net")
+	assert.Equal(t, "v0.21.0", version)
+	assert.Equal(t, "v0.20.0", info.GetModules()[0].Version)
+	assert.Nil(t, (&Info{}).GetModules())
+}
+
 func TestInfo_LoadedAt(t *testing.T) {
 	now := time.Now()
 	info := &Info{
@@ -155,31 +201,76 @@ func TestInfo_LoadedAt(t *testing.T) {
 	assert.Equal(t, now, info.LoadedAt())
 }
 
-func TestInfo_LogFields(t *testing.T) {
+func TestInfo_KeysAndValues(t *testing.T) {
 	info := &Info{
-		Project: ProjectVersion{
-			Name:    "test-app",
-			Version: "1.2.3",
-		},
-		Git: GitInfo{
-			Commit:     "abc123",
-			Tag:        "v1.2.3",
-			TreeState:  "clean",
-			CommitTime: "2025-10-11T10:00:00Z",
-		},
-		Build: BuildInfo{
-			Time:      "2025-10-11T12:00:00Z",
-			User:      "ci",
-			GoVersion: "go1.21.0",
-		},
+		Project: ProjectVersion{Name: "test-app", Version: "1.2.3"},
+		Git:     GitInfo{Commit: "abc123", Tag: "v1.2.3", TreeState: "clean"},
+		Build:   BuildInfo{Time: "2025-10-11T12:00:00Z", User: "ci", GoVersion: "go1.21.0"},
 	}
 
-	fields := info.LogFields()
+	kvs := info.KeysAndValues()
+	require.Len(t, kvs, 24)
+	assert.Equal(t, []any{LogFieldProjectName, "test-app", LogFieldProjectVersion, "1.2.3"}, kvs[:4])
+	assert.Equal(t, []any{LogFieldGoVersion, "go1.21.0"}, kvs[22:])
+	for n := 0; n < len(kvs); n += 2 {
+		assert.IsType(t, "", kvs[n], "keys must be strings")
+	}
+}
 
-	require.Len(t, fields, 8)
+func TestInfo_Public(t *testing.T) {
+	manifest := []byte(`
+project:
+  name: "public-app"
+  version: "1.2.3"
+components:
+  billing-internal: "2.0.0"
+  ledger: "1.4.0"
+custom:
+  owner: "payments"
+  region: "eu-west-1"
+private:
+  - custom.owner
+  - components.billing-internal
+`)
+	info, err := New(WithEmbedded(manifest), WithoutGitInfo(), WithoutBuildInfo())
+	require.NoError(t, err)
+	full := *info
+	full.Build.User = "ci-runner"
+	full.Git.RemoteURL = "https://git.internal/public-app.git"
+
+	public := full.Public()
+
+	assert.Empty(t, public.Build.User)
+	assert.Empty(t, public.Git.RemoteURL)
+	assert.Empty(t, public.GetModules())
+	assert.Equal(t, map[string]string{"ledger": "1.4.0"}, public.GetComponents())
+	assert.Equal(t, map[string]interface{}{"region": "eu-west-1"}, public.GetCustom())
+	assert.Equal(t, full.Project, public.Project)
+	assert.Equal(t, public, public.Public())
+	assert.Equal(t, []string{"custom.owner", "components.billing-internal"}, full.PrivateFields())
+
+	// The full view is not modified
+	assert.Equal(t, "ci-runner", full.Build.User)
+	assert.Len(t, full.GetComponents(), 2)
+	assert.Len(t, full.GetCustom(), 2)
+}
+
+func TestInfo_Public_InvalidPrivateField(t *testing.T) {
+	tests := map[string]string{
+		"unknown_part":       "secrets",
+		"unknown_git_field":  "git.password",
+		"nested_environment": "environment.name",
+		"empty_key":          "custom.",
+	}
 
-	// Verify all fields are present (zap.Field doesn't expose values easily, so we just check length)
-	assert.NotNil(t, fields)
+	for name, path := range tests {
+		t.Run(name, func(t *testing.T) {
+			manifest := []byte("project:\n  name: \"app\"\n  version: \"1.0.0\"\nprivate:\n  - \"" + path + "\"\n")
+			_, err := New(WithEmbedded(manifest), WithoutGitInfo(), WithoutBuildInfo())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid private field '"+path+"'")
+		})
+	}
 }
 
 func TestInfo_String(t *testing.T) {
@@ -197,6 +288,32 @@ func TestInfo_String(t *testing.T) {
 	assert.Equal(t, expected, info.String())
 }
 
+func TestProjectVersion_SemVer(t *testing.T) {
+	tests := map[string]struct {
+		version   string
+		wantMajor int
+		wantMinor int
+		wantErr   bool
+	}{
+		"release":    {version: "1.2.3", wantMajor: 1, wantMinor: 2},
+		"prerelease": {version: "v2.0.0-rc.1", wantMajor: 2, wantMinor: 0},
+		"invalid":    {version: "latest", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := ProjectVersion{Name: "app", Version: tt.version}.SemVer()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMajor, v.Major())
+			assert.Equal(t, tt.wantMinor, v.Minor())
+		})
+	}
+}
+
 func TestInfo_MarshalJSON(t *testing.T) {
 	now := time.Now()
 	info := &Info{
@@ -289,6 +406,33 @@ func TestInfo_JSONRoundTrip(t *testing.T) {
 }
 
 // TestInfo_ConcurrentReads tests that multiple goroutines can safely read from Info
+func TestInfo_JSONRoundTrip_ModulesAndPlatform(t *testing.T) {
+	original := &Info{
+		Project: ProjectVersion{Name: "test-app", Version: "1.2.3"},
+		Build: BuildInfo{
+			GoVersion:  "go1.24.0",
+			GOOS:       "linux",
+			GOARCH:     "arm64",
+			CGOEnabled: true,
+			Tags:       []string{"netgo", "osusergo"},
+			Trimpath:   true,
+		},
+		modules: []Module{
+			{Path: "go.uber.org/zap", Version: "v1.27.0", Sum: "h1:abc="},
+		},
+	}
+
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"modules":[{"path":"go.uber.org/zap","version":"v1.27.0","sum":"h1:abc="}]`)
+	assert.Contains(t, string(data), `"goarch":"arm64"`)
+
+	var decoded Info
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, original.Build, decoded.Build)
+	assert.Equal(t, original.GetModules(), decoded.GetModules())
+}
+
 func TestInfo_ConcurrentReads(t *testing.T) {
 	info := &Info{
 		Project: ProjectVersion{
@@ -339,7 +483,7 @@ func TestInfo_ConcurrentReads(t *testing.T) {
 				// Call other methods
 				_ = info.String()
 				_ = info.LoadedAt()
-				_ = info.LogFields()
+				_ = info.SlogAttrs()
 			}
 		}()
 	}
@@ -0,0 +1,197 @@
+package version
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const inspectTestCommit = "89abcdef0123456789abcdef0123456789abcdef"
+
+// buildInspectee builds testdata/inspectee with version variables injected via
+// -X and returns the binary path.
+func buildInspectee(t *testing.T, extraLdflags string) string {
+	t.Helper()
+	return buildTestBinary(t, "./testdata/inspectee", extraLdflags)
+}
+
+// buildTestBinary builds the main package pkg with version variables injected
+// via -X and returns the binary path.
+func buildTestBinary(t *testing.T, pkg, extraLdflags string) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds a binary")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+
+	out := filepath.Join(t.TempDir(), filepath.Base(pkg))
+	ldflags := "-X " + PackageImportPath + ".GitCommit=" + inspectTestCommit +
+		" -X " + PackageImportPath + ".GitTag=v3.2.1" +
+		" -X " + PackageImportPath + ".GitBranch=release/3.2" +
+		" -X " + PackageImportPath + ".BuildTime=2025-06-01T12:00:00Z" +
+		" -X " + PackageImportPath + ".BuildUser=ci " + extraLdflags
+	cmd := exec.Command(goBin, "build", "-o", out, "-ldflags", ldflags, pkg)
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	return out
+}
+
+func TestInspect(t *testing.T) {
+	info, err := Inspect(buildInspectee(t, ""))
+	require.NoError(t, err)
+
+	assert.Equal(t, "inspectee", info.Project.Name)
+	assert.Equal(t, "3.2.1", info.Project.Version)
+	assert.Equal(t, map[string]string{"postgres_main": "47"}, info.GetSchemas())
+	assert.Equal(t, map[string]string{"redis": "7.2.4"}, info.GetComponents())
+	assert.Equal(t, "eu-west-1", info.GetCustom()["region"])
+
+	assert.Equal(t, inspectTestCommit, info.Git.Commit)
+	assert.Equal(t, "89abcde", info.Git.ShortCommit)
+	assert.Equal(t, "v3.2.1", info.Git.Tag)
+	assert.Equal(t, "release/3.2", info.Git.Branch)
+	assert.Equal(t, "2025-06-01T12:00:00Z", info.Build.Time)
+	assert.Equal(t, "ci", info.Build.User)
+
+	assert.Contains(t, info.Build.GoVersion, "go")
+	assert.Equal(t, PackageImportPath+"/testdata/inspectee", info.Build.Path)
+	version, ok := info.GetModuleVersion("gopkg.in/yaml.v3")
+	assert.True(t, ok)
+	assert.NotEmpty(t, version)
+}
+
+func TestInspect_Stripped(t *testing.T) {
+	info, err := Inspect(buildInspectee(t, "-s -w"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "inspectee", info.Project.Name)
+	assert.Equal(t, inspectTestCommit, info.Git.Commit, "unique commit hash in the data section")
+	assert.Equal(t, "2025-06-01T12:00:00Z", info.Build.Time, "unique timestamp in the data section")
+	assert.Empty(t, info.Git.Branch, "other variables cannot be attributed without symbols")
+}
+
+func TestInspect_LinksTemplate(t *testing.T) {
+	info, err := Inspect(buildTestBinary(t, "./testdata/inspectee-init", ""))
+	require.NoError(t, err)
+
+	assert.Equal(t, "inspectee-init", info.Project.Name, "the program's manifest, not the init template")
+	assert.Equal(t, "2.5.0", info.Project.Version)
+}
+
+func TestInspect_Errors(t *testing.T) {
+	dir := t.TempDir()
+	notBinary := filepath.Join(dir, "versions.yaml")
+	require.NoError(t, os.WriteFile(notBinary, []byte(testManifestForOverrides), 0o644))
+
+	tests := map[string]string{
+		"missing_file": filepath.Join(dir, "missing"),
+		"not_a_binary": notBinary,
+	}
+
+	for name, path := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Inspect(path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), ErrMsgInspectBinary)
+			assert.Contains(t, err.Error(), "Hint:")
+		})
+	}
+}
+
+func TestFindEmbeddedManifest(t *testing.T) {
+	manifest := "manifest_version: \"1.0\"\nproject:\n  name: \"embedded\"\n  version: \"1.4.0\"\napis:\n  rest_v1: \"1.15.0\"\n"
+	projectFirst := "project:\n  name: \"embedded\"\n  version: \"1.4.0\"\nmanifest_version: \"1.0\"\napis:\n  rest_v1: \"1.15.0\"\n"
+	other := "manifest_version: \"1.0\"\nproject:\n  name: \"other\"\n  version: \"2.0.0\"\n"
+	template := "# Template\nmanifest_version: \"1.0\"\nproject:\n  name: " + ManifestTemplatePlaceholder + "\n  version: \"0.1.0\"\n"
+	commented := "manifest_version: \"1.0\"\n# manifest_version: \"0.9\"\nproject:\n  name: \"embedded\"\n  version: \"1.4.0\"\napis:\n  rest_v1: \"1.15.0\"\n"
+
+	tests := map[string]struct {
+		data          string
+		wantName      string
+		wantAPI       string
+		wantAmbiguous bool
+	}{
+		"surrounded_by_binary":   {data: "\x00\x01" + manifest + "\x00\xff", wantName: "embedded", wantAPI: "1.15.0"},
+		"followed_by_strings":    {data: "\x00" + manifest + "unknowngo1.24truefalse", wantName: "embedded", wantAPI: "1.15.0"},
+		"followed_by_junk_lines": {data: "\x00" + manifest + "junk\nmore junk\n", wantName: "embedded", wantAPI: "1.15.0"},
+		"project_before_marker":  {data: "\x00" + projectFirst + "\x00", wantName: "embedded", wantAPI: "1.15.0"},
+		"struct_tag_only":        {data: `yaml:"manifest_version" json:"manifest_version"`},
+		"marker_without_project": {data: "\x00manifest_version: \"1.0\"\n\x00"},
+		"no_marker":              {data: "\x7fELF\x00\x00"},
+		"template_skipped":       {data: "\x00" + template + "\x00" + manifest + "\x00", wantName: "embedded", wantAPI: "1.15.0"},
+		"template_only":          {data: "\x00" + template + "\x00"},
+		"marker_in_comment":      {data: "\x00" + commented + "\x00", wantName: "embedded", wantAPI: "1.15.0"},
+		"same_manifest_twice":    {data: "\x00" + manifest + "\x00" + manifest + "\x00", wantName: "embedded", wantAPI: "1.15.0"},
+		"ambiguous":              {data: "\x00" + manifest + "\x00" + other + "\x00", wantAmbiguous: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := findEmbeddedManifest([]byte(tt.data))
+			if tt.wantAmbiguous {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), ErrMsgInspectAmbiguousManifest)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantName == "" {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.wantName, got.Project.Name)
+			assert.Equal(t, tt.wantAPI, got.APIs["rest_v1"])
+		})
+	}
+}
+
+func TestScanStrippedLdflags(t *testing.T) {
+	tests := map[string]struct {
+		strs       []string
+		wantCommit string
+		wantTime   string
+	}{
+		"unique_values": {
+			strs:       []string{"dev", inspectTestCommit, "2025-06-01T12:00:00Z", "1387778780781445675529539585113525390625"},
+			wantCommit: inspectTestCommit,
+			wantTime:   "2025-06-01T12:00:00Z",
+		},
+		"ambiguous_values": {
+			strs:       []string{inspectTestCommit, "0123456789abcdef0123456789abcdef01234567", "2025-06-01T12:00:00Z", "2024-01-01T00:00:00+01:00"},
+			wantCommit: DefaultGitCommit,
+			wantTime:   DefaultBuildTime,
+		},
+		"duplicates_count_once": {
+			strs:       []string{inspectTestCommit, inspectTestCommit},
+			wantCommit: inspectTestCommit,
+			wantTime:   DefaultBuildTime,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := ldflagsValues{gitCommit: DefaultGitCommit, buildTime: DefaultBuildTime}
+			scanStrippedLdflags(tt.strs, &values)
+			assert.Equal(t, tt.wantCommit, values.gitCommit)
+			assert.Equal(t, tt.wantTime, values.buildTime)
+		})
+	}
+}
+
+func TestInspectDefaultManifest(t *testing.T) {
+	manifest := inspectDefaultManifest("github.com/acme/app/cmd/server", "v1.4.0")
+	assert.Equal(t, "server", manifest.Project.Name)
+	assert.Equal(t, "1.4.0", manifest.Project.Version)
+
+	manifest = inspectDefaultManifest("", "(devel)")
+	assert.Equal(t, DefaultProjectName, manifest.Project.Name)
+	assert.Equal(t, DefaultProjectVersion, manifest.Project.Version)
+}
@@ -0,0 +1,331 @@
+// Package binscan reads string variables from Go executables without running
+// them.
+//
+// It understands ELF, Mach-O (including universal binaries, of which the first
+// architecture is used) and PE files. Variables are located by name through the
+// symbol table; for stripped binaries, DataStrings lists every string a string
+// header in the data section points to, so callers can search it heuristically.
+package binscan
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+)
+
+const (
+	// maxFileSize bounds the size of executables read into memory
+	maxFileSize = 1 << 30
+
+	// maxStringLen bounds the length of strings read through a string header
+	maxStringLen = 1 << 20
+)
+
+// ErrUnknownFormat is returned for files that are not ELF, Mach-O or PE executables
+var ErrUnknownFormat = errors.New("unrecognized executable format")
+
+// ErrNoSymbols is returned by StringVar for binaries without a symbol table
+// (e.g. built with -ldflags=-s)
+var ErrNoSymbols = errors.New("binary has no symbol table")
+
+// section is an address range of the loaded image.
+type section struct {
+	name string
+	addr uint64
+	size uint64
+
+	// data is the file content of the section; shorter than size (or nil) for
+	// zero-filled sections such as .bss
+	data []byte
+
+	// writable reports whether the section holds initialized Go data
+	writable bool
+}
+
+// File is an executable loaded into memory.
+type File struct {
+	// raw is the complete file content
+	raw []byte
+
+	sections  []section
+	symbols   map[string]uint64
+	ptrSize   int
+	byteOrder binary.ByteOrder
+}
+
+// Open reads and parses the executable at path.
+func Open(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(f, maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > maxFileSize {
+		return nil, fmt.Errorf("%s: file larger than %d bytes", path, maxFileSize)
+	}
+	return Parse(raw)
+}
+
+// Parse parses an executable held in memory.
+func Parse(raw []byte) (*File, error) {
+	switch {
+	case bytes.HasPrefix(raw, []byte(elf.ELFMAG)):
+		return parseELF(raw)
+	case bytes.HasPrefix(raw, []byte("MZ")):
+		return parsePE(raw)
+	default:
+		if f, err := parseMachO(raw); err == nil {
+			return f, nil
+		}
+		return nil, ErrUnknownFormat
+	}
+}
+
+// Bytes returns the raw file content. The caller must not modify it.
+func (f *File) Bytes() []byte {
+	return f.raw
+}
+
+// HasSymbols reports whether the binary has a symbol table.
+func (f *File) HasSymbols() bool {
+	return len(f.symbols) > 0
+}
+
+// StringVar returns the value of the Go string variable with the given fully
+// qualified name (e.g. "main.version"). found is false if the binary has no
+// such symbol, for example because the linker removed an unused variable.
+// Returns ErrNoSymbols if the binary has no symbol table at all.
+func (f *File) StringVar(name string) (value string, found bool, err error) {
+	if !f.HasSymbols() {
+		return "", false, ErrNoSymbols
+	}
+	addr, ok := f.symbols[name]
+	if !ok {
+		return "", false, nil
+	}
+	value, err = f.stringAt(addr)
+	if err != nil {
+		return "", true, fmt.Errorf("%s: %w", name, err)
+	}
+	return value, true, nil
+}
+
+// DataStrings returns the strings referenced by string headers (pointer and
+// length pairs) in the writable data sections, in file order. Strings that are
+// empty or not valid UTF-8 are skipped. This works on stripped binaries but
+// cannot tell which variable a string belongs to.
+func (f *File) DataStrings() []string {
+	var values []string
+	step := uint64(f.ptrSize)
+	for _, sec := range f.sections {
+		if !sec.writable {
+			continue
+		}
+		for off := uint64(0); off+2*step <= uint64(len(sec.data)); off += step {
+			ptr := f.word(sec.data[off:])
+			length := f.word(sec.data[off+step:])
+			if length == 0 || length > maxStringLen || ptr == 0 {
+				continue
+			}
+			data, ok := f.read(ptr, length)
+			if !ok || !isText(data) {
+				continue
+			}
+			values = append(values, string(data))
+		}
+	}
+	return values
+}
+
+// stringAt reads the string header at addr and the bytes it points to.
+func (f *File) stringAt(addr uint64) (string, error) {
+	header, ok := f.read(addr, uint64(2*f.ptrSize))
+	if !ok {
+		return "", fmt.Errorf("address %#x not mapped", addr)
+	}
+	ptr := f.word(header)
+	length := f.word(header[f.ptrSize:])
+	if length == 0 {
+		return "", nil
+	}
+	if length > maxStringLen {
+		return "", fmt.Errorf("string length %d too large", length)
+	}
+	data, ok := f.read(ptr, length)
+	if !ok {
+		return "", fmt.Errorf("string data at %#x not mapped", ptr)
+	}
+	return string(data), nil
+}
+
+// read returns n bytes at virtual address addr. Bytes of zero-filled sections read as zero.
+func (f *File) read(addr, n uint64) ([]byte, bool) {
+	for _, sec := range f.sections {
+		if addr < sec.addr || addr-sec.addr > sec.size || n > sec.size-(addr-sec.addr) {
+			continue
+		}
+		off := addr - sec.addr
+		out := make([]byte, n)
+		if off < uint64(len(sec.data)) {
+			copy(out, sec.data[off:])
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+// word decodes a pointer-sized integer.
+func (f *File) word(b []byte) uint64 {
+	if f.ptrSize == 4 {
+		return uint64(f.byteOrder.Uint32(b))
+	}
+	return f.byteOrder.Uint64(b)
+}
+
+// isText reports whether b looks like a string literal: valid UTF-8 without
+// control characters other than tab and newlines.
+func isText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, c := range b {
+		if c < 0x20 && c != '\t' && c != '\n' && c != '\r' || c == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+func parseELF(raw []byte) (*File, error) {
+	ef, err := elf.NewFile(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	f := &File{raw: raw, ptrSize: 4, byteOrder: ef.ByteOrder}
+	if ef.Class == elf.ELFCLASS64 {
+		f.ptrSize = 8
+	}
+
+	for _, s := range ef.Sections {
+		if s.Flags&elf.SHF_ALLOC == 0 || s.Addr == 0 {
+			continue
+		}
+		sec := section{name: s.Name, addr: s.Addr, size: s.Size}
+		if s.Type != elf.SHT_NOBITS {
+			if sec.data, err = s.Data(); err != nil {
+				return nil, err
+			}
+			sec.writable = s.Flags&elf.SHF_WRITE != 0 && s.Name == ".data"
+		}
+		f.sections = append(f.sections, sec)
+	}
+
+	if syms, err := ef.Symbols(); err == nil {
+		f.symbols = make(map[string]uint64, len(syms))
+		for _, sym := range syms {
+			f.symbols[sym.Name] = sym.Value
+		}
+	}
+	return f, nil
+}
+
+func parseMachO(raw []byte) (*File, error) {
+	mf, err := macho.NewFile(bytes.NewReader(raw))
+	if err != nil {
+		fat, fatErr := macho.NewFatFile(bytes.NewReader(raw))
+		if fatErr != nil || len(fat.Arches) == 0 {
+			return nil, err
+		}
+		mf = fat.Arches[0].File
+	}
+
+	f := &File{raw: raw, ptrSize: 4, byteOrder: mf.ByteOrder}
+	if mf.Magic == macho.Magic64 {
+		f.ptrSize = 8
+	}
+
+	for _, s := range mf.Sections {
+		sec := section{name: s.Name, addr: s.Addr, size: s.Size}
+		// Zero-fill sections (__bss, __noptrbss) have no file content
+		const sectionTypeMask, zeroFill = 0xff, 0x1
+		if s.Flags&sectionTypeMask != zeroFill && s.Offset != 0 {
+			if sec.data, err = s.Data(); err != nil {
+				return nil, err
+			}
+			sec.writable = s.Seg == "__DATA" && s.Name == "__data"
+		}
+		f.sections = append(f.sections, sec)
+	}
+
+	if mf.Symtab != nil {
+		f.symbols = make(map[string]uint64, len(mf.Symtab.Syms))
+		for _, sym := range mf.Symtab.Syms {
+			f.symbols[sym.Name] = sym.Value
+			// C toolchains prefix symbols with '_'; accept both spellings
+			if len(sym.Name) > 1 && sym.Name[0] == '_' {
+				if _, ok := f.symbols[sym.Name[1:]]; !ok {
+					f.symbols[sym.Name[1:]] = sym.Value
+				}
+			}
+		}
+	}
+	return f, nil
+}
+
+func parsePE(raw []byte) (*File, error) {
+	pf, err := pe.NewFile(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	var imageBase uint64
+	f := &File{raw: raw, ptrSize: 4, byteOrder: binary.LittleEndian}
+	switch oh := pf.OptionalHeader.(type) {
+	case *pe.OptionalHeader64:
+		imageBase = oh.ImageBase
+		f.ptrSize = 8
+	case *pe.OptionalHeader32:
+		imageBase = uint64(oh.ImageBase)
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	for _, s := range pf.Sections {
+		sec := section{name: s.Name, addr: imageBase + uint64(s.VirtualAddress), size: uint64(s.VirtualSize)}
+		if s.Size > 0 {
+			if sec.data, err = s.Data(); err != nil {
+				return nil, err
+			}
+			if uint64(len(sec.data)) > sec.size {
+				// Raw data is padded to the file alignment
+				sec.data = sec.data[:sec.size]
+			}
+			sec.writable = s.Name == ".data"
+		}
+		f.sections = append(f.sections, sec)
+	}
+
+	if len(pf.Symbols) > 0 {
+		f.symbols = make(map[string]uint64, len(pf.Symbols))
+		for _, sym := range pf.Symbols {
+			if sym.SectionNumber <= 0 || int(sym.SectionNumber) > len(pf.Sections) {
+				continue
+			}
+			s := pf.Sections[sym.SectionNumber-1]
+			f.symbols[sym.Name] = imageBase + uint64(s.VirtualAddress) + uint64(sym.Value)
+		}
+	}
+	return f, nil
+}
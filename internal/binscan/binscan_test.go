@@ -0,0 +1,109 @@
+package binscan
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCommit = "0123456789abcdef0123456789abcdef01234567"
+
+// buildProg builds testdata/prog for the given platform with commit and tag
+// injected via -X and returns the binary path.
+func buildProg(t *testing.T, goos, goarch string, extraLdflags string) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds a binary")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+
+	out := filepath.Join(t.TempDir(), "prog")
+	ldflags := "-X main.commit=" + testCommit + " -X main.tag=v1.2.3 " + extraLdflags
+	cmd := exec.Command(goBin, "build", "-o", out, "-ldflags", ldflags, "./testdata/prog")
+	cmd.Env = append(os.Environ(), "GOOS="+goos, "GOARCH="+goarch, "CGO_ENABLED=0")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	return out
+}
+
+func TestStringVar(t *testing.T) {
+	tests := map[string]struct {
+		goos   string
+		goarch string
+	}{
+		"elf_amd64":   {goos: "linux", goarch: "amd64"},
+		"elf_386":     {goos: "linux", goarch: "386"},
+		"macho_arm64": {goos: "darwin", goarch: "arm64"},
+		"pe_amd64":    {goos: "windows", goarch: "amd64"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, err := Open(buildProg(t, tt.goos, tt.goarch, ""))
+			require.NoError(t, err)
+			require.True(t, f.HasSymbols())
+
+			commit, found, err := f.StringVar("main.commit")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, testCommit, commit)
+
+			tag, found, err := f.StringVar("main.tag")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, "v1.2.3", tag)
+
+			_, found, err = f.StringVar("main.doesNotExist")
+			require.NoError(t, err)
+			assert.False(t, found)
+		})
+	}
+}
+
+func TestStripped(t *testing.T) {
+	f, err := Open(buildProg(t, "linux", "amd64", "-s -w"))
+	require.NoError(t, err)
+	assert.False(t, f.HasSymbols())
+
+	_, _, err = f.StringVar("main.commit")
+	assert.True(t, errors.Is(err, ErrNoSymbols))
+
+	assert.Contains(t, f.DataStrings(), testCommit)
+	assert.Contains(t, f.DataStrings(), "v1.2.3")
+}
+
+func TestParse_UnknownFormat(t *testing.T) {
+	tests := map[string][]byte{
+		"empty":      nil,
+		"text":       []byte("manifest_version: \"1.0\"\n"),
+		"truncated":  []byte("\x7fELF\x02\x01\x01"),
+		"truncatedz": []byte("MZ"),
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(data)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestOpen_Missing(t *testing.T) {
+	_, err := Open(filepath.Join(t.TempDir(), "missing"))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestIsText(t *testing.T) {
+	assert.True(t, isText([]byte("v1.2.3\tok\n")))
+	assert.True(t, isText([]byte("grüße")))
+	assert.False(t, isText([]byte{0xff, 0xfe}))
+	assert.False(t, isText([]byte("a\x00b")))
+}
@@ -0,0 +1,13 @@
+// Command prog is built by the binscan tests with -ldflags -X.
+package main
+
+import "fmt"
+
+var (
+	commit = "dev"
+	tag    = ""
+)
+
+func main() {
+	fmt.Println(commit, tag)
+}
@@ -0,0 +1,346 @@
+package gitrepo
+
+import (
+	"bufio"
+	"container/heap"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxWalkCommits bounds history walks. Describing a commit further than this
+// from its nearest tag fails with ErrWalkLimit rather than reading the whole
+// history of a large repository.
+const maxWalkCommits = 10000
+
+// ErrWalkLimit is returned when a history walk exceeds maxWalkCommits
+var ErrWalkLimit = errors.New("history walk limit exceeded")
+
+// ErrNoTag is returned by Describe when no tag is reachable from the commit
+var ErrNoTag = errors.New("no tag reachable")
+
+// Commit holds the parts of a commit object needed for describing it.
+type Commit struct {
+	// Parents are the parent commit ids
+	Parents []string
+
+	// Time is the committer timestamp
+	Time time.Time
+}
+
+// Description is the result of Describe.
+type Description struct {
+	// Tags are the tags on the nearest tagged ancestor (at least one)
+	Tags []Tag
+
+	// Distance is the number of commits reachable from the described commit
+	// but not from the tagged ancestor (0 if the commit itself is tagged)
+	Distance int
+}
+
+// ReadCommit reads and parses the commit object id.
+func (r *Repo) ReadCommit(id string) (*Commit, error) {
+	obj, err := r.ReadObject(id)
+	if err != nil {
+		return nil, err
+	}
+	if obj.Type != ObjectCommit {
+		return nil, fmt.Errorf("object %s is a %s, not a commit", id, obj.Type)
+	}
+
+	commit := &Commit{}
+	for _, line := range strings.Split(string(obj.Data), "\n") {
+		if line == "" {
+			break // end of headers
+		}
+		switch key, value, _ := strings.Cut(line, " "); key {
+		case "parent":
+			if isObjectID(value) {
+				commit.Parents = append(commit.Parents, value)
+			}
+		case "committer":
+			commit.Time = parseSignatureTime(value)
+		}
+	}
+	return commit, nil
+}
+
+// parseSignatureTime extracts the timestamp of "Name <email> 1700000000 +0100".
+func parseSignatureTime(signature string) time.Time {
+	fields := strings.Fields(signature[strings.LastIndex(signature, ">")+1:])
+	if len(fields) != 2 {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
+
+// Describe finds the nearest tagged ancestor of commit, like "git describe --tags".
+//
+// Ancestors are visited breadth-first, so the tag with the fewest hops wins.
+// History before the tagged ancestor is only read as far as needed to tell it
+// apart from the commits being counted.
+// Returns ErrNoTag if no tag is reachable and ErrWalkLimit for very long histories.
+func (r *Repo) Describe(commit string) (Description, error) {
+	tags, err := r.Tags()
+	if err != nil {
+		return Description{}, err
+	}
+	byCommit := make(map[string][]Tag)
+	for _, tag := range tags {
+		byCommit[tag.Commit] = append(byCommit[tag.Commit], tag)
+	}
+	if len(byCommit) == 0 {
+		return Description{}, ErrNoTag
+	}
+
+	// Commits read while finding the tag are reused when counting the distance
+	commits := make(map[string]*Commit)
+	read := func(id string) (*Commit, error) {
+		if c, ok := commits[id]; ok {
+			return c, nil
+		}
+		c, err := r.ReadCommit(id)
+		if err != nil {
+			return nil, err
+		}
+		commits[id] = c
+		return c, nil
+	}
+
+	var tagged string
+	err = walk(commit, read, func(id string) bool {
+		if _, ok := byCommit[id]; ok {
+			tagged = id
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return Description{}, err
+	}
+	if tagged == "" {
+		return Description{}, ErrNoTag
+	}
+	if tagged == commit {
+		return Description{Tags: byCommit[tagged]}, nil
+	}
+
+	distance, err := distance(commit, tagged, read)
+	if err != nil {
+		return Description{}, err
+	}
+	return Description{Tags: byCommit[tagged], Distance: distance}, nil
+}
+
+// walk visits commit and its ancestors breadth-first until visit returns false.
+func walk(start string, read func(id string) (*Commit, error), visit func(id string) bool) error {
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		if !visit(id) {
+			return nil
+		}
+		if len(seen) > maxWalkCommits {
+			return ErrWalkLimit
+		}
+
+		commit, err := read(id)
+		if err != nil {
+			return err
+		}
+		for _, parent := range commit.Parents {
+			if !seen[parent] {
+				seen[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return nil
+}
+
+// Sides of a distance walk a commit is reachable from
+const (
+	fromCommit = 1 << iota
+	fromTag
+)
+
+// distance counts the commits reachable from commit but not from tagged.
+//
+// Both sides are walked together, newest commit first, painting each commit
+// with the side that reaches it. The walk stops once every pending commit is
+// reachable from tagged, so the history before the tag is not read. Like
+// "git describe", this relies on committer times: a parent committed after
+// its child (clock skew) can make the count slightly off.
+func distance(commit, tagged string, read func(id string) (*Commit, error)) (int, error) {
+	flags := make(map[string]int)
+	counted := make(map[string]bool)
+	queue := &commitQueue{}
+	commitOnly := 0 // queued commits not (yet) known to be reachable from tagged
+
+	push := func(id string, side int) error {
+		c, err := read(id)
+		if err != nil {
+			return err
+		}
+		flags[id] = side
+		if side == fromCommit {
+			commitOnly++
+		}
+		queue.push(id, c)
+		return nil
+	}
+	if err := push(commit, fromCommit); err != nil {
+		return 0, err
+	}
+	if err := push(tagged, fromTag); err != nil {
+		return 0, err
+	}
+
+	distance := 0
+	for visited := 0; commitOnly > 0; visited++ {
+		if visited > maxWalkCommits {
+			return 0, ErrWalkLimit
+		}
+
+		id, c := queue.pop()
+		side := flags[id]
+		switch {
+		case side == fromCommit:
+			commitOnly--
+			counted[id] = true
+			distance++
+		case counted[id]:
+			// Reached from tagged after being counted: clock skew
+			counted[id] = false
+			distance--
+		}
+
+		for _, parent := range c.Parents {
+			old, seen := flags[parent]
+			if old&fromTag != 0 || old == side {
+				continue
+			}
+			if !seen {
+				if err := push(parent, side); err != nil {
+					return 0, err
+				}
+				continue
+			}
+			// Painted fromCommit before; now also reachable from tagged
+			flags[parent] = old | fromTag
+			if queue.contains(parent) {
+				commitOnly--
+			} else if err := push(parent, old|fromTag); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return distance, nil
+}
+
+// commitQueue orders pending commits newest first, oldest insertion first on
+// equal times (breadth-first for histories committed within one second).
+type commitQueue struct {
+	items   []queuedCommit
+	pending map[string]bool
+	seq     int
+}
+
+// queuedCommit is an entry of a commitQueue
+type queuedCommit struct {
+	id     string
+	commit *Commit
+	seq    int
+}
+
+func (q *commitQueue) Len() int { return len(q.items) }
+
+func (q *commitQueue) Less(i, j int) bool {
+	a, b := q.items[i], q.items[j]
+	if !a.commit.Time.Equal(b.commit.Time) {
+		return a.commit.Time.After(b.commit.Time)
+	}
+	return a.seq < b.seq
+}
+
+func (q *commitQueue) Swap(i, j int) { q.items[i], q.items[j] = q.items[j], q.items[i] }
+
+func (q *commitQueue) Push(x any) { q.items = append(q.items, x.(queuedCommit)) }
+
+func (q *commitQueue) Pop() any {
+	last := q.items[len(q.items)-1]
+	q.items = q.items[:len(q.items)-1]
+	return last
+}
+
+func (q *commitQueue) push(id string, c *Commit) {
+	if q.pending == nil {
+		q.pending = make(map[string]bool)
+	}
+	q.pending[id] = true
+	q.seq++
+	heap.Push(q, queuedCommit{id: id, commit: c, seq: q.seq})
+}
+
+func (q *commitQueue) pop() (string, *Commit) {
+	item := heap.Pop(q).(queuedCommit)
+	delete(q.pending, item.id)
+	return item.id, item.commit
+}
+
+func (q *commitQueue) contains(id string) bool { return q.pending[id] }
+
+// RemoteURL returns the configured URL of the named remote (e.g. "origin").
+// Returns ErrRefNotFound if the remote is not configured.
+//
+// Only the repository's own config file is read; include directives and
+// url.<base>.insteadOf rewrites are not applied.
+func (r *Repo) RemoteURL(name string) (string, error) {
+	f, err := os.Open(filepath.Join(r.commonDir, "config"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	section := fmt.Sprintf(`remote "%s"`, name)
+	inSection := false
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			header := strings.TrimSpace(strings.Trim(line, "[]"))
+			// Section names are case-insensitive, subsection names are not
+			sectionName, subsection, _ := strings.Cut(header, " ")
+			inSection = strings.EqualFold(sectionName, "remote") &&
+				strings.TrimSpace(subsection) == section[len("remote "):]
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.EqualFold(strings.TrimSpace(key), "url") {
+			return strings.Trim(strings.TrimSpace(value), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%w: remote %s", ErrRefNotFound, name)
+}
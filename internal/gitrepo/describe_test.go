@@ -0,0 +1,182 @@
+package gitrepo
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepo_ReadCommit(t *testing.T) {
+	f := newFixture(t)
+	first := f.loose(ObjectCommit, commitData(emptyTree, "", "first"))
+	second := f.loose(ObjectCommit, commitData(emptyTree, first, "second"))
+
+	repo, err := Open(f.gitDir)
+	require.NoError(t, err)
+
+	commit, err := repo.ReadCommit(second)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first}, commit.Parents)
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), commit.Time)
+
+	tag := f.loose(ObjectTag, tagData(second, ObjectCommit, "v1"))
+	_, err = repo.ReadCommit(tag)
+	assert.Error(t, err, "tag objects are not commits")
+}
+
+func TestRepo_Describe(t *testing.T) {
+	f := newFixture(t)
+
+	// c0 <- c1 (v1.0.0) <- c2 <- c3 <- m (merge of c3 and side) ; side branches off c1
+	c0 := f.loose(ObjectCommit, commitData(emptyTree, "", "c0"))
+	c1 := f.loose(ObjectCommit, commitData(emptyTree, c0, "c1"))
+	c2 := f.loose(ObjectCommit, commitData(emptyTree, c1, "c2"))
+	c3 := f.loose(ObjectCommit, commitData(emptyTree, c2, "c3"))
+	side := f.loose(ObjectCommit, commitData(emptyTree, c1, "side"))
+	merge := f.loose(ObjectCommit, []byte(fmt.Sprintf(
+		"tree %s\nparent %s\nparent %s\ncommitter Jane Doe <jane@example.com> 1700000000 +0000\n\nmerge\n",
+		emptyTree, c3, side)))
+	f.write("refs/tags/v1.0.0", f.loose(ObjectTag, tagData(c1, ObjectCommit, "v1.0.0"))+"\n")
+
+	repo, err := Open(f.gitDir)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		commit   string
+		distance int
+		wantErr  error
+	}{
+		"tagged_commit": {commit: c1, distance: 0},
+		"linear":        {commit: c3, distance: 2},
+		"merge":         {commit: merge, distance: 4},
+		"before_tag":    {commit: c0, wantErr: ErrNoTag},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc, err := repo.Describe(tt.commit)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, desc.Tags, 1)
+			assert.Equal(t, "v1.0.0", desc.Tags[0].Name)
+			assert.Equal(t, tt.distance, desc.Distance)
+		})
+	}
+}
+
+func TestRepo_DescribeLongHistory(t *testing.T) {
+	f := newFixture(t)
+
+	// More commits before the tag than a walk may visit; the tag is near the head
+	var commits []string
+	parent := ""
+	for i := 0; i < maxWalkCommits+50; i++ {
+		parent = f.loose(ObjectCommit, commitData(emptyTree, parent, fmt.Sprintf("c%d", i)))
+		commits = append(commits, parent)
+	}
+	tagged := commits[len(commits)-10]
+	f.write("refs/tags/v1.0.0", tagged+"\n")
+
+	repo, err := Open(f.gitDir)
+	require.NoError(t, err)
+
+	desc, err := repo.Describe(parent)
+	require.NoError(t, err)
+	require.Len(t, desc.Tags, 1)
+	assert.Equal(t, "v1.0.0", desc.Tags[0].Name)
+	assert.Equal(t, 9, desc.Distance)
+
+	// A tag further away than the limit is still not searched for
+	f.write("refs/tags/v1.0.0", commits[0]+"\n")
+	_, err = repo.Describe(parent)
+	assert.ErrorIs(t, err, ErrWalkLimit)
+}
+
+func TestRepo_RemoteURL(t *testing.T) {
+	f := newFixture(t)
+	f.write("config", strings.Join([]string{
+		"[core]",
+		"\trepositoryformatversion = 0",
+		`[remote "upstream"]`,
+		"\turl = https://example.com/upstream.git",
+		`[Remote "origin"]`,
+		"\tfetch = +refs/heads/*:refs/remotes/origin/*",
+		`	URL = "git@github.com:acme/app.git"`,
+		"",
+	}, "\n"))
+
+	repo, err := Open(f.gitDir)
+	require.NoError(t, err)
+
+	url, err := repo.RemoteURL("origin")
+	require.NoError(t, err)
+	assert.Equal(t, "git@github.com:acme/app.git", url)
+
+	url, err = repo.RemoteURL("upstream")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/upstream.git", url)
+
+	_, err = repo.RemoteURL("missing")
+	assert.ErrorIs(t, err, ErrRefNotFound)
+}
+
+// TestRepo_DescribeMatchesGitBinary cross-checks Describe against "git describe".
+// Skipped when git is unavailable.
+func TestRepo_DescribeMatchesGitBinary(t *testing.T) {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command(gitPath, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	git("init", "-q", "-b", "main")
+	commit := func(msg string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "f.txt"), []byte(msg), 0o644))
+		git("add", "f.txt")
+		git("commit", "-q", "-m", msg)
+	}
+	commit("one")
+	git("tag", "-a", "v0.1.0", "-m", "release")
+	commit("two")
+	git("checkout", "-q", "-b", "feature")
+	commit("feature")
+	git("checkout", "-q", "main")
+	commit("three")
+	git("merge", "-q", "--no-ff", "-X", "ours", "-m", "merge", "feature")
+	git("gc", "-q")
+
+	repo, err := Discover(dir)
+	require.NoError(t, err)
+	head, err := repo.Head()
+	require.NoError(t, err)
+
+	desc, err := repo.Describe(head.Commit)
+	require.NoError(t, err)
+	require.Len(t, desc.Tags, 1)
+
+	want := git("describe", "--tags", "--long")
+	assert.Equal(t, want, fmt.Sprintf("%s-%d-g%s", desc.Tags[0].Name, desc.Distance, head.Commit[:7]))
+}
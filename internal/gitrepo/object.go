@@ -0,0 +1,460 @@
+package gitrepo
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxObjectSize bounds inflated object sizes. Commits and tags are tiny;
+	// the limit protects against decompression bombs in corrupt repositories.
+	maxObjectSize = 16 << 20
+
+	// maxDeltaDepth bounds delta chains in pack files
+	maxDeltaDepth = 64
+)
+
+// Object types as named in loose object headers
+const (
+	ObjectCommit = "commit"
+	ObjectTree   = "tree"
+	ObjectBlob   = "blob"
+	ObjectTag    = "tag"
+)
+
+// Pack object type codes
+const (
+	packCommit   = 1
+	packTree     = 2
+	packBlob     = 3
+	packTag      = 4
+	packOfsDelta = 6
+	packRefDelta = 7
+)
+
+// ErrObjectNotFound is returned when an object is neither loose nor packed
+var ErrObjectNotFound = errors.New("object not found")
+
+// Object is an inflated git object.
+type Object struct {
+	// Type is one of ObjectCommit, ObjectTree, ObjectBlob or ObjectTag
+	Type string
+
+	// Data is the object content without the loose object header
+	Data []byte
+}
+
+// ReadObject reads the object with the given id from loose objects or pack files.
+func (r *Repo) ReadObject(id string) (*Object, error) {
+	return r.readObject(id, 0)
+}
+
+// readObject reads an object; depth counts enclosing delta resolutions.
+func (r *Repo) readObject(id string, depth int) (*Object, error) {
+	if !isObjectID(id) {
+		return nil, fmt.Errorf("invalid object id %q", id)
+	}
+
+	obj, err := r.readLooseObject(id)
+	if !errors.Is(err, os.ErrNotExist) {
+		return obj, err
+	}
+
+	return r.readPackedObject(id, depth)
+}
+
+// readLooseObject reads objects/<id[:2]>/<id[2:]>: a zlib stream of
+// "<type> <size>\x00<content>".
+func (r *Repo) readLooseObject(id string) (*Object, error) {
+	f, err := os.Open(filepath.Join(r.commonDir, "objects", id[:2], id[2:]))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := inflate(f, maxObjectSize+64)
+	if err != nil {
+		return nil, fmt.Errorf("object %s: %w", id, err)
+	}
+
+	header, content, ok := bytes.Cut(data, []byte{0})
+	if !ok {
+		return nil, fmt.Errorf("object %s: missing header", id)
+	}
+	typ, sizeStr, ok := strings.Cut(string(header), " ")
+	if !ok {
+		return nil, fmt.Errorf("object %s: invalid header %q", id, header)
+	}
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size != len(content) {
+		return nil, fmt.Errorf("object %s: size mismatch", id)
+	}
+
+	return &Object{Type: typ, Data: content}, nil
+}
+
+// readPackedObject looks id up in every pack index and reads it from the pack.
+func (r *Repo) readPackedObject(id string, depth int) (*Object, error) {
+	raw, err := hex.DecodeString(id)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := r.packIndexes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, idx := range indexes {
+		offset, found, err := idx.find(raw)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		pack, err := r.packFile(idx)
+		if err != nil {
+			return nil, err
+		}
+		obj, err := r.readPackObjectAt(pack, offset, len(raw), depth)
+		if err != nil {
+			return nil, fmt.Errorf("object %s: %w", id, err)
+		}
+		return obj, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, id)
+}
+
+// packIndex is a loaded pack index file
+type packIndex struct {
+	path string
+	data []byte
+
+	// pack is the open pack file (nil until first use, guarded by Repo.mu)
+	pack *os.File
+}
+
+// packFile returns the pack file of idx, opening it on first use and keeping
+// it open so a history walk does not reopen the pack for every commit.
+// Reads go through ReadAt, so the shared handle is safe for concurrent use.
+func (r *Repo) packFile(idx *packIndex) (*os.File, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if idx.pack == nil {
+		pack, err := os.Open(strings.TrimSuffix(idx.path, ".idx") + ".pack")
+		if err != nil {
+			return nil, err
+		}
+		idx.pack = pack
+	}
+	return idx.pack, nil
+}
+
+// packIndexes loads all pack indexes once and caches them, so walking many
+// commits does not re-read the index for every object.
+func (r *Repo) packIndexes() ([]*packIndex, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.indexes != nil {
+		return r.indexes, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(r.commonDir, "objects", "pack", "pack-*.idx"))
+	if err != nil {
+		return nil, err
+	}
+	indexes := make([]*packIndex, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, &packIndex{path: path, data: data})
+	}
+	r.indexes = indexes
+	return indexes, nil
+}
+
+// find searches a version 2 pack index for the raw object id and returns the
+// object's offset in the pack.
+//
+// Layout: magic, version, 256 fanout entries, N ids, N CRCs, N 4-byte offsets
+// (MSB set: index into the following 8-byte offset table).
+func (idx *packIndex) find(id []byte) (int64, bool, error) {
+	data, path := idx.data, idx.path
+
+	const headerSize = 8 + 256*4
+	if len(data) < headerSize || !bytes.Equal(data[:4], []byte{0xff, 't', 'O', 'c'}) ||
+		binary.BigEndian.Uint32(data[4:8]) != 2 {
+		return 0, false, fmt.Errorf("%s: unsupported pack index format", path)
+	}
+
+	fanout := func(i int) int {
+		return int(binary.BigEndian.Uint32(data[8+i*4:]))
+	}
+	count := fanout(255)
+	hashLen := len(id)
+	idsStart := headerSize
+	crcStart := idsStart + count*hashLen
+	offsetsStart := crcStart + count*4
+	largeStart := offsetsStart + count*4
+	if len(data) < largeStart {
+		return 0, false, fmt.Errorf("%s: truncated pack index", path)
+	}
+
+	lo := 0
+	if id[0] > 0 {
+		lo = fanout(int(id[0]) - 1)
+	}
+	hi := fanout(int(id[0]))
+	if lo > hi || hi > count {
+		// Fanout entries are cumulative counts bounded by the last one
+		return 0, false, fmt.Errorf("%s: corrupt pack index fanout", path)
+	}
+	for lo < hi {
+		mid := (lo + hi) / 2
+		cmp := bytes.Compare(data[idsStart+mid*hashLen:idsStart+(mid+1)*hashLen], id)
+		switch {
+		case cmp == 0:
+			offset := binary.BigEndian.Uint32(data[offsetsStart+mid*4:])
+			if offset&0x80000000 == 0 {
+				return int64(offset), true, nil
+			}
+			pos := largeStart + int(offset&0x7fffffff)*8
+			if len(data) < pos+8 {
+				return 0, false, fmt.Errorf("%s: truncated pack index", path)
+			}
+			return int64(binary.BigEndian.Uint64(data[pos:])), true, nil
+		case cmp < 0:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return 0, false, nil
+}
+
+// readPackObjectAt reads the object at offset in pack, resolving deltas.
+func (r *Repo) readPackObjectAt(pack *os.File, offset int64, hashLen, depth int) (*Object, error) {
+	if depth > maxDeltaDepth {
+		return nil, fmt.Errorf("delta chain too deep")
+	}
+
+	section := io.NewSectionReader(pack, offset, 1<<62)
+	br := &byteReader{r: section}
+
+	// Header: 3-bit type and a variable-length size
+	c, err := br.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	typ := (c >> 4) & 0x07
+	size := uint64(c & 0x0f)
+	for shift := 4; c&0x80 != 0; shift += 7 {
+		if c, err = br.ReadByte(); err != nil {
+			return nil, err
+		}
+		size |= uint64(c&0x7f) << shift
+	}
+	if size > maxObjectSize {
+		return nil, fmt.Errorf("object too large")
+	}
+
+	var base *Object
+	switch typ {
+	case packOfsDelta:
+		rel, err := readOffsetDelta(br)
+		if err != nil {
+			return nil, err
+		}
+		if rel <= 0 || rel > offset {
+			return nil, fmt.Errorf("invalid delta base offset")
+		}
+		base, err = r.readPackObjectAt(pack, offset-rel, hashLen, depth+1)
+		if err != nil {
+			return nil, err
+		}
+	case packRefDelta:
+		baseID := make([]byte, hashLen)
+		if _, err := io.ReadFull(br, baseID); err != nil {
+			return nil, err
+		}
+		base, err = r.readObject(hex.EncodeToString(baseID), depth+1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := inflate(io.NewSectionReader(pack, offset+br.n, 1<<62), int64(size))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(data)) != size {
+		return nil, fmt.Errorf("size mismatch")
+	}
+
+	if base != nil {
+		patched, err := applyDelta(base.Data, data)
+		if err != nil {
+			return nil, err
+		}
+		return &Object{Type: base.Type, Data: patched}, nil
+	}
+
+	name, ok := packTypeNames[typ]
+	if !ok {
+		return nil, fmt.Errorf("unknown pack object type %d", typ)
+	}
+	return &Object{Type: name, Data: data}, nil
+}
+
+// packTypeNames maps pack type codes to object type names
+var packTypeNames = map[byte]string{
+	packCommit: ObjectCommit,
+	packTree:   ObjectTree,
+	packBlob:   ObjectBlob,
+	packTag:    ObjectTag,
+}
+
+// readOffsetDelta decodes the base offset of an OFS_DELTA object.
+func readOffsetDelta(br io.ByteReader) (int64, error) {
+	c, err := br.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	rel := int64(c & 0x7f)
+	for c&0x80 != 0 {
+		if c, err = br.ReadByte(); err != nil {
+			return 0, err
+		}
+		if rel > (1<<55)-1 {
+			return 0, fmt.Errorf("invalid delta base offset")
+		}
+		rel = ((rel + 1) << 7) | int64(c&0x7f)
+	}
+	return rel, nil
+}
+
+// applyDelta applies a git delta to base.
+//
+// A delta starts with the source and target sizes, followed by copy
+// instructions (MSB set: offset/size from base) and insert instructions
+// (MSB clear: the next n bytes are literal data).
+func applyDelta(base, delta []byte) ([]byte, error) {
+	br := bytes.NewReader(delta)
+
+	srcSize, err := binary.ReadUvarint(br)
+	if err != nil || srcSize != uint64(len(base)) {
+		return nil, fmt.Errorf("delta base size mismatch")
+	}
+	dstSize, err := binary.ReadUvarint(br)
+	if err != nil || dstSize > maxObjectSize {
+		return nil, fmt.Errorf("invalid delta target size")
+	}
+
+	out := make([]byte, 0, dstSize)
+	for br.Len() > 0 {
+		c, _ := br.ReadByte()
+
+		if c&0x80 == 0 {
+			if c == 0 {
+				return nil, fmt.Errorf("invalid delta instruction")
+			}
+			n := int(c)
+			if br.Len() < n {
+				return nil, fmt.Errorf("truncated delta")
+			}
+			buf := make([]byte, n)
+			_, _ = br.Read(buf)
+			out = append(out, buf...)
+			continue
+		}
+
+		var offset, size uint64
+		for i := 0; i < 4; i++ {
+			if c&(1<<i) != 0 {
+				b, err := br.ReadByte()
+				if err != nil {
+					return nil, fmt.Errorf("truncated delta")
+				}
+				offset |= uint64(b) << (8 * i)
+			}
+		}
+		for i := 0; i < 3; i++ {
+			if c&(1<<(4+i)) != 0 {
+				b, err := br.ReadByte()
+				if err != nil {
+					return nil, fmt.Errorf("truncated delta")
+				}
+				size |= uint64(b) << (8 * i)
+			}
+		}
+		if size == 0 {
+			size = 0x10000
+		}
+		if offset+size > uint64(len(base)) {
+			return nil, fmt.Errorf("delta copy out of range")
+		}
+		out = append(out, base[offset:offset+size]...)
+	}
+
+	if uint64(len(out)) != dstSize {
+		return nil, fmt.Errorf("delta target size mismatch")
+	}
+	return out, nil
+}
+
+// inflate decompresses a zlib stream, failing if it exceeds limit bytes.
+func inflate(r io.Reader, limit int64) ([]byte, error) {
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	data, err := io.ReadAll(io.LimitReader(zr, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("object too large")
+	}
+	return data, nil
+}
+
+// byteReader is an io.ByteReader over an io.Reader that counts consumed bytes.
+type byteReader struct {
+	r   io.Reader
+	n   int64
+	buf [1]byte
+}
+
+// ReadByte implements io.ByteReader
+func (b *byteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(b.r, b.buf[:]); err != nil {
+		return 0, err
+	}
+	b.n++
+	return b.buf[0], nil
+}
+
+// Read implements io.Reader
+func (b *byteReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.n += int64(n)
+	return n, err
+}
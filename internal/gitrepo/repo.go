@@ -0,0 +1,444 @@
+// Package gitrepo reads git metadata directly from a .git directory.
+//
+// It understands HEAD, loose refs, packed-refs, loose objects and pack files
+// (including delta objects), which is enough to resolve the current commit,
+// branch and tags without a git binary. It never writes to the repository.
+package gitrepo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// maxSymrefDepth bounds symbolic ref chains (HEAD -> refs/heads/main -> ...)
+	maxSymrefDepth = 5
+
+	// maxRefFileSize bounds the size of ref and HEAD files
+	maxRefFileSize = 4 << 10
+
+	// refPrefixHeads is the prefix of branch refs
+	refPrefixHeads = "refs/heads/"
+
+	// refPrefixTags is the prefix of tag refs
+	refPrefixTags = "refs/tags/"
+)
+
+// ErrNotRepository is returned when no .git directory can be found
+var ErrNotRepository = errors.New("not a git repository")
+
+// ErrRefNotFound is returned when a ref does not exist
+var ErrRefNotFound = errors.New("ref not found")
+
+// Repo is a read-only view of a git repository's metadata.
+// It is safe for concurrent use.
+type Repo struct {
+	// gitDir holds HEAD (per worktree)
+	gitDir string
+
+	// commonDir holds refs, packed-refs and objects (shared by worktrees)
+	commonDir string
+
+	// mu protects indexes and their open pack files
+	mu sync.Mutex
+
+	// indexes caches loaded pack indexes (nil until first use)
+	indexes []*packIndex
+}
+
+// Close releases the pack files held open by object reads.
+// The Repo may still be used afterwards; packs are reopened on demand.
+func (r *Repo) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var firstErr error
+	for _, idx := range r.indexes {
+		if idx.pack == nil {
+			continue
+		}
+		if err := idx.pack.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		idx.pack = nil
+	}
+	return firstErr
+}
+
+// Head describes the commit checked out in a repository.
+type Head struct {
+	// Commit is the full object id of the checked-out commit
+	Commit string
+
+	// Branch is the short branch name, or empty for a detached HEAD
+	Branch string
+}
+
+// Tag is a tag ref.
+type Tag struct {
+	// Name is the short tag name (without refs/tags/)
+	Name string
+
+	// Target is the object the ref points to (a tag object for annotated tags)
+	Target string
+
+	// Commit is the commit the tag ultimately points to
+	Commit string
+
+	// Annotated reports whether Target is an annotated tag object
+	Annotated bool
+}
+
+// Discover finds the repository containing dir by walking up the directory tree.
+//
+// Both .git directories and .git files ("gitdir: <path>", used by worktrees
+// and submodules) are supported.
+func Discover(dir string) (*Repo, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		candidate := filepath.Join(dir, ".git")
+		if fi, err := os.Stat(candidate); err == nil {
+			if fi.IsDir() {
+				return Open(candidate)
+			}
+			gitDir, err := readGitFile(candidate)
+			if err != nil {
+				return nil, err
+			}
+			return Open(gitDir)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, ErrNotRepository
+		}
+		dir = parent
+	}
+}
+
+// Open opens the git directory gitDir (the ".git" directory itself).
+func Open(gitDir string) (*Repo, error) {
+	if fi, err := os.Stat(filepath.Join(gitDir, "HEAD")); err != nil || fi.IsDir() {
+		return nil, ErrNotRepository
+	}
+
+	r := &Repo{gitDir: gitDir, commonDir: gitDir}
+
+	// Linked worktrees keep refs and objects in the main repository
+	if data, err := readSmallFile(filepath.Join(gitDir, "commondir")); err == nil {
+		common := strings.TrimSpace(string(data))
+		if !filepath.IsAbs(common) {
+			common = filepath.Join(gitDir, common)
+		}
+		r.commonDir = filepath.Clean(common)
+	}
+
+	return r, nil
+}
+
+// readGitFile resolves a ".git" file of the form "gitdir: <path>".
+func readGitFile(path string) (string, error) {
+	data, err := readSmallFile(path)
+	if err != nil {
+		return "", err
+	}
+	line := strings.TrimSpace(string(data))
+	gitDir, ok := strings.CutPrefix(line, "gitdir:")
+	if !ok {
+		return "", ErrNotRepository
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+	return filepath.Clean(gitDir), nil
+}
+
+// Head resolves HEAD to a commit and, unless detached, the current branch.
+// An unborn branch (no commits yet) returns ErrRefNotFound.
+func (r *Repo) Head() (Head, error) {
+	data, err := readSmallFile(filepath.Join(r.gitDir, "HEAD"))
+	if err != nil {
+		return Head{}, err
+	}
+	content := strings.TrimSpace(string(data))
+
+	target, symbolic := strings.CutPrefix(content, "ref:")
+	if !symbolic {
+		if !isObjectID(content) {
+			return Head{}, fmt.Errorf("invalid HEAD %q", content)
+		}
+		return Head{Commit: content}, nil
+	}
+
+	ref := strings.TrimSpace(target)
+	commit, err := r.ResolveRef(ref)
+	if err != nil {
+		return Head{}, err
+	}
+	return Head{Commit: commit, Branch: strings.TrimPrefix(ref, refPrefixHeads)}, nil
+}
+
+// ResolveRef resolves a full ref name such as "refs/heads/main" to an object id,
+// following symbolic refs and falling back to packed-refs.
+func (r *Repo) ResolveRef(name string) (string, error) {
+	for depth := 0; depth < maxSymrefDepth; depth++ {
+		if !isSafeRefName(name) {
+			return "", fmt.Errorf("invalid ref name %q", name)
+		}
+
+		data, err := readSmallFile(filepath.Join(r.commonDir, filepath.FromSlash(name)))
+		if errors.Is(err, os.ErrNotExist) {
+			refs, err := r.packedRefs()
+			if err != nil {
+				return "", err
+			}
+			if id, ok := refs[name]; ok {
+				return id.target, nil
+			}
+			return "", fmt.Errorf("%w: %s", ErrRefNotFound, name)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		content := strings.TrimSpace(string(data))
+		if target, symbolic := strings.CutPrefix(content, "ref:"); symbolic {
+			name = strings.TrimSpace(target)
+			continue
+		}
+		if !isObjectID(content) {
+			return "", fmt.Errorf("invalid ref %s: %q", name, content)
+		}
+		return content, nil
+	}
+	return "", fmt.Errorf("too many levels of symbolic refs")
+}
+
+// Tags returns all tags, sorted by name. Annotated tags are peeled to the
+// commit they point to.
+func (r *Repo) Tags() ([]Tag, error) {
+	packed, err := r.packedRefs()
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]Tag)
+	for ref, p := range packed {
+		if name, ok := strings.CutPrefix(ref, refPrefixTags); ok {
+			tag := Tag{Name: name, Target: p.target, Commit: p.peeled}
+			if tag.Commit == "" && p.fullyPeeled {
+				// No "^" line although git recorded peeled values: a lightweight tag
+				tag.Commit = p.target
+			}
+			byName[name] = tag
+		}
+	}
+
+	// Loose refs take precedence over packed-refs
+	tagsDir := filepath.Join(r.commonDir, "refs", "tags")
+	err = filepath.WalkDir(tagsDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(tagsDir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		target, err := r.ResolveRef(refPrefixTags + name)
+		if err != nil {
+			return nil // skip unreadable refs, like git does for broken refs
+		}
+		byName[name] = Tag{Name: name, Target: target}
+		return nil
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	tags := make([]Tag, 0, len(byName))
+	for _, tag := range byName {
+		if err := r.peelTag(&tag); err != nil {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+// TagsAt returns the tags pointing at commit, sorted by name.
+func (r *Repo) TagsAt(commit string) ([]Tag, error) {
+	tags, err := r.Tags()
+	if err != nil {
+		return nil, err
+	}
+	var matching []Tag
+	for _, tag := range tags {
+		if tag.Commit == commit {
+			matching = append(matching, tag)
+		}
+	}
+	return matching, nil
+}
+
+// peelTag fills tag.Commit and tag.Annotated by following tag objects.
+func (r *Repo) peelTag(tag *Tag) error {
+	if tag.Commit != "" {
+		// Peeled by packed-refs: the target is an annotated tag
+		tag.Annotated = tag.Commit != tag.Target
+		return nil
+	}
+
+	id := tag.Target
+	for depth := 0; depth < maxSymrefDepth; depth++ {
+		obj, err := r.ReadObject(id)
+		if err != nil {
+			return err
+		}
+		if obj.Type != ObjectTag {
+			tag.Commit = id
+			return nil
+		}
+		tag.Annotated = true
+		id, err = tagObjectTarget(obj.Data)
+		if err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("too many levels of nested tags")
+}
+
+// tagObjectTarget returns the "object" header of an annotated tag object.
+func tagObjectTarget(data []byte) (string, error) {
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			break
+		}
+		if target, ok := strings.CutPrefix(line, "object "); ok && isObjectID(target) {
+			return target, nil
+		}
+	}
+	return "", fmt.Errorf("tag object without target")
+}
+
+// packedRef is an entry of the packed-refs file
+type packedRef struct {
+	target string
+	peeled string
+
+	// fullyPeeled is set when the file header promises a "^" line for every
+	// annotated tag, so entries without one point directly at their commit
+	fullyPeeled bool
+}
+
+// packedRefs parses the packed-refs file. A missing file yields an empty map.
+//
+// Lines have the form "<id> <ref>"; a following "^<id>" line holds the peeled
+// commit of an annotated tag. The "# pack-refs with:" header lists traits;
+// "peeled" covers refs/tags/ and "fully-peeled" covers all refs.
+func (r *Repo) packedRefs() (map[string]packedRef, error) {
+	refs := make(map[string]packedRef)
+
+	f, err := os.Open(filepath.Join(r.commonDir, "packed-refs"))
+	if errors.Is(err, os.ErrNotExist) {
+		return refs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var last string
+	var peeled, fullyPeeled bool
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# pack-refs with:"):
+			traits := strings.Fields(strings.TrimPrefix(line, "# pack-refs with:"))
+			for _, trait := range traits {
+				peeled = peeled || trait == "peeled"
+				fullyPeeled = fullyPeeled || trait == "fully-peeled"
+			}
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "^"):
+			if entry, ok := refs[last]; ok && isObjectID(line[1:]) {
+				entry.peeled = line[1:]
+				refs[last] = entry
+			}
+		default:
+			id, name, ok := strings.Cut(line, " ")
+			if !ok || !isObjectID(id) {
+				continue
+			}
+			refs[name] = packedRef{
+				target:      id,
+				fullyPeeled: fullyPeeled || (peeled && strings.HasPrefix(name, refPrefixTags)),
+			}
+			last = name
+		}
+	}
+	return refs, scanner.Err()
+}
+
+// isSafeRefName reports whether name is a ref path that stays inside the
+// repository ("HEAD" or "refs/..." without "..", backslashes or empty parts).
+func isSafeRefName(name string) bool {
+	if name != "HEAD" && !strings.HasPrefix(name, "refs/") {
+		return false
+	}
+	if strings.ContainsAny(name, "\\\x00") {
+		return false
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// isObjectID reports whether s is a full SHA-1 or SHA-256 object id in lower-case hex.
+func isObjectID(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	for _, c := range s {
+		if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f')) {
+			return false
+		}
+	}
+	return true
+}
+
+// readSmallFile reads a metadata file, refusing files larger than maxRefFileSize.
+func readSmallFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxRefFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxRefFileSize {
+		return nil, fmt.Errorf("%s: file too large", path)
+	}
+	return data, nil
+}
@@ -0,0 +1,530 @@
+package gitrepo
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixture builds a git repository on disk without a git binary
+type fixture struct {
+	t      *testing.T
+	root   string
+	gitDir string
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	root := t.TempDir()
+	f := &fixture{t: t, root: root, gitDir: filepath.Join(root, ".git")}
+	f.write("HEAD", "ref: refs/heads/main\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(f.gitDir, "objects"), 0o755))
+	return f
+}
+
+// write creates a file relative to the git directory
+func (f *fixture) write(name, content string) {
+	f.t.Helper()
+	path := filepath.Join(f.gitDir, filepath.FromSlash(name))
+	require.NoError(f.t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(f.t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// objectID returns the SHA-1 id of an object
+func objectID(typ string, data []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s %d\x00", typ, len(data))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func deflate(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, err := zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// loose writes a loose object and returns its id
+func (f *fixture) loose(typ string, data []byte) string {
+	f.t.Helper()
+	id := objectID(typ, data)
+	raw := append([]byte(fmt.Sprintf("%s %d\x00", typ, len(data))), data...)
+	path := filepath.Join(f.gitDir, "objects", id[:2], id[2:])
+	require.NoError(f.t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(f.t, os.WriteFile(path, deflate(f.t, raw), 0o444))
+	return id
+}
+
+func commitData(tree, parent, message string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "tree %s\n", tree)
+	if parent != "" {
+		fmt.Fprintf(&b, "parent %s\n", parent)
+	}
+	b.WriteString("author Jane Doe <jane@example.com> 1700000000 +0000\n")
+	b.WriteString("committer Jane Doe <jane@example.com> 1700000000 +0000\n\n")
+	b.WriteString(message + "\n")
+	return []byte(b.String())
+}
+
+func tagData(target, targetType, name string) []byte {
+	return []byte(fmt.Sprintf("object %s\ntype %s\ntag %s\ntagger Jane Doe <jane@example.com> 1700000000 +0000\n\nRelease %s\n",
+		target, targetType, name, name))
+}
+
+// packEntry is an object to write into a fixture pack
+type packEntry struct {
+	typ     byte
+	data    []byte // object content, or delta for delta types
+	baseIdx int    // OFS_DELTA: index of the base entry
+	baseID  string // REF_DELTA: id of the base object
+	id      string // resulting object id
+}
+
+// pack writes a version 2 pack and index containing entries
+func (f *fixture) pack(entries []packEntry) {
+	f.t.Helper()
+
+	var pack bytes.Buffer
+	pack.WriteString("PACK")
+	_ = binary.Write(&pack, binary.BigEndian, uint32(2))
+	_ = binary.Write(&pack, binary.BigEndian, uint32(len(entries)))
+
+	offsets := make([]int, len(entries))
+	for i, e := range entries {
+		offsets[i] = pack.Len()
+
+		size := len(e.data)
+		c := e.typ<<4 | byte(size&0x0f)
+		size >>= 4
+		for size > 0 {
+			pack.WriteByte(c | 0x80)
+			c = byte(size & 0x7f)
+			size >>= 7
+		}
+		pack.WriteByte(c)
+
+		switch e.typ {
+		case packOfsDelta:
+			rel := offsets[i] - offsets[e.baseIdx]
+			enc := []byte{byte(rel & 0x7f)}
+			for rel >>= 7; rel > 0; rel >>= 7 {
+				rel--
+				enc = append([]byte{0x80 | byte(rel&0x7f)}, enc...)
+			}
+			pack.Write(enc)
+		case packRefDelta:
+			raw, err := hex.DecodeString(e.baseID)
+			require.NoError(f.t, err)
+			pack.Write(raw)
+		}
+		pack.Write(deflate(f.t, e.data))
+	}
+	sum := sha1.Sum(pack.Bytes())
+	pack.Write(sum[:])
+
+	// Index entries are sorted by id
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return entries[order[a]].id < entries[order[b]].id })
+
+	var idx bytes.Buffer
+	idx.Write([]byte{0xff, 't', 'O', 'c'})
+	_ = binary.Write(&idx, binary.BigEndian, uint32(2))
+	var fanout [256]uint32
+	for _, e := range entries {
+		raw, _ := hex.DecodeString(e.id)
+		for b := int(raw[0]); b < 256; b++ {
+			fanout[b]++
+		}
+	}
+	_ = binary.Write(&idx, binary.BigEndian, fanout)
+	for _, i := range order {
+		raw, _ := hex.DecodeString(entries[i].id)
+		idx.Write(raw)
+	}
+	idx.Write(make([]byte, 4*len(entries))) // CRCs are not verified
+	for _, i := range order {
+		_ = binary.Write(&idx, binary.BigEndian, uint32(offsets[i]))
+	}
+	idx.Write(sum[:])
+	idx.Write(make([]byte, 20))
+
+	name := "objects/pack/pack-" + hex.EncodeToString(sum[:])
+	f.write(name+".pack", pack.String())
+	f.write(name+".idx", idx.String())
+}
+
+// makeDelta encodes target as a copy of the common prefix with base followed
+// by literal inserts
+func makeDelta(base, target []byte) []byte {
+	var d bytes.Buffer
+	var tmp [binary.MaxVarintLen64]byte
+	d.Write(tmp[:binary.PutUvarint(tmp[:], uint64(len(base)))])
+	d.Write(tmp[:binary.PutUvarint(tmp[:], uint64(len(target)))])
+
+	prefix := 0
+	for prefix < len(base) && prefix < len(target) && prefix < 255 && base[prefix] == target[prefix] {
+		prefix++
+	}
+	if prefix > 0 {
+		d.WriteByte(0x80 | 0x10) // copy from offset 0, one size byte
+		d.WriteByte(byte(prefix))
+	}
+	for rest := target[prefix:]; len(rest) > 0; {
+		n := min(len(rest), 127)
+		d.WriteByte(byte(n))
+		d.Write(rest[:n])
+		rest = rest[n:]
+	}
+	return d.Bytes()
+}
+
+const emptyTree = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+func TestRepo_LooseRefsAndObjects(t *testing.T) {
+	f := newFixture(t)
+	first := f.loose(ObjectCommit, commitData(emptyTree, "", "first"))
+	second := f.loose(ObjectCommit, commitData(emptyTree, first, "second"))
+	annotated := f.loose(ObjectTag, tagData(second, ObjectCommit, "v1.1.0"))
+
+	f.write("refs/heads/main", second+"\n")
+	f.write("refs/tags/v1.0.0", first+"\n")     // lightweight
+	f.write("refs/tags/v1.1.0", annotated+"\n") // annotated
+	f.write("refs/tags/release/stable", second+"\n")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(f.root, "nested", "dir"), 0o755))
+	repo, err := Discover(filepath.Join(f.root, "nested", "dir"))
+	require.NoError(t, err)
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, Head{Commit: second, Branch: "main"}, head)
+
+	tags, err := repo.TagsAt(second)
+	require.NoError(t, err)
+	assert.Equal(t, []Tag{
+		{Name: "release/stable", Target: second, Commit: second},
+		{Name: "v1.1.0", Target: annotated, Commit: second, Annotated: true},
+	}, tags)
+
+	tags, err = repo.TagsAt(first)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "v1.0.0", tags[0].Name)
+	assert.False(t, tags[0].Annotated)
+
+	obj, err := repo.ReadObject(second)
+	require.NoError(t, err)
+	assert.Equal(t, ObjectCommit, obj.Type)
+	assert.Contains(t, string(obj.Data), "parent "+first)
+}
+
+func TestRepo_PackedRefs(t *testing.T) {
+	f := newFixture(t)
+	commit := f.loose(ObjectCommit, commitData(emptyTree, "", "packed"))
+	annotated := f.loose(ObjectTag, tagData(commit, ObjectCommit, "v2.0.0"))
+	other := strings.Repeat("a", 40)
+
+	f.write("packed-refs", strings.Join([]string{
+		"# pack-refs with: peeled fully-peeled sorted ",
+		commit + " refs/heads/main",
+		commit + " refs/tags/v2.0.0-light",
+		annotated + " refs/tags/v2.0.0",
+		"^" + commit,
+		other + " refs/tags/v0.1.0",
+		"",
+	}, "\n"))
+
+	repo, err := Open(f.gitDir)
+	require.NoError(t, err)
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, commit, head.Commit)
+	assert.Equal(t, "main", head.Branch)
+
+	tags, err := repo.TagsAt(commit)
+	require.NoError(t, err)
+	assert.Equal(t, []Tag{
+		{Name: "v2.0.0", Target: annotated, Commit: commit, Annotated: true},
+		{Name: "v2.0.0-light", Target: commit, Commit: commit},
+	}, tags)
+
+	// A loose ref overrides its packed entry
+	newer := f.loose(ObjectCommit, commitData(emptyTree, commit, "newer"))
+	f.write("refs/heads/main", newer+"\n")
+	head, err = repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, newer, head.Commit)
+}
+
+func TestRepo_PackFiles(t *testing.T) {
+	f := newFixture(t)
+
+	base := commitData(emptyTree, "", "base")
+	baseID := objectID(ObjectCommit, base)
+	head := commitData(emptyTree, baseID, "head")
+	headID := objectID(ObjectCommit, head)
+
+	baseTag := tagData(baseID, ObjectCommit, "v3.0.0")
+	baseTagID := objectID(ObjectTag, baseTag)
+	headTag := tagData(headID, ObjectCommit, "v3.1.0")
+	headTagID := objectID(ObjectTag, headTag)
+
+	// A loose blob used as REF_DELTA base
+	blob := []byte("base blob content that is long enough to copy")
+	blobID := f.loose(ObjectBlob, blob)
+	patched := append(append([]byte{}, blob[:9]...), "patched"...)
+	patchedID := objectID(ObjectBlob, patched)
+
+	f.pack([]packEntry{
+		{typ: packCommit, data: base, id: baseID},
+		{typ: packOfsDelta, data: makeDelta(base, head), baseIdx: 0, id: headID},
+		{typ: packTag, data: baseTag, id: baseTagID},
+		{typ: packOfsDelta, data: makeDelta(baseTag, headTag), baseIdx: 2, id: headTagID},
+		{typ: packRefDelta, data: makeDelta(blob, patched), baseID: blobID, id: patchedID},
+	})
+	f.write("refs/heads/main", headID+"\n")
+	f.write("refs/tags/v3.0.0", baseTagID+"\n")
+	f.write("refs/tags/v3.1.0", headTagID+"\n")
+
+	repo, err := Open(f.gitDir)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		id       string
+		wantType string
+		wantData []byte
+	}{
+		"plain_commit":    {id: baseID, wantType: ObjectCommit, wantData: base},
+		"ofs_delta":       {id: headID, wantType: ObjectCommit, wantData: head},
+		"plain_tag":       {id: baseTagID, wantType: ObjectTag, wantData: baseTag},
+		"ofs_delta_tag":   {id: headTagID, wantType: ObjectTag, wantData: headTag},
+		"ref_delta_loose": {id: patchedID, wantType: ObjectBlob, wantData: patched},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj, err := repo.ReadObject(tt.id)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, obj.Type)
+			assert.Equal(t, tt.wantData, obj.Data)
+		})
+	}
+
+	tags, err := repo.TagsAt(headID)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "v3.1.0", tags[0].Name)
+	assert.True(t, tags[0].Annotated)
+
+	_, err = repo.ReadObject(strings.Repeat("0", 40))
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+
+	// Close releases the open pack; later reads reopen it
+	require.NoError(t, repo.Close())
+	obj, err := repo.ReadObject(headID)
+	require.NoError(t, err)
+	assert.Equal(t, head, obj.Data)
+	require.NoError(t, repo.Close())
+}
+
+func TestRepo_DetachedHeadAndWorktree(t *testing.T) {
+	f := newFixture(t)
+	commit := f.loose(ObjectCommit, commitData(emptyTree, "", "detached"))
+	f.write("refs/heads/main", commit+"\n")
+
+	// Linked worktree: .git file -> worktree gitdir with its own HEAD and a commondir
+	f.write("worktrees/wt/HEAD", commit+"\n")
+	f.write("worktrees/wt/commondir", "../..\n")
+	wt := filepath.Join(t.TempDir(), "wt")
+	require.NoError(t, os.MkdirAll(wt, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(wt, ".git"),
+		[]byte("gitdir: "+filepath.Join(f.gitDir, "worktrees", "wt")+"\n"), 0o644))
+
+	repo, err := Discover(wt)
+	require.NoError(t, err)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, Head{Commit: commit}, head, "detached HEAD has no branch")
+
+	resolved, err := repo.ResolveRef("refs/heads/main")
+	require.NoError(t, err)
+	assert.Equal(t, commit, resolved, "refs are read from the common dir")
+}
+
+func TestRepo_Errors(t *testing.T) {
+	t.Run("not_a_repository", func(t *testing.T) {
+		_, err := Open(t.TempDir())
+		assert.ErrorIs(t, err, ErrNotRepository)
+	})
+
+	t.Run("unborn_branch", func(t *testing.T) {
+		f := newFixture(t)
+		repo, err := Open(f.gitDir)
+		require.NoError(t, err)
+		_, err = repo.Head()
+		assert.ErrorIs(t, err, ErrRefNotFound)
+	})
+
+	t.Run("ref_traversal", func(t *testing.T) {
+		f := newFixture(t)
+		f.write("HEAD", "ref: refs/../../../etc/passwd\n")
+		repo, err := Open(f.gitDir)
+		require.NoError(t, err)
+		_, err = repo.Head()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid ref name")
+	})
+
+	t.Run("symref_loop", func(t *testing.T) {
+		f := newFixture(t)
+		f.write("refs/heads/main", "ref: refs/heads/loop\n")
+		f.write("refs/heads/loop", "ref: refs/heads/main\n")
+		repo, err := Open(f.gitDir)
+		require.NoError(t, err)
+		_, err = repo.Head()
+		assert.Error(t, err)
+	})
+
+	t.Run("corrupt_object", func(t *testing.T) {
+		f := newFixture(t)
+		id := strings.Repeat("b", 40)
+		f.write("objects/bb/"+id[2:], "not zlib")
+		repo, err := Open(f.gitDir)
+		require.NoError(t, err)
+		_, err = repo.ReadObject(id)
+		assert.Error(t, err)
+	})
+}
+
+func TestApplyDelta_Invalid(t *testing.T) {
+	tests := map[string][]byte{
+		"base_size_mismatch": {0x05, 0x01, 0x01, 'a'},
+		"copy_out_of_range":  {0x03, 0x05, 0x80 | 0x10, 0x05},
+		"zero_instruction":   {0x03, 0x01, 0x00},
+		"truncated_insert":   {0x03, 0x05, 0x05, 'a'},
+		"target_size":        {0x03, 0x05, 0x01, 'a'},
+	}
+
+	for name, delta := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := applyDelta([]byte("abc"), delta)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPackIndex_CorruptFanout(t *testing.T) {
+	id := bytes.Repeat([]byte{0x12}, 20)
+
+	// One entry in total, so fanout values above 1 are out of range
+	tests := map[string]func(fanout *[256]uint32){
+		"above_count": func(fanout *[256]uint32) {
+			fanout[0x12] = 5
+		},
+		"decreasing": func(fanout *[256]uint32) {
+			fanout[0x11] = 1
+			fanout[0x12] = 0
+		},
+	}
+
+	for name, corrupt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var fanout [256]uint32
+			fanout[255] = 1
+			corrupt(&fanout)
+
+			var data bytes.Buffer
+			data.Write([]byte{0xff, 't', 'O', 'c'})
+			_ = binary.Write(&data, binary.BigEndian, uint32(2))
+			_ = binary.Write(&data, binary.BigEndian, fanout)
+			data.Write(make([]byte, 20+4+4)) // one id, CRC and offset
+
+			idx := &packIndex{path: "pack-test.idx", data: data.Bytes()}
+			_, found, err := idx.find(id)
+			require.Error(t, err)
+			assert.False(t, found)
+			assert.Contains(t, err.Error(), "corrupt pack index")
+		})
+	}
+}
+
+// TestRepo_MatchesGitBinary cross-checks the reader against a real repository
+// after "git gc" has packed refs and objects. Skipped when git is unavailable.
+func TestRepo_MatchesGitBinary(t *testing.T) {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command(gitPath, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	git("init", "-q", "-b", "trunk")
+	for i := 0; i < 5; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "file.txt"),
+			[]byte(strings.Repeat(fmt.Sprintf("line %d\n", i), 200)), 0o644))
+		git("add", "file.txt")
+		git("commit", "-q", "-m", fmt.Sprintf("commit %d", i))
+		if i == 2 {
+			git("tag", "-a", "v0.3.0", "-m", "annotated")
+		}
+	}
+	git("tag", "v0.5.0")
+	git("tag", "-a", "v0.5.0-annotated", "-m", "annotated")
+	git("gc", "-q", "--aggressive")
+
+	repo, err := Discover(dir)
+	require.NoError(t, err)
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, git("rev-parse", "HEAD"), head.Commit)
+	assert.Equal(t, "trunk", head.Branch)
+
+	tags, err := repo.TagsAt(head.Commit)
+	require.NoError(t, err)
+	var names []string
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	assert.Equal(t, []string{"v0.5.0", "v0.5.0-annotated"}, names)
+
+	// Every packed object can be read back
+	for _, id := range strings.Fields(git("rev-list", "--all", "--objects", "--no-object-names")) {
+		obj, err := repo.ReadObject(id)
+		require.NoError(t, err, id)
+		assert.Equal(t, git("cat-file", "-t", id), obj.Type, id)
+	}
+}
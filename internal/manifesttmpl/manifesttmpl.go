@@ -0,0 +1,25 @@
+// Package manifesttmpl holds the annotated versions.yaml template written by
+// go-version init.
+//
+// It is internal to keep the template out of applications: an embedded
+// manifest in a binary is what Inspect reports, so only the CLI links it.
+package manifesttmpl
+
+import (
+	"bytes"
+	_ "embed"
+	"strconv"
+
+	"github.com/itsatony/go-version"
+)
+
+// template is the annotated manifest template with the project name placeholder
+//
+//go:embed versions.yaml.tmpl
+var template []byte
+
+// Render returns the template with the project name set to projectName. The
+// result documents every manifest section and is a valid manifest as is.
+func Render(projectName string) []byte {
+	return bytes.Replace(template, []byte(version.ManifestTemplatePlaceholder), []byte(strconv.Quote(projectName)), 1)
+}
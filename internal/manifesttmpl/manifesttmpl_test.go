@@ -0,0 +1,32 @@
+package manifesttmpl
+
+import (
+	"testing"
+
+	"github.com/itsatony/go-version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	tests := map[string]string{
+		"simple":       "billing-api",
+		"needs_escape": `odd "name"`,
+	}
+
+	for name, projectName := range tests {
+		t.Run(name, func(t *testing.T) {
+			info, err := version.New(version.WithEmbedded(Render(projectName)), version.WithoutGitInfo(), version.WithoutBuildInfo())
+			require.NoError(t, err)
+			assert.Equal(t, projectName, info.Project.Name)
+			assert.Equal(t, "0.1.0", info.Project.Version)
+		})
+	}
+
+	assert.Contains(t, string(Render("x")), "# Database schema versions", "comments are kept")
+}
+
+func TestTemplate_NotAManifest(t *testing.T) {
+	_, err := version.New(version.WithEmbedded(template), version.WithoutGitInfo(), version.WithoutBuildInfo())
+	assert.Error(t, err, "the unrendered template must not parse as a manifest")
+}
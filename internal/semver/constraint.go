@@ -0,0 +1,386 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// operator is a comparison operator used by a comparator
+type operator int
+
+const (
+	opEQ operator = iota
+	opNE
+	opGT
+	opGE
+	opLT
+	opLE
+
+	// opOutside matches versions outside [version, upper), the expansion of
+	// "!=" with a partial version ("!=1.2" is <1.2.0 || >=1.3.0)
+	opOutside
+)
+
+// String returns the textual form of the operator
+func (o operator) String() string {
+	switch o {
+	case opNE:
+		return "!="
+	case opGT:
+		return ">"
+	case opGE:
+		return ">="
+	case opLT:
+		return "<"
+	case opLE:
+		return "<="
+	default:
+		return "="
+	}
+}
+
+// comparator is a single "operator version" check such as ">=1.2.0"
+type comparator struct {
+	op      operator
+	version *Version
+	upper   *Version // exclusive upper bound of the range excluded by opOutside
+}
+
+// check reports whether v satisfies the comparator
+func (c comparator) check(v *Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case opOutside:
+		return cmp < 0 || v.Compare(c.upper) >= 0
+	case opNE:
+		return cmp != 0
+	case opGT:
+		return cmp > 0
+	case opGE:
+		return cmp >= 0
+	case opLT:
+		return cmp < 0
+	case opLE:
+		return cmp <= 0
+	default:
+		return cmp == 0
+	}
+}
+
+// String returns the comparator in "op version" form
+func (c comparator) String() string {
+	if c.op == opOutside {
+		return opLT.String() + c.version.String() + " || " + opGE.String() + c.upper.String()
+	}
+	return c.op.String() + c.version.String()
+}
+
+// clause is a set of comparators that must all be satisfied (logical AND)
+type clause struct {
+	raw         string
+	comparators []comparator
+}
+
+// Constraint is a version range expression.
+//
+// Supported syntax:
+//   - Comparison: "=1.2.3", ">1.2", ">=1.2.3", "<2", "<=1.4", "!=1.3.0"
+//   - Caret: "^1.2.3" (>=1.2.3 <2.0.0), "^0.2" (>=0.2.0 <0.3.0)
+//   - Tilde: "~1.4.0" or "~>1.4.0" (>=1.4.0 <1.5.0), "~1" (>=1.0.0 <2.0.0)
+//   - Wildcards: "1.x", "1.2.*", "*" and bare partial versions ("45" means 45.x)
+//   - AND: comparators separated by whitespace or commas (">=2.0 <3.0")
+//   - OR: clauses separated by "||" ("^1.15 || ^2.0")
+//
+// Prerelease versions are compared by normal SemVer precedence.
+type Constraint struct {
+	clauses []clause
+}
+
+// ParseConstraint parses a constraint expression.
+func ParseConstraint(s string) (*Constraint, error) {
+	raw := strings.TrimSpace(s)
+	if raw == "" {
+		return nil, fmt.Errorf("empty constraint")
+	}
+
+	c := &Constraint{}
+	for _, part := range strings.Split(raw, "||") {
+		cl, err := parseClause(part)
+		if err != nil {
+			return nil, err
+		}
+		c.clauses = append(c.clauses, cl)
+	}
+
+	return c, nil
+}
+
+// parseClause parses a whitespace or comma separated list of comparators.
+func parseClause(s string) (clause, error) {
+	raw := strings.Join(strings.Fields(strings.ReplaceAll(s, ",", " ")), " ")
+	if raw == "" {
+		return clause{}, fmt.Errorf("empty clause in constraint")
+	}
+
+	cl := clause{raw: raw}
+	tokens := strings.Fields(raw)
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		// Allow a space between operator and version (">= 2.0")
+		if isOperatorOnly(token) {
+			if i+1 >= len(tokens) {
+				return clause{}, fmt.Errorf("operator %q without version", token)
+			}
+			i++
+			token += tokens[i]
+		}
+
+		comparators, err := parseComparator(token)
+		if err != nil {
+			return clause{}, err
+		}
+		cl.comparators = append(cl.comparators, comparators...)
+	}
+
+	return cl, nil
+}
+
+// isOperatorOnly reports whether token consists only of operator characters
+func isOperatorOnly(token string) bool {
+	return strings.Trim(token, "<>=!~^") == ""
+}
+
+// parseComparator expands a single token into one or more comparators.
+func parseComparator(token string) ([]comparator, error) {
+	switch {
+	case strings.HasPrefix(token, "^"):
+		return expandCaret(token[1:])
+	case strings.HasPrefix(token, "~>"):
+		return expandTilde(token[2:])
+	case strings.HasPrefix(token, "~"):
+		return expandTilde(token[1:])
+	case strings.HasPrefix(token, ">="):
+		return expandPartial(opGE, token[2:])
+	case strings.HasPrefix(token, "<="):
+		return expandPartial(opLE, token[2:])
+	case strings.HasPrefix(token, "!="):
+		return expandPartial(opNE, token[2:])
+	case strings.HasPrefix(token, "=="):
+		return expandPartial(opEQ, token[2:])
+	case strings.HasPrefix(token, ">"):
+		return expandPartial(opGT, token[1:])
+	case strings.HasPrefix(token, "<"):
+		return expandPartial(opLT, token[1:])
+	case strings.HasPrefix(token, "="):
+		return expandPartial(opEQ, token[1:])
+	default:
+		return expandPartial(opEQ, token)
+	}
+}
+
+// expandPartial expands a comparison against a possibly partial version.
+// Missing components act as wildcards: "=1.2" matches 1.2.x, ">1.2" means >=1.3.0
+// and "!=1.2" excludes 1.2.x.
+func expandPartial(op operator, s string) ([]comparator, error) {
+	v, parts, err := parsePartial(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if parts == 3 {
+		return []comparator{{op: op, version: v}}, nil
+	}
+
+	switch op {
+	case opEQ:
+		if parts == 0 {
+			return nil, nil
+		}
+		return []comparator{{op: opGE, version: v}, {op: opLT, version: bump(v, parts)}}, nil
+	case opGT:
+		if parts == 0 {
+			return nil, fmt.Errorf("constraint %q can never be satisfied", ">"+s)
+		}
+		return []comparator{{op: opGE, version: bump(v, parts)}}, nil
+	case opLE:
+		if parts == 0 {
+			return nil, nil
+		}
+		return []comparator{{op: opLT, version: bump(v, parts)}}, nil
+	case opGE:
+		if parts == 0 {
+			return nil, nil
+		}
+		return []comparator{{op: opGE, version: v}}, nil
+	case opLT:
+		if parts == 0 {
+			return nil, fmt.Errorf("constraint %q can never be satisfied", "<"+s)
+		}
+		return []comparator{{op: opLT, version: v}}, nil
+	default:
+		if parts == 0 {
+			return nil, fmt.Errorf("constraint %q can never be satisfied", "!="+s)
+		}
+		return []comparator{{op: opOutside, version: v, upper: bump(v, parts)}}, nil
+	}
+}
+
+// expandCaret expands "^v": changes that do not modify the left-most non-zero component.
+func expandCaret(s string) ([]comparator, error) {
+	v, parts, err := parsePartial(s)
+	if err != nil {
+		return nil, err
+	}
+	if parts == 0 {
+		return nil, nil
+	}
+
+	var upper *Version
+	switch {
+	case v.Major > 0 || parts == 1:
+		upper = &Version{Major: v.Major + 1}
+	case v.Minor > 0 || parts == 2:
+		upper = &Version{Minor: v.Minor + 1}
+	default:
+		upper = &Version{Patch: v.Patch + 1}
+	}
+
+	return []comparator{{op: opGE, version: v}, {op: opLT, version: upper}}, nil
+}
+
+// expandTilde expands "~v": patch-level changes if minor is given, minor-level otherwise.
+func expandTilde(s string) ([]comparator, error) {
+	v, parts, err := parsePartial(s)
+	if err != nil {
+		return nil, err
+	}
+	if parts == 0 {
+		return nil, nil
+	}
+
+	upper := &Version{Major: v.Major, Minor: v.Minor + 1}
+	if parts == 1 {
+		upper = &Version{Major: v.Major + 1}
+	}
+
+	return []comparator{{op: opGE, version: v}, {op: opLT, version: upper}}, nil
+}
+
+// bump returns the smallest version above the range described by the first parts components.
+func bump(v *Version, parts int) *Version {
+	if parts == 1 {
+		return &Version{Major: v.Major + 1}
+	}
+	return &Version{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// parsePartial parses a version that may omit components or use wildcards ("1", "1.x", "*").
+// It returns the version with missing components set to zero and the number of
+// components that were given explicitly.
+func parsePartial(s string) (*Version, int, error) {
+	s = strings.TrimPrefix(s, "v")
+	if s == "" {
+		return nil, 0, fmt.Errorf("missing version in constraint")
+	}
+
+	versionPart, buildPart, hasBuild := strings.Cut(s, "+")
+	if hasBuild {
+		if err := validateIdentifiers(buildPart, false); err != nil {
+			return nil, 0, fmt.Errorf("invalid build metadata in constraint %q: %w", s, err)
+		}
+	}
+
+	corePart, prereleasePart, hasPrerelease := strings.Cut(versionPart, "-")
+	if hasPrerelease {
+		if err := validateIdentifiers(prereleasePart, true); err != nil {
+			return nil, 0, fmt.Errorf("invalid prerelease in constraint %q: %w", s, err)
+		}
+	}
+
+	coreParts := strings.Split(corePart, ".")
+	if len(coreParts) > 3 {
+		return nil, 0, fmt.Errorf("invalid version in constraint: %s", s)
+	}
+
+	nums := [3]int{}
+	parts := 0
+	for i, p := range coreParts {
+		if isWildcard(p) {
+			// Everything after a wildcard must also be a wildcard
+			for _, rest := range coreParts[i:] {
+				if !isWildcard(rest) {
+					return nil, 0, fmt.Errorf("invalid wildcard in constraint: %s", s)
+				}
+			}
+			break
+		}
+		n, err := parseNumeric(p)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid version in constraint %q: %w", s, err)
+		}
+		nums[i] = n
+		parts++
+	}
+
+	if hasPrerelease && parts != 3 {
+		return nil, 0, fmt.Errorf("prerelease requires a full version in constraint: %s", s)
+	}
+
+	return &Version{Major: nums[0], Minor: nums[1], Patch: nums[2], Prerelease: prereleasePart}, parts, nil
+}
+
+// isWildcard reports whether a version component is a wildcard
+func isWildcard(s string) bool {
+	return s == "x" || s == "X" || s == "*"
+}
+
+// Check reports whether v satisfies at least one clause of the constraint.
+func (c *Constraint) Check(v *Version) bool {
+	for _, cl := range c.clauses {
+		if cl.check(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// check reports whether v satisfies every comparator of the clause
+func (cl clause) check(v *Version) bool {
+	for _, cmp := range cl.comparators {
+		if !cmp.check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Explain returns one message per clause describing the first comparator v fails.
+// Returns nil if v satisfies the constraint.
+//
+// Example: for "^1.15" and 1.14.0 the result is ["^1.15 requires >=1.15.0"].
+func (c *Constraint) Explain(v *Version) []string {
+	if c.Check(v) {
+		return nil
+	}
+
+	reasons := make([]string, 0, len(c.clauses))
+	for _, cl := range c.clauses {
+		for _, cmp := range cl.comparators {
+			if !cmp.check(v) {
+				reasons = append(reasons, cl.raw+" requires "+cmp.String())
+				break
+			}
+		}
+	}
+	return reasons
+}
+
+// String returns the normalized constraint expression
+func (c *Constraint) String() string {
+	raws := make([]string, len(c.clauses))
+	for i, cl := range c.clauses {
+		raws[i] = cl.raw
+	}
+	return strings.Join(raws, " || ")
+}
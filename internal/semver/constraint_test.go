@@ -0,0 +1,181 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraint_Check(t *testing.T) {
+	tests := map[string]struct {
+		constraint string
+		matches    []string
+		rejects    []string
+	}{
+		"exact": {
+			constraint: "=1.2.3",
+			matches:    []string{"1.2.3", "1.2.3+build"},
+			rejects:    []string{"1.2.4", "1.2.3-rc.1"},
+		},
+		"bare_partial_is_wildcard": {
+			constraint: "45",
+			matches:    []string{"45", "45.9.1"},
+			rejects:    []string{"44.9.9", "46"},
+		},
+		"caret_major": {
+			constraint: "^1.2",
+			matches:    []string{"1.2.0", "1.9.9"},
+			rejects:    []string{"1.1.9", "2.0.0"},
+		},
+		"caret_zero_minor": {
+			constraint: "^0.2.3",
+			matches:    []string{"0.2.3", "0.2.9"},
+			rejects:    []string{"0.3.0", "0.2.2"},
+		},
+		"caret_zero_zero": {
+			constraint: "^0.0.3",
+			matches:    []string{"0.0.3"},
+			rejects:    []string{"0.0.4"},
+		},
+		"caret_zero_partial": {
+			constraint: "^0.0",
+			matches:    []string{"0.0.9"},
+			rejects:    []string{"0.1.0"},
+		},
+		"tilde": {
+			constraint: "~1.4.0",
+			matches:    []string{"1.4.0", "1.4.12"},
+			rejects:    []string{"1.5.0", "1.3.9"},
+		},
+		"tilde_major_only": {
+			constraint: "~1",
+			matches:    []string{"1.0.0", "1.9.0"},
+			rejects:    []string{"2.0.0"},
+		},
+		"pessimistic_operator": {
+			constraint: "~>1.4",
+			matches:    []string{"1.4.5"},
+			rejects:    []string{"1.5.0"},
+		},
+		"range_and": {
+			constraint: ">=2.0 <3.0",
+			matches:    []string{"2.0.0", "2.99.0"},
+			rejects:    []string{"1.9.9", "3.0.0"},
+		},
+		"range_with_comma_and_spaces": {
+			constraint: ">= 45, < 60",
+			matches:    []string{"45", "59.9.9"},
+			rejects:    []string{"44", "60"},
+		},
+		"or": {
+			constraint: "^1.15 || ^2.0",
+			matches:    []string{"1.15.0", "2.3.0"},
+			rejects:    []string{"1.14.9", "3.0.0"},
+		},
+		"greater_than_partial": {
+			constraint: ">1.2",
+			matches:    []string{"1.3.0"},
+			rejects:    []string{"1.2.9"},
+		},
+		"less_equal_partial": {
+			constraint: "<=1.2",
+			matches:    []string{"1.2.9"},
+			rejects:    []string{"1.3.0"},
+		},
+		"not_equal": {
+			constraint: "!=1.3.0",
+			matches:    []string{"1.2.0", "1.3.1"},
+			rejects:    []string{"1.3.0"},
+		},
+		"not_equal_partial": {
+			constraint: "!=1.2",
+			matches:    []string{"1.1.9", "1.3.0"},
+			rejects:    []string{"1.2.0", "1.2.5"},
+		},
+		"not_equal_major": {
+			constraint: "!=1",
+			matches:    []string{"0.9.0", "2.0.0"},
+			rejects:    []string{"1.0.0", "1.9.9"},
+		},
+		"wildcard_x": {
+			constraint: "1.x",
+			matches:    []string{"1.0.0", "1.99.0"},
+			rejects:    []string{"2.0.0"},
+		},
+		"wildcard_star": {
+			constraint: "*",
+			matches:    []string{"0.0.1", "99.0.0"},
+		},
+		"prerelease_precedence": {
+			constraint: ">=1.0.0-alpha.2",
+			matches:    []string{"1.0.0-alpha.10", "1.0.0"},
+			rejects:    []string{"1.0.0-alpha.1"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			require.NoError(t, err)
+
+			for _, s := range tt.matches {
+				v, err := Parse(s)
+				require.NoError(t, err)
+				assert.True(t, c.Check(v), "%s should satisfy %s", s, tt.constraint)
+				assert.Nil(t, c.Explain(v))
+			}
+			for _, s := range tt.rejects {
+				v, err := Parse(s)
+				require.NoError(t, err)
+				assert.False(t, c.Check(v), "%s should not satisfy %s", s, tt.constraint)
+				assert.NotEmpty(t, c.Explain(v))
+			}
+		})
+	}
+}
+
+func TestParseConstraint_Invalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"   ",
+		"^",
+		">=",
+		"1.2.3.4",
+		">=1.2 ||",
+		"1.x.3",
+		"1.2-beta",
+		">=01.2",
+		"<*",
+		">*",
+		"1.2 - 2.3",
+		"abc",
+	}
+
+	for _, input := range invalid {
+		t.Run(input, func(t *testing.T) {
+			c, err := ParseConstraint(input)
+			assert.Error(t, err)
+			assert.Nil(t, c)
+		})
+	}
+}
+
+func TestConstraint_Explain(t *testing.T) {
+	c, err := ParseConstraint("^1.15 || >=2.1 <3")
+	require.NoError(t, err)
+
+	v, err := Parse("2.0.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"^1.15 requires <2.0.0",
+		">=2.1 <3 requires >=2.1.0",
+	}, c.Explain(v))
+}
+
+func TestConstraint_String(t *testing.T) {
+	c, err := ParseConstraint("  >=2.0,   <3.0 ||^4 ")
+	require.NoError(t, err)
+	assert.Equal(t, ">=2.0 <3.0 || ^4", c.String())
+}
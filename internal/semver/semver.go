@@ -15,36 +15,57 @@ type Version struct {
 	Build      string
 }
 
-// Parse parses a semantic version string.
+// Parse parses a semantic version string in lenient mode.
 // Supports formats: "1.2.3", "v1.2.3", "1.2.3-alpha", "1.2.3+build"
+//
+// Lenient mode additionally accepts a leading "v" and missing minor or patch
+// components ("1", "v1.2"), which are common for schema and API versions.
+// All other SemVer 2.0.0 rules still apply: numeric components must not have
+// leading zeros and prerelease/build identifiers must be non-empty and only
+// contain [0-9A-Za-z-].
 func Parse(s string) (*Version, error) {
+	return parse(s, false)
+}
+
+// ParseStrict parses a version string that must conform exactly to SemVer 2.0.0.
+// No "v" prefix is accepted and major, minor and patch are all required.
+func ParseStrict(s string) (*Version, error) {
+	return parse(s, true)
+}
+
+// parse implements both parsing modes.
+func parse(s string, strict bool) (*Version, error) {
+	input := s
+
 	// Remove leading 'v' if present
-	s = strings.TrimPrefix(s, "v")
+	if !strict {
+		s = strings.TrimPrefix(s, "v")
+	}
 
 	if s == "" {
 		return nil, fmt.Errorf("empty version string")
 	}
 
 	// Split on '+' for build metadata
-	parts := strings.SplitN(s, "+", 2)
-	versionPart := parts[0]
-	buildPart := ""
-	if len(parts) > 1 {
-		buildPart = parts[1]
+	versionPart, buildPart, hasBuild := strings.Cut(s, "+")
+	if hasBuild {
+		if err := validateIdentifiers(buildPart, false); err != nil {
+			return nil, fmt.Errorf("invalid build metadata in %q: %w", input, err)
+		}
 	}
 
-	// Split on '-' for prerelease
-	parts = strings.SplitN(versionPart, "-", 2)
-	corePart := parts[0]
-	prereleasePart := ""
-	if len(parts) > 1 {
-		prereleasePart = parts[1]
+	// Split on the first '-' for prerelease (hyphens are valid inside identifiers)
+	corePart, prereleasePart, hasPrerelease := strings.Cut(versionPart, "-")
+	if hasPrerelease {
+		if err := validateIdentifiers(prereleasePart, true); err != nil {
+			return nil, fmt.Errorf("invalid prerelease in %q: %w", input, err)
+		}
 	}
 
 	// Parse core version (major.minor.patch)
 	coreParts := strings.Split(corePart, ".")
-	if len(coreParts) < 1 || len(coreParts) > 3 {
-		return nil, fmt.Errorf("invalid version format: %s", s)
+	if len(coreParts) > 3 || (strict && len(coreParts) != 3) {
+		return nil, fmt.Errorf("invalid version format: %s", input)
 	}
 
 	v := &Version{
@@ -55,14 +76,14 @@ func Parse(s string) (*Version, error) {
 	var err error
 
 	// Parse major
-	v.Major, err = strconv.Atoi(coreParts[0])
+	v.Major, err = parseNumeric(coreParts[0])
 	if err != nil {
 		return nil, fmt.Errorf("invalid major version: %s", coreParts[0])
 	}
 
 	// Parse minor (default 0)
 	if len(coreParts) > 1 {
-		v.Minor, err = strconv.Atoi(coreParts[1])
+		v.Minor, err = parseNumeric(coreParts[1])
 		if err != nil {
 			return nil, fmt.Errorf("invalid minor version: %s", coreParts[1])
 		}
@@ -70,7 +91,7 @@ func Parse(s string) (*Version, error) {
 
 	// Parse patch (default 0)
 	if len(coreParts) > 2 {
-		v.Patch, err = strconv.Atoi(coreParts[2])
+		v.Patch, err = parseNumeric(coreParts[2])
 		if err != nil {
 			return nil, fmt.Errorf("invalid patch version: %s", coreParts[2])
 		}
@@ -79,6 +100,55 @@ func Parse(s string) (*Version, error) {
 	return v, nil
 }
 
+// parseNumeric parses a core version component.
+// The component must consist of digits only and must not have leading zeros.
+func parseNumeric(s string) (int, error) {
+	if !isNumeric(s) {
+		return 0, fmt.Errorf("not a number: %q", s)
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("leading zero in %q", s)
+	}
+	return strconv.Atoi(s)
+}
+
+// validateIdentifiers checks a dot-separated list of prerelease or build identifiers.
+// Numeric prerelease identifiers must not have leading zeros; build identifiers may.
+func validateIdentifiers(s string, prerelease bool) error {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return fmt.Errorf("empty identifier")
+		}
+		for i := 0; i < len(id); i++ {
+			if !isIdentifierChar(id[i]) {
+				return fmt.Errorf("invalid character %q in identifier %q", id[i], id)
+			}
+		}
+		if prerelease && isNumeric(id) && len(id) > 1 && id[0] == '0' {
+			return fmt.Errorf("leading zero in numeric identifier %q", id)
+		}
+	}
+	return nil
+}
+
+// isIdentifierChar reports whether c is allowed in a prerelease or build identifier.
+func isIdentifierChar(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '-'
+}
+
+// isNumeric reports whether s is a non-empty string of ASCII digits.
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // String returns the string representation of the version
 func (v *Version) String() string {
 	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
@@ -96,7 +166,15 @@ func (v *Version) String() string {
 
 // Compare compares two versions.
 // Returns -1 if v < other, 0 if v == other, 1 if v > other.
-// Prerelease versions have lower precedence than normal versions.
+//
+// Precedence follows SemVer 2.0.0 section 11:
+//   - Major, minor and patch are compared numerically
+//   - Prerelease versions have lower precedence than normal versions
+//   - Prerelease identifiers are compared one by one: numeric identifiers
+//     numerically, alphanumeric identifiers lexically in ASCII order, and
+//     numeric identifiers always sort before alphanumeric ones
+//   - A larger set of prerelease identifiers wins if all preceding ones are equal
+//   - Build metadata is ignored
 func (v *Version) Compare(other *Version) int {
 	if v.Major != other.Major {
 		if v.Major < other.Major {
@@ -119,24 +197,67 @@ func (v *Version) Compare(other *Version) int {
 		return 1
 	}
 
-	// Handle prerelease comparison
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// comparePrerelease compares two prerelease strings by SemVer precedence.
+func comparePrerelease(a, b string) int {
+	if a == b {
+		return 0
+	}
+
 	// No prerelease > prerelease
-	if v.Prerelease == "" && other.Prerelease != "" {
+	if a == "" {
 		return 1
 	}
-	if v.Prerelease != "" && other.Prerelease == "" {
+	if b == "" {
 		return -1
 	}
 
-	// Both have prerelease or both don't
-	if v.Prerelease != other.Prerelease {
-		if v.Prerelease < other.Prerelease {
-			return -1
+	aIDs := strings.Split(a, ".")
+	bIDs := strings.Split(b, ".")
+
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		if c := compareIdentifier(aIDs[i], bIDs[i]); c != 0 {
+			return c
 		}
+	}
+
+	switch {
+	case len(aIDs) < len(bIDs):
+		return -1
+	case len(aIDs) > len(bIDs):
 		return 1
+	default:
+		return 0
 	}
+}
 
-	return 0
+// compareIdentifier compares a single pair of prerelease identifiers.
+func compareIdentifier(a, b string) int {
+	aNum := isNumeric(a)
+	bNum := isNumeric(b)
+
+	switch {
+	case aNum && bNum:
+		// Compare by length first so arbitrarily large numbers never overflow.
+		// Leading zeros are rejected by the parser, so length orders magnitude.
+		a = strings.TrimLeft(a, "0")
+		b = strings.TrimLeft(b, "0")
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
 }
 
 // LessThan returns true if v < other
@@ -263,3 +263,269 @@ func TestVersion_ParseAndString_RoundTrip(t *testing.T) {
 		})
 	}
 }
+
+// TestConformance_Precedence checks the precedence example from semver.org section 11:
+// 1.0.0-alpha < 1.0.0-alpha.1 < 1.0.0-alpha.beta < 1.0.0-beta < 1.0.0-beta.2 <
+// 1.0.0-beta.11 < 1.0.0-rc.1 < 1.0.0
+func TestConformance_Precedence(t *testing.T) {
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i := 0; i < len(ordered); i++ {
+		for j := 0; j < len(ordered); j++ {
+			a, err := ParseStrict(ordered[i])
+			require.NoError(t, err)
+			b, err := ParseStrict(ordered[j])
+			require.NoError(t, err)
+
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			assert.Equal(t, expected, a.Compare(b), "%s vs %s", ordered[i], ordered[j])
+		}
+	}
+}
+
+func TestConformance_PrereleaseIdentifiers(t *testing.T) {
+	tests := map[string]struct {
+		v1       string
+		v2       string
+		expected int
+	}{
+		"numeric_not_lexical": {
+			v1:       "1.0.0-alpha.10",
+			v2:       "1.0.0-alpha.2",
+			expected: 1,
+		},
+		"rc_after_beta_11": {
+			v1:       "1.0.0-rc.1",
+			v2:       "1.0.0-beta.11",
+			expected: 1,
+		},
+		"numeric_before_alphanumeric": {
+			v1:       "1.0.0-1",
+			v2:       "1.0.0-alpha",
+			expected: -1,
+		},
+		"more_fields_win": {
+			v1:       "1.0.0-alpha.1.1",
+			v2:       "1.0.0-alpha.1",
+			expected: 1,
+		},
+		"ascii_order": {
+			v1:       "1.0.0-Beta",
+			v2:       "1.0.0-alpha",
+			expected: -1,
+		},
+		"hyphen_in_identifier": {
+			v1:       "1.0.0-x-y",
+			v2:       "1.0.0-x",
+			expected: 1,
+		},
+		"huge_numeric_identifiers": {
+			v1:       "1.0.0-99999999999999999999999",
+			v2:       "1.0.0-100000000000000000000000",
+			expected: -1,
+		},
+		"build_metadata_ignored": {
+			v1:       "1.0.0+20130313144700",
+			v2:       "1.0.0+exp.sha.5114f85",
+			expected: 0,
+		},
+		"build_metadata_ignored_with_prerelease": {
+			v1:       "1.0.0-beta+exp.sha.5114f85",
+			v2:       "1.0.0-beta",
+			expected: 0,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v1, err := Parse(tt.v1)
+			require.NoError(t, err)
+			v2, err := Parse(tt.v2)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, v1.Compare(v2))
+			assert.Equal(t, -tt.expected, v2.Compare(v1))
+		})
+	}
+}
+
+// TestConformance_Valid checks valid version strings from the semver.org regex examples.
+func TestConformance_Valid(t *testing.T) {
+	valid := []string{
+		"0.0.4",
+		"1.2.3",
+		"10.20.30",
+		"1.1.2-prerelease+meta",
+		"1.1.2+meta",
+		"1.1.2+meta-valid",
+		"1.0.0-alpha",
+		"1.0.0-beta",
+		"1.0.0-alpha.beta",
+		"1.0.0-alpha.beta.1",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha0.valid",
+		"1.0.0-alpha.0valid",
+		"1.0.0-alpha-a.b-c-somethinglong+build.1-aef.1-its-okay",
+		"1.0.0-rc.1+build.1",
+		"2.0.0-rc.1+build.123",
+		"1.2.3-beta",
+		"10.2.3-DEV-SNAPSHOT",
+		"1.2.3-SNAPSHOT-123",
+		"1.0.0",
+		"2.0.0",
+		"1.1.7",
+		"2.0.0+build.1848",
+		"2.0.1-alpha.1227",
+		"1.0.0-alpha+beta",
+		"1.2.3----RC-SNAPSHOT.12.9.1--.12+788",
+		"1.2.3----R-S.12.9.1--.12+meta",
+		"1.2.3----RC-SNAPSHOT.12.9.1--.12",
+		"1.0.0+0.build.1-rc.10000aaa-kk-0.1",
+		"1.0.0-0A.is.legal",
+	}
+
+	for _, input := range valid {
+		t.Run(input, func(t *testing.T) {
+			v, err := ParseStrict(input)
+			require.NoError(t, err)
+			assert.Equal(t, input, v.String())
+
+			_, err = Parse(input)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// TestConformance_Invalid checks invalid version strings from the semver.org regex examples.
+func TestConformance_Invalid(t *testing.T) {
+	invalid := []string{
+		"1.2.3-0123",
+		"1.2.3-0123.0123",
+		"1.1.2+.123",
+		"+invalid",
+		"-invalid",
+		"-invalid+invalid",
+		"-invalid.01",
+		"alpha",
+		"alpha.beta",
+		"alpha.beta.1",
+		"alpha.1",
+		"alpha+beta",
+		"alpha_beta",
+		"alpha.",
+		"alpha..",
+		"beta",
+		"1.0.0-alpha_beta",
+		"-alpha.",
+		"1.0.0-alpha..",
+		"1.0.0-alpha..1",
+		"1.0.0-alpha...1",
+		"1.0.0-alpha....1",
+		"1.0.0-alpha.....1",
+		"1.0.0-alpha......1",
+		"1.0.0-alpha.......1",
+		"01.1.1",
+		"1.01.1",
+		"1.1.01",
+		"1.2.3.DEV",
+		"1.2-SNAPSHOT",
+		"1.2.31.2.3----RC-SNAPSHOT.12.09.1--..12+788",
+		"1.2-RC-SNAPSHOT",
+		"-1.0.3-gamma+b7718",
+		"+justmeta",
+		"9.8.7+meta+meta",
+		"9.8.7-whatever+meta+meta",
+		"+1.2.3",
+		"1.+2.3",
+	}
+
+	for _, input := range invalid {
+		t.Run(input, func(t *testing.T) {
+			v, err := ParseStrict(input)
+			assert.Error(t, err)
+			assert.Nil(t, v)
+		})
+	}
+}
+
+func TestParse_LenientVersusStrict(t *testing.T) {
+	tests := map[string]struct {
+		input         string
+		lenientValid  bool
+		strictValid   bool
+		lenientString string
+	}{
+		"v_prefix": {
+			input:         "v1.2.3",
+			lenientValid:  true,
+			lenientString: "1.2.3",
+		},
+		"major_only": {
+			input:         "45",
+			lenientValid:  true,
+			lenientString: "45.0.0",
+		},
+		"major_minor_prerelease": {
+			input:         "v1.2-rc.1",
+			lenientValid:  true,
+			lenientString: "1.2.0-rc.1",
+		},
+		"full": {
+			input:         "1.2.3-rc.1",
+			lenientValid:  true,
+			strictValid:   true,
+			lenientString: "1.2.3-rc.1",
+		},
+		"leading_zero_rejected_in_both": {
+			input: "01.2.3",
+		},
+		"garbage_prerelease_rejected_in_both": {
+			input: "1.2.3-beta!",
+		},
+		"empty_prerelease_rejected_in_both": {
+			input: "1.2.3-",
+		},
+		"signed_component_rejected_in_both": {
+			input: "1.-2.3",
+		},
+		"only_prefix": {
+			input: "v",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			lenient, err := Parse(tt.input)
+			if tt.lenientValid {
+				require.NoError(t, err)
+				assert.Equal(t, tt.lenientString, lenient.String())
+			} else {
+				assert.Error(t, err)
+			}
+
+			_, err = ParseStrict(tt.input)
+			if tt.strictValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package version
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itsatony/go-version/internal/gitrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLdflags_String(t *testing.T) {
+	tests := map[string]struct {
+		flags *Ldflags
+		want  string
+	}{
+		"empty": {flags: &Ldflags{}, want: ""},
+		"fixed_order_skips_empty": {
+			flags: &Ldflags{BuildUser: "ci", GitCommit: "abc1234", BuildTime: "2025-01-01T00:00:00Z"},
+			want: "-X " + PackageImportPath + ".GitCommit=abc1234" +
+				" -X " + PackageImportPath + ".BuildTime=2025-01-01T00:00:00Z" +
+				" -X " + PackageImportPath + ".BuildUser=ci",
+		},
+		"quotes_whitespace": {
+			flags: &Ldflags{BuildUser: "Jane Doe"},
+			want:  "-X '" + PackageImportPath + ".BuildUser=Jane Doe'",
+		},
+		"double_quotes_if_single_present": {
+			flags: &Ldflags{BuildUser: "Jane O'Doe"},
+			want:  `-X "` + PackageImportPath + `.BuildUser=Jane O'Doe"`,
+		},
+		"quote_without_whitespace": {
+			flags: &Ldflags{BuildUser: "o'doe"},
+			want:  "-X " + PackageImportPath + ".BuildUser=o'doe",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.flags.String())
+		})
+	}
+}
+
+func TestLdflagsBuildTime(t *testing.T) {
+	now := time.Date(2025, 6, 1, 14, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	tests := map[string]struct {
+		epoch   string
+		want    string
+		wantErr bool
+	}{
+		"now_in_utc":   {epoch: "", want: "2025-06-01T12:30:00Z"},
+		"source_date":  {epoch: "1700000000", want: "2023-11-14T22:13:20Z"},
+		"trims_space":  {epoch: " 0\n", want: "1970-01-01T00:00:00Z"},
+		"not_a_number": {epoch: "yesterday", wantErr: true},
+		"fractional":   {epoch: "1700000000.5", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ldflagsBuildTime(tt.epoch, now)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), ErrMsgSourceDateEpoch)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadLdflags(t *testing.T) {
+	t.Setenv(EnvSourceDateEpoch, "1700000000")
+
+	flags, err := ReadLdflags(GitSourceDirectory)
+	require.NoError(t, err)
+	assert.Equal(t, "2023-11-14T22:13:20Z", flags.BuildTime)
+	assert.Empty(t, flags.GitTreeState, "the directory reader cannot determine the tree state")
+
+	repo, err := gitrepo.Discover(".")
+	if err != nil {
+		assert.Empty(t, flags.GitCommit)
+		return
+	}
+	head, err := repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, head.Commit, flags.GitCommit)
+	assert.Equal(t, head.Branch, flags.GitBranch)
+}
+
+func TestReadLdflags_InvalidSourceDateEpoch(t *testing.T) {
+	t.Setenv(EnvSourceDateEpoch, "soon")
+
+	_, err := ReadLdflags(GitSourceDirectory)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrMsgSourceDateEpoch)
+}
+
+func TestLdflags_RoundTrip(t *testing.T) {
+	flags := &Ldflags{
+		GitCommit:    "0123456789abcdef0123456789abcdef01234567",
+		GitTreeState: GitTreeStateDirty,
+		GitDescribe:  "v3.2.1-4-g0123456",
+		BuildTime:    "2023-11-14T22:13:20Z",
+		BuildUser:    "Jane Doe",
+	}
+
+	// Later -X flags override the defaults of buildInspectee
+	info, err := Inspect(buildInspectee(t, flags.String()))
+	require.NoError(t, err)
+
+	assert.Equal(t, flags.GitCommit, info.Git.Commit)
+	assert.Equal(t, flags.GitTreeState, info.Git.TreeState)
+	assert.Equal(t, flags.GitDescribe, info.Git.Describe)
+	assert.Equal(t, flags.BuildTime, info.Build.Time)
+	assert.Equal(t, flags.BuildUser, info.Build.User)
+}
@@ -0,0 +1,215 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifestJSON = `{
+  "manifest_version": "1.0",
+  "project": {"name": "json-app", "version": "1.2.3"},
+  "schemas": {"postgres_main": "45"},
+  "apis": {"rest_v1": "1.15.0"},
+  "custom": {"region": "eu-1"}
+}`
+
+const testManifestTOML = `# TOML manifest
+manifest_version = "1.0"
+
+[project]
+name = "toml-app"
+version = "2.3.4"
+
+[schemas]
+postgres_main = "46"
+
+[components]
+aigentchat = "3.4.1"
+`
+
+func TestDetectManifestFormat(t *testing.T) {
+	tests := map[string]struct {
+		format   ManifestFormat
+		path     string
+		data     string
+		expected ManifestFormat
+	}{
+		"explicit_wins":                   {format: ManifestFormatTOML, path: "versions.json", expected: ManifestFormatTOML},
+		"yaml_extension":                  {path: "versions.yaml", expected: ManifestFormatYAML},
+		"yml_extension":                   {path: "config/versions.YML", expected: ManifestFormatYAML},
+		"json_extension":                  {path: "versions.json", expected: ManifestFormatJSON},
+		"toml_extension":                  {path: "versions.toml", expected: ManifestFormatTOML},
+		"sniff_json":                      {path: "versions.conf", data: testManifestJSON, expected: ManifestFormatJSON},
+		"sniff_toml":                      {data: testManifestTOML, expected: ManifestFormatTOML},
+		"sniff_toml_table":                {data: "[project]\nname = \"x\"", expected: ManifestFormatTOML},
+		"sniff_yaml":                      {data: "# comment\n---\nproject:\n  name: x", expected: ManifestFormatYAML},
+		"sniff_yaml_with_equals_in_value": {data: "custom: a=b", expected: ManifestFormatYAML},
+		"sniff_empty":                     {expected: ManifestFormatYAML},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, detectManifestFormat(tc.format, tc.path, []byte(tc.data)))
+		})
+	}
+}
+
+func TestParseManifest_JSON(t *testing.T) {
+	manifest, err := parseManifest([]byte(testManifestJSON))
+	require.NoError(t, err)
+
+	assert.Equal(t, "json-app", manifest.Project.Name)
+	assert.Equal(t, "1.2.3", manifest.Project.Version)
+	assert.Equal(t, "45", manifest.Schemas["postgres_main"])
+	assert.Equal(t, "1.15.0", manifest.APIs["rest_v1"])
+	assert.Equal(t, "eu-1", manifest.Custom["region"])
+}
+
+func TestParseManifest_TOML(t *testing.T) {
+	manifest, err := parseManifest([]byte(testManifestTOML))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0", manifest.ManifestVersion)
+	assert.Equal(t, "toml-app", manifest.Project.Name)
+	assert.Equal(t, "2.3.4", manifest.Project.Version)
+	assert.Equal(t, "46", manifest.Schemas["postgres_main"])
+	assert.Equal(t, "3.4.1", manifest.Components["aigentchat"])
+}
+
+func TestParseManifest_ErrorPositions(t *testing.T) {
+	tests := map[string]struct {
+		data     string
+		format   ManifestFormat
+		contains []string
+	}{
+		"json_syntax": {
+			data:     "{\n  \"project\": {\n    \"name\": \"x\",,\n  }\n}",
+			format:   ManifestFormatJSON,
+			contains: []string{"[manifest]", ErrMsgParseJSON, "line 3, column"},
+		},
+		"json_type": {
+			data:     "{\n  \"schemas\": {\"db\": 45}\n}",
+			format:   ManifestFormatJSON,
+			contains: []string{"[manifest]", ErrMsgParseJSON, "line 2, column"},
+		},
+		"toml_syntax": {
+			data:     "[project]\nname = \"x\"\nversion = \n",
+			format:   ManifestFormatTOML,
+			contains: []string{"[manifest]", ErrMsgParseTOML, "line 3, column"},
+		},
+		"yaml_syntax": {
+			data:     "project:\n  name: \"x\n",
+			format:   ManifestFormatYAML,
+			contains: []string{"[manifest]", ErrMsgParseYAML, "line"},
+		},
+		"unsupported_format": {
+			data:     "{}",
+			format:   ManifestFormat("xml"),
+			contains: []string{"[manifest]", "unsupported manifest format 'xml'"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseManifestFormat([]byte(tc.data), tc.format)
+			require.Error(t, err)
+			for _, s := range tc.contains {
+				assert.Contains(t, err.Error(), s)
+			}
+		})
+	}
+}
+
+func TestLoadVersionInfo_JSONAndTOMLFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	jsonPath := filepath.Join(tmpDir, "versions.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(testManifestJSON), 0o600))
+
+	tomlPath := filepath.Join(tmpDir, "versions.toml")
+	require.NoError(t, os.WriteFile(tomlPath, []byte(testManifestTOML), 0o600))
+
+	info, err := loadVersionInfo(WithManifestPath(jsonPath), WithoutGitInfo())
+	require.NoError(t, err)
+	assert.Equal(t, "json-app", info.Project.Name)
+
+	info, err = loadVersionInfo(WithManifestPath(tomlPath), WithoutGitInfo())
+	require.NoError(t, err)
+	assert.Equal(t, "toml-app", info.Project.Name)
+}
+
+func TestLoadVersionInfo_EmbeddedWithManifestFormat(t *testing.T) {
+	info, err := loadVersionInfo(
+		WithEmbedded([]byte(testManifestTOML)),
+		WithManifestFormat(ManifestFormatTOML),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "toml-app", info.Project.Name)
+
+	// Forcing the wrong format surfaces a parse error
+	_, err = loadVersionInfo(
+		WithEmbedded([]byte(testManifestTOML)),
+		WithManifestFormat(ManifestFormatJSON),
+		WithoutGitInfo(),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrMsgParseJSON)
+}
+
+func TestLoadVersionInfo_DefaultLookupOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	// No manifest at all: defaults
+	info, err := loadVersionInfo(WithoutGitInfo())
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProjectName, info.Project.Name)
+
+	// versions.json is found when no YAML manifest exists
+	require.NoError(t, os.WriteFile(ManifestFilenameJSON, []byte(testManifestJSON), 0o600))
+	info, err = loadVersionInfo(WithoutGitInfo())
+	require.NoError(t, err)
+	assert.Equal(t, "json-app", info.Project.Name)
+
+	// versions.yml takes precedence over versions.json
+	require.NoError(t, os.WriteFile(ManifestFilenameYML, []byte("project:\n  name: yml-app\n  version: 1.0.0\n"), 0o600))
+	info, err = loadVersionInfo(WithoutGitInfo())
+	require.NoError(t, err)
+	assert.Equal(t, "yml-app", info.Project.Name)
+
+	// versions.yaml takes precedence over everything else
+	require.NoError(t, os.WriteFile(ManifestFilenameYAML, []byte("project:\n  name: yaml-app\n  version: 1.0.0\n"), 0o600))
+	info, err = loadVersionInfo(WithoutGitInfo())
+	require.NoError(t, err)
+	assert.Equal(t, "yaml-app", info.Project.Name)
+}
+
+func TestLoadVersionInfo_ManifestFormatOnlyForExplicitSources(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	// The default lookup ignores an explicit format
+	require.NoError(t, os.WriteFile(ManifestFilenameYAML, []byte("project:\n  name: yaml-app\n  version: 1.0.0\n"), 0o600))
+	info, err := loadVersionInfo(WithManifestFormat(ManifestFormatJSON), WithoutGitInfo())
+	require.NoError(t, err)
+	assert.Equal(t, "yaml-app", info.Project.Name)
+
+	// Environment overlay files are detected by extension or content
+	confPath := filepath.Join(tmpDir, "versions.conf")
+	require.NoError(t, os.WriteFile(confPath, []byte(testManifestTOML), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "versions.prod.conf"), []byte("project:\n  version: 9.9.9\n"), 0o600))
+	info, err = loadVersionInfo(
+		WithManifestPath(confPath),
+		WithManifestFormat(ManifestFormatTOML),
+		WithEnvironment("prod"),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "toml-app", info.Project.Name)
+	assert.Equal(t, "9.9.9", info.Project.Version)
+}
@@ -0,0 +1,83 @@
+package version
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfo_WriteMetrics(t *testing.T) {
+	info := &Info{
+		Project: ProjectVersion{Name: "metrics-app", Version: "1.2.3"},
+		Git:     GitInfo{Commit: "0123456789abcdef0123456789abcdef01234567", Tag: "v1.2.3", TreeState: GitTreeStateClean},
+		Build:   BuildInfo{GoVersion: "go1.24.6"},
+		schemas: map[string]string{"redis": "3", "postgres_main": "45"},
+		apis:    map[string]string{"rest_v1": "v1.15"},
+	}
+
+	want := `# HELP build_info Build information of the running binary.
+# TYPE build_info gauge
+build_info{name="metrics-app",version="1.2.3",commit="0123456789abcdef0123456789abcdef01234567",tag="v1.2.3",tree_state="clean",go_version="go1.24.6"} 1
+# HELP schema_version_info Schema versions declared in the version manifest.
+# TYPE schema_version_info gauge
+schema_version_info{name="postgres_main",version="45"} 1
+schema_version_info{name="redis",version="3"} 1
+# HELP api_version_info API versions declared in the version manifest.
+# TYPE api_version_info gauge
+api_version_info{name="rest_v1",version="v1.15"} 1
+`
+
+	var buf bytes.Buffer
+	require.NoError(t, info.WriteMetrics(&buf))
+	assert.Equal(t, want, buf.String())
+}
+
+func TestInfo_WriteMetrics_EscapesLabelValues(t *testing.T) {
+	info := &Info{
+		Project:    ProjectVersion{Name: `say "hi"`, Version: `C:\dist`},
+		components: map[string]string{"multi": "line\nvalue"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, info.WriteMetrics(&buf))
+	assert.Contains(t, buf.String(), `build_info{name="say \"hi\"",version="C:\\dist",commit="",tag="",tree_state="",go_version=""} 1`)
+	assert.Contains(t, buf.String(), `component_version_info{name="multi",version="line\nvalue"} 1`)
+	assert.NotContains(t, buf.String(), MetricSchemaVersionInfo)
+}
+
+func TestMetricsHandler(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	require.NoError(t, Initialize(WithEmbedded([]byte(testManifestForOverrides)), WithoutGitInfo()))
+
+	w := httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTPPathMetrics, http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, HTTPContentTypePrometheus, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `build_info{name="override-app",version="1.2.3",`)
+	assert.Contains(t, w.Body.String(), `schema_version_info{name="postgres_main",version="45"} 1`)
+	assert.Contains(t, w.Body.String(), `api_version_info{name="rest_v1",version="1.15.0"} 1`)
+	assert.Contains(t, w.Body.String(), `component_version_info{name="Cache",version="1.0.0"} 1`)
+}
+
+func TestMetricsHandler_MethodNotAllowed(t *testing.T) {
+	w := httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, HTTPPathMetrics, http.NoBody))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestRegistry_MetricsHandler(t *testing.T) {
+	reg := newTestRegistry(t, "billing", "1.0.0")
+
+	w := httptest.NewRecorder()
+	reg.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTPPathMetrics, http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `build_info{name="billing",version="1.0.0",`)
+}
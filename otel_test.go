@@ -0,0 +1,90 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfo_ResourceAttributes(t *testing.T) {
+	info := &Info{
+		Project:     ProjectVersion{Name: "otel-app", Version: "1.2.3"},
+		environment: "staging",
+		Git: GitInfo{
+			Commit:    "0123456789abcdef0123456789abcdef01234567",
+			Tag:       "v1.2.3",
+			Branch:    "main",
+			RemoteURL: "https://github.com/acme/otel-app.git",
+		},
+		Build:      BuildInfo{GoVersion: "go1.24.6"},
+		schemas:    map[string]string{"redis": "3", "postgres_main": "45"},
+		apis:       map[string]string{"rest_v1": "v1.15"},
+		components: map[string]string{"cache": "1.0.0"},
+	}
+
+	expected := ResourceAttributes{
+		{Key: "service.name", Value: "otel-app"},
+		{Key: "service.version", Value: "1.2.3"},
+		{Key: "deployment.environment.name", Value: "staging"},
+		{Key: "vcs.repository.ref.revision", Value: "0123456789abcdef0123456789abcdef01234567"},
+		{Key: "vcs.repository.ref.name", Value: "v1.2.3"},
+		{Key: "vcs.repository.ref.type", Value: "tag"},
+		{Key: "vcs.repository.url.full", Value: "https://github.com/acme/otel-app.git"},
+		{Key: "process.runtime.name", Value: "go"},
+		{Key: "process.runtime.version", Value: "go1.24.6"},
+		{Key: "app.schema.postgres_main", Value: "45"},
+		{Key: "app.schema.redis", Value: "3"},
+		{Key: "app.api.rest_v1", Value: "v1.15"},
+		{Key: "app.component.cache", Value: "1.0.0"},
+	}
+	assert.Equal(t, expected, info.ResourceAttributes(""))
+
+	custom := info.ResourceAttributes("acme.")
+	require.Len(t, custom, len(expected))
+	assert.Equal(t, "acme.schema.postgres_main", custom[9].Key)
+}
+
+func TestInfo_ResourceAttributes_Minimal(t *testing.T) {
+	tests := map[string]struct {
+		git  GitInfo
+		want ResourceAttributes
+	}{
+		"placeholder_commit": {
+			git:  GitInfo{Commit: DefaultGitCommit, TreeState: DefaultGitTreeState},
+			want: ResourceAttributes{{Key: OTelAttrServiceName, Value: "app"}, {Key: OTelAttrServiceVersion, Value: "0.1.0"}},
+		},
+		"branch_without_tag": {
+			git: GitInfo{Branch: "feature/x"},
+			want: ResourceAttributes{
+				{Key: OTelAttrServiceName, Value: "app"}, {Key: OTelAttrServiceVersion, Value: "0.1.0"},
+				{Key: OTelAttrVCSRefName, Value: "feature/x"}, {Key: OTelAttrVCSRefType, Value: OTelRefTypeBranch},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			info := &Info{Project: ProjectVersion{Name: "app", Version: "0.1.0"}, Git: tt.git}
+			assert.Equal(t, tt.want, info.ResourceAttributes(""))
+		})
+	}
+}
+
+func TestResourceAttributes_String(t *testing.T) {
+	tests := map[string]struct {
+		attrs ResourceAttributes
+		want  string
+	}{
+		"empty":  {attrs: nil, want: ""},
+		"plain":  {attrs: ResourceAttributes{{Key: "service.name", Value: "api"}, {Key: "service.version", Value: "1.0.0+build.1"}}, want: "service.name=api,service.version=1.0.0+build.1"},
+		"escape": {attrs: ResourceAttributes{{Key: "app.custom", Value: `a b,c;d="e"\100%é`}}, want: `app.custom=a%20b%2Cc%3Bd%3D%22e%22%5C100%25%C3%A9`},
+		"key":    {attrs: ResourceAttributes{{Key: "app.schema.a=b", Value: "1"}}, want: "app.schema.a%3Db=1"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.attrs.String())
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifestForOverrides = `
+manifest_version: "1.0"
+project:
+  name: "override-app"
+  version: "1.2.3"
+schemas:
+  postgres_main: "45"
+apis:
+  rest_v1: "1.15.0"
+components:
+  Cache: "1.0.0"
+  billing-internal: "2.0.0"
+  billing_v2: "3.0.0"
+custom:
+  region: "us-east-1"
+  cost-center: "ops"
+`
+
+func TestApplyEnvOverrides(t *testing.T) {
+	tests := map[string]struct {
+		prefix    string
+		environ   []string
+		check     func(t *testing.T, m *Manifest)
+		overrides map[string]string
+	}{
+		"project_fields": {
+			prefix:  "GOVERSION",
+			environ: []string{"GOVERSION_PROJECT_NAME=renamed", "GOVERSION_PROJECT_VERSION=2.0.0"},
+			check: func(t *testing.T, m *Manifest) {
+				assert.Equal(t, "renamed", m.Project.Name)
+				assert.Equal(t, "2.0.0", m.Project.Version)
+			},
+			overrides: map[string]string{
+				"project.name":    "env:GOVERSION_PROJECT_NAME",
+				"project.version": "env:GOVERSION_PROJECT_VERSION",
+			},
+		},
+		"case_insensitive_existing_keys": {
+			prefix:  "GOVERSION",
+			environ: []string{"GOVERSION_SCHEMAS_POSTGRES_MAIN=46", "GOVERSION_COMPONENTS_CACHE=1.1.0"},
+			check: func(t *testing.T, m *Manifest) {
+				assert.Equal(t, "46", m.Schemas["postgres_main"])
+				assert.Equal(t, "1.1.0", m.Components["Cache"], "existing key spelling is kept")
+				assert.NotContains(t, m.Components, "cache")
+			},
+			overrides: map[string]string{
+				"schemas.postgres_main": "env:GOVERSION_SCHEMAS_POSTGRES_MAIN",
+				"components.Cache":      "env:GOVERSION_COMPONENTS_CACHE",
+			},
+		},
+		"underscore_matches_hyphen": {
+			prefix: "GOVERSION",
+			environ: []string{
+				"GOVERSION_COMPONENTS_BILLING_INTERNAL=2.1.0",
+				"GOVERSION_COMPONENTS_BILLING_V2=3.1.0",
+				"GOVERSION_CUSTOM_COST_CENTER=finance",
+			},
+			check: func(t *testing.T, m *Manifest) {
+				assert.Equal(t, "2.1.0", m.Components["billing-internal"])
+				assert.Equal(t, "3.1.0", m.Components["billing_v2"])
+				assert.NotContains(t, m.Components, "billing_internal")
+				assert.Equal(t, "finance", m.Custom["cost-center"])
+			},
+			overrides: map[string]string{
+				"components.billing-internal": "env:GOVERSION_COMPONENTS_BILLING_INTERNAL",
+				"components.billing_v2":       "env:GOVERSION_COMPONENTS_BILLING_V2",
+				"custom.cost-center":          "env:GOVERSION_CUSTOM_COST_CENTER",
+			},
+		},
+		"new_keys_are_lowercased": {
+			prefix:  "GOVERSION",
+			environ: []string{"GOVERSION_APIS_GRPC=1.0.0", "GOVERSION_CUSTOM_DEPLOY_ID=abc123"},
+			check: func(t *testing.T, m *Manifest) {
+				assert.Equal(t, "1.0.0", m.APIs["grpc"])
+				assert.Equal(t, "1.15.0", m.APIs["rest_v1"])
+				assert.Equal(t, "abc123", m.Custom["deploy_id"])
+			},
+			overrides: map[string]string{
+				"apis.grpc":        "env:GOVERSION_APIS_GRPC",
+				"custom.deploy_id": "env:GOVERSION_CUSTOM_DEPLOY_ID",
+			},
+		},
+		"custom_prefix_with_trailing_underscore": {
+			prefix:  "myapp_",
+			environ: []string{"MYAPP_PROJECT_VERSION=3.0.0", "GOVERSION_PROJECT_VERSION=9.9.9"},
+			check: func(t *testing.T, m *Manifest) {
+				assert.Equal(t, "3.0.0", m.Project.Version)
+			},
+			overrides: map[string]string{"project.version": "env:MYAPP_PROJECT_VERSION"},
+		},
+		"ignored_variables": {
+			prefix: "GOVERSION",
+			environ: []string{
+				"GOVERSION_PROJECT_VERSION=",  // empty value
+				"GOVERSION_APIS_=1.0.0",       // missing key
+				"GOVERSION_UNKNOWN_FIELD=x",   // unknown section
+				"OTHER_PROJECT_VERSION=5.0.0", // other prefix
+				"GOVERSION_ENV=staging",       // environment selector
+				"NOT_A_KEY_VALUE_PAIR",
+			},
+			check: func(t *testing.T, m *Manifest) {
+				assert.Equal(t, "1.2.3", m.Project.Version)
+				assert.Len(t, m.APIs, 1)
+			},
+			overrides: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			manifest, err := decodeManifest([]byte(testManifestForOverrides), ManifestFormatYAML)
+			require.NoError(t, err)
+
+			applyEnvOverrides(manifest, tt.prefix, tt.environ)
+
+			tt.check(t, manifest)
+			assert.Equal(t, tt.overrides, manifest.overrides)
+		})
+	}
+}
+
+func TestLoadVersionInfo_WithEnvOverrides(t *testing.T) {
+	t.Setenv("GOVERSION_PROJECT_VERSION", "1.2.4-hotfix")
+	t.Setenv("GOVERSION_APIS_REST_V1", "1.16.0")
+
+	info, err := loadVersionInfo(
+		WithEmbedded([]byte(testManifestForOverrides)),
+		WithEnvOverrides(DefaultEnvOverridePrefix),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.4-hotfix", info.Project.Version)
+	assert.Equal(t, "1.16.0", info.GetAPIs()["rest_v1"])
+	assert.Equal(t, map[string]string{
+		"project.version": "env:GOVERSION_PROJECT_VERSION",
+		"apis.rest_v1":    "env:GOVERSION_APIS_REST_V1",
+	}, info.GetOverrides())
+
+	// Overrides are opt-in
+	info, err = loadVersionInfo(WithEmbedded([]byte(testManifestForOverrides)), WithoutGitInfo())
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", info.Project.Version)
+	assert.Nil(t, info.GetOverrides())
+}
+
+func TestLoadVersionInfo_EnvOverridesAfterEnvironment(t *testing.T) {
+	t.Setenv("GOVERSION_PROJECT_VERSION", "4.0.0")
+
+	info, err := loadVersionInfo(
+		WithEmbedded([]byte(testManifestWithEnvironments)),
+		WithEnvironment("staging"),
+		WithEnvOverrides("GOVERSION"),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "4.0.0", info.Project.Version, "environment variables win over overlays")
+	assert.Equal(t, "1.16.0", info.GetAPIs()["rest_v1"], "overlay still applies")
+}
+
+func TestLoadVersionInfo_EnvOverridesOnDefaultManifest(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GOVERSION_PROJECT_NAME", "from-env")
+
+	info, err := loadVersionInfo(WithEnvOverrides("GOVERSION"), WithoutGitInfo())
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", info.Project.Name)
+}
+
+func TestLoadVersionInfo_EnvOverridesAreValidated(t *testing.T) {
+	t.Setenv("GOVERSION_APIS_REST_V1", "1.14.0")
+
+	_, err := loadVersionInfo(
+		WithEmbedded([]byte(testManifestForOverrides)),
+		WithEnvOverrides("GOVERSION"),
+		WithValidators(NewAPIValidator("rest_v1", "1.15.0")),
+		WithoutGitInfo(),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1.14.0")
+
+	t.Setenv("GOVERSION_APIS_REST_V1", "1.20.0")
+	info, err := loadVersionInfo(
+		WithEmbedded([]byte(testManifestForOverrides)),
+		WithEnvOverrides("GOVERSION"),
+		WithValidators(ValidatorFunc(func(ctx context.Context, info *Info) error {
+			v, _ := info.GetAPIVersion("rest_v1")
+			assert.Equal(t, "1.20.0", v)
+			return nil
+		})),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+	assert.NotNil(t, info)
+}
+
+func TestInfo_OverridesJSONRoundTrip(t *testing.T) {
+	t.Setenv("GOVERSION_CUSTOM_REGION", "eu-west-1")
+
+	info, err := loadVersionInfo(
+		WithEmbedded([]byte(testManifestForOverrides)),
+		WithEnvOverrides("GOVERSION"),
+		WithoutGitInfo(),
+	)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(info)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"overrides":{"custom.region":"env:GOVERSION_CUSTOM_REGION"}`)
+
+	var decoded Info
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, info.GetOverrides(), decoded.GetOverrides())
+}
@@ -0,0 +1,152 @@
+package version
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry(t *testing.T, name, projectVersion string) *Registry {
+	t.Helper()
+	manifest := []byte("project:\n  name: \"" + name + "\"\n  version: \"" + projectVersion + "\"\n")
+	reg, err := NewRegistry(WithEmbedded(manifest), WithoutGitInfo(), WithoutBuildInfo())
+	require.NoError(t, err)
+	return reg
+}
+
+func TestNewRegistry(t *testing.T) {
+	tests := map[string]struct {
+		opts    []Option
+		wantErr string
+	}{
+		"valid_manifest": {
+			opts: []Option{WithEmbedded([]byte(testManifestForOverrides)), WithoutGitInfo()},
+		},
+		"invalid_manifest": {
+			opts:    []Option{WithEmbedded([]byte("project: [unclosed"))},
+			wantErr: ErrMsgLoadManifest,
+		},
+		"validator_failure": {
+			opts: []Option{
+				WithEmbedded([]byte(testManifestForOverrides)),
+				WithValidators(NewAPIValidator("rest_v1", "2.0.0")),
+				WithoutGitInfo(),
+			},
+			wantErr: ErrMsgValidationFailedWrap,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			reg, err := NewRegistry(tt.opts...)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, reg)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, reg.IsInitialized())
+			assert.Equal(t, "override-app", reg.MustGet().Project.Name)
+		})
+	}
+}
+
+func TestRegistry_ZeroValueAutoInitializes(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	var reg Registry
+	assert.False(t, reg.IsInitialized())
+
+	info, err := reg.Get()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProjectName, info.Project.Name)
+	assert.True(t, reg.IsInitialized())
+	assert.Same(t, info, reg.MustGet())
+}
+
+func TestRegistry_IndependentOfSingleton(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	require.NoError(t, Initialize(WithEmbedded([]byte(testManifestForOverrides)), WithoutGitInfo()))
+	reg := newTestRegistry(t, "plugin", "9.0.0")
+
+	assert.Equal(t, "override-app", MustGet().Project.Name)
+	assert.Equal(t, "plugin", reg.MustGet().Project.Name)
+}
+
+func TestRegistry_Handlers(t *testing.T) {
+	billing := newTestRegistry(t, "billing", "1.0.0")
+	search := newTestRegistry(t, "search", "2.0.0")
+
+	mux := http.NewServeMux()
+	mux.Handle("/billing/version", billing.Handler())
+	mux.Handle("/search/version", search.HandlerFunc())
+	mux.Handle("/billing/health", billing.HealthHandler())
+	mux.Handle("/search/health", search.HealthHandlerFunc())
+	mux.Handle("/search/api", search.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})))
+
+	tests := map[string]struct {
+		path        string
+		wantVersion string
+		wantName    string
+	}{
+		"billing_version": {path: "/billing/version", wantName: "billing", wantVersion: "1.0.0"},
+		"search_version":  {path: "/search/version", wantName: "search", wantVersion: "2.0.0"},
+		"billing_health":  {path: "/billing/health", wantVersion: "1.0.0"},
+		"search_health":   {path: "/search/health", wantVersion: "2.0.0"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			if tt.wantName != "" {
+				project, ok := body["project"].(map[string]interface{})
+				require.True(t, ok)
+				assert.Equal(t, tt.wantName, project["name"])
+				assert.Equal(t, tt.wantVersion, project["version"])
+			} else {
+				assert.Equal(t, HTTPStatusOK, body["status"])
+				assert.Equal(t, tt.wantVersion, body["version"])
+			}
+		})
+	}
+
+	t.Run("middleware", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search/api", http.NoBody))
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "2.0.0", w.Header().Get(HTTPHeaderAppVersion))
+	})
+}
+
+func TestRegistry_HandlerServesReloadedInfo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "versions.yaml")
+	writeReloadManifest(t, path, "1.0.0")
+	reg, err := NewRegistry(WithManifestPath(path), WithoutGitInfo(), WithoutBuildInfo())
+	require.NoError(t, err)
+
+	handler := reg.Handler()
+	writeReloadManifest(t, path, "1.1.0")
+	require.NoError(t, reg.Reload())
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/version", http.NoBody))
+
+	var info Info
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	assert.Equal(t, "1.1.0", info.Project.Version)
+}
@@ -0,0 +1,206 @@
+package version
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reloadEvent records a single ChangeFunc invocation
+type reloadEvent struct {
+	old, new *Info
+	err      error
+}
+
+// recordReloads subscribes to reloads and returns a function reporting the events so far
+func recordReloads(t *testing.T) func() []reloadEvent {
+	t.Helper()
+	var mu sync.Mutex
+	var events []reloadEvent
+	unsubscribe := Subscribe(func(old, new *Info, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, reloadEvent{old: old, new: new, err: err})
+	})
+	t.Cleanup(unsubscribe)
+	return func() []reloadEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]reloadEvent(nil), events...)
+	}
+}
+
+func writeReloadManifest(t *testing.T, path, projectVersion string) {
+	t.Helper()
+	content := "project:\n  name: \"reload-app\"\n  version: \"" + projectVersion + "\"\napis:\n  rest_v1: \"1.15.0\"\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestReload_Manual(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	path := filepath.Join(t.TempDir(), "versions.yaml")
+	writeReloadManifest(t, path, "1.0.0")
+	require.NoError(t, Initialize(WithManifestPath(path), WithoutGitInfo(), WithoutBuildInfo()))
+	events := recordReloads(t)
+
+	writeReloadManifest(t, path, "1.1.0")
+	require.NoError(t, Reload())
+
+	assert.Equal(t, "1.1.0", MustGet().Project.Version)
+	require.Len(t, events(), 1)
+	assert.Equal(t, "1.0.0", events()[0].old.Project.Version)
+	assert.Equal(t, "1.1.0", events()[0].new.Project.Version)
+	assert.NoError(t, events()[0].err)
+}
+
+func TestReload_FailureKeepsPreviousInfo(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		opts    []Option
+		errMsg  string
+	}{
+		"parse_error": {
+			content: "project: [unclosed",
+			errMsg:  ErrMsgLoadManifest,
+		},
+		"validator_error": {
+			content: "project:\n  name: \"reload-app\"\n  version: \"1.1.0\"\napis:\n  rest_v1: \"1.0.0\"\n",
+			opts:    []Option{WithValidators(NewAPIValidator("rest_v1", "1.15.0"))},
+			errMsg:  ErrMsgValidationFailedWrap,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			path := filepath.Join(t.TempDir(), "versions.yaml")
+			writeReloadManifest(t, path, "1.0.0")
+			opts := append([]Option{WithManifestPath(path), WithoutGitInfo(), WithoutBuildInfo()}, tt.opts...)
+			require.NoError(t, Initialize(opts...))
+			before := MustGet()
+			events := recordReloads(t)
+
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			err := Reload()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+
+			assert.Same(t, before, MustGet(), "previous Info must be kept")
+			require.Len(t, events(), 1)
+			assert.Same(t, before, events()[0].old)
+			assert.Nil(t, events()[0].new)
+			assert.Equal(t, err, events()[0].err)
+		})
+	}
+}
+
+func TestReload_NotInitialized(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	assert.ErrorIs(t, Reload(), ErrNotInitialized)
+}
+
+func TestWithReload_WatcherPicksUpChanges(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	path := filepath.Join(t.TempDir(), "versions.yaml")
+	writeReloadManifest(t, path, "1.0.0")
+	require.NoError(t, Initialize(
+		WithManifestPath(path),
+		WithReload(10*time.Millisecond),
+		WithoutGitInfo(),
+		WithoutBuildInfo(),
+	))
+	events := recordReloads(t)
+
+	writeReloadManifest(t, path, "2.0.0")
+	assert.Eventually(t, func() bool {
+		return MustGet().Project.Version == "2.0.0"
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// A broken manifest is reported once and the last good Info is kept
+	require.NoError(t, os.WriteFile(path, []byte("project: [unclosed"), 0o600))
+	assert.Eventually(t, func() bool {
+		e := events()
+		return len(e) > 0 && e[len(e)-1].err != nil
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "2.0.0", MustGet().Project.Version)
+
+	// Unchanged content does not trigger further reloads
+	count := len(events())
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, events(), count)
+}
+
+func TestWithReload_StopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	path := filepath.Join(t.TempDir(), "versions.yaml")
+	writeReloadManifest(t, path, "1.0.0")
+	reg, err := NewRegistry(
+		WithManifestPath(path),
+		WithReload(10*time.Millisecond),
+		WithContext(ctx),
+		WithoutGitInfo(),
+		WithoutBuildInfo(),
+	)
+	require.NoError(t, err)
+	defer reg.StopReload()
+
+	cancel()
+	reg.watcherMu.Lock()
+	w := reg.watcher
+	reg.watcherMu.Unlock()
+	require.NotNil(t, w)
+	select {
+	case <-w.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("watcher did not stop after context cancellation")
+	}
+
+	writeReloadManifest(t, path, "2.0.0")
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, "1.0.0", reg.MustGet().Project.Version)
+}
+
+func TestSubscribe_Unsubscribe(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	require.NoError(t, Initialize(WithEmbedded([]byte(testManifestForOverrides)), WithoutGitInfo()))
+
+	calls := 0
+	unsubscribe := Subscribe(func(old, new *Info, err error) { calls++ })
+	require.NoError(t, Reload())
+	unsubscribe()
+	unsubscribe() // idempotent
+	require.NoError(t, Reload())
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestManifestFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "versions.yaml")
+	options := newLoadOptions(WithManifestPath(path), WithEnvironment("prod"))
+
+	missing := manifestFingerprint(options)
+	writeReloadManifest(t, path, "1.0.0")
+	created := manifestFingerprint(options)
+	assert.NotEqual(t, missing, created)
+	assert.Equal(t, created, manifestFingerprint(options), "fingerprint is stable")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "versions.prod.yaml"), []byte("project:\n  version: \"9.0.0\"\n"), 0o600))
+	assert.NotEqual(t, created, manifestFingerprint(options), "overlay file is watched")
+}
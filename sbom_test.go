@@ -0,0 +1,196 @@
+package version
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSBOMInfo returns an Info with modules, a replaced module and manifest components
+func testSBOMInfo() *Info {
+	return &Info{
+		Project: ProjectVersion{Name: "sbom-app", Version: "2.1.0"},
+		Git: GitInfo{
+			Commit:    "0123456789abcdef0123456789abcdef01234567",
+			RemoteURL: "https://github.com/acme/sbom-app.git",
+		},
+		Build: BuildInfo{MainModule: "github.com/acme/sbom-app", MainVersion: "v2.1.0"},
+		components: map[string]string{
+			"redis":    "7.2.4",
+			"keycloak": "24.0.1",
+		},
+		modules: []Module{
+			// h1 hash of 32 zero bytes
+			{Path: "go.uber.org/zap", Version: "v1.27.0", Sum: "h1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+			{Path: "golang.org/x/net", Version: "v0.20.0", Replace: &Module{Path: "golang.org/x/net", Version: "v0.21.0"}},
+			{Path: "example.com/patched", Version: "v1.0.0", Replace: &Module{Path: "../patched"}},
+		},
+		loadedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestInfo_SBOM_CycloneDX(t *testing.T) {
+	data, err := testSBOMInfo().SBOM(SBOMFormatCycloneDX)
+	require.NoError(t, err)
+
+	var doc cdxDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	assert.Equal(t, "CycloneDX", doc.BOMFormat)
+	assert.Equal(t, "1.5", doc.SpecVersion)
+	assert.Regexp(t, `^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-8[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, doc.SerialNumber)
+	assert.Equal(t, "2025-06-01T12:00:00Z", doc.Metadata.Timestamp)
+
+	main := doc.Metadata.Component
+	assert.Equal(t, "sbom-app", main.Name)
+	assert.Equal(t, "2.1.0", main.Version)
+	assert.Equal(t, "pkg:golang/github.com/acme/sbom-app@v2.1.0", main.PURL)
+	assert.Equal(t, []cdxExternalReference{{Type: "vcs", URL: "https://github.com/acme/sbom-app.git"}}, main.ExternalReferences)
+
+	require.Len(t, doc.Components, 5)
+	assert.Equal(t, "pkg:golang/go.uber.org/zap@v1.27.0", doc.Components[0].PURL)
+	assert.Equal(t, []cdxHash{{Algorithm: "SHA-256", Content: "0000000000000000000000000000000000000000000000000000000000000000"}},
+		doc.Components[0].Hashes)
+	assert.Equal(t, "pkg:golang/golang.org/x/net@v0.21.0", doc.Components[1].PURL, "replacement version is reported")
+	assert.Equal(t, "pkg:golang/example.com/patched", doc.Components[2].PURL)
+	assert.Equal(t, []cdxProperty{{Name: SBOMPropertyReplacedBy, Value: "../patched"}}, doc.Components[2].Properties)
+	assert.Equal(t, "keycloak", doc.Components[3].Name, "manifest components are first-class entries")
+	assert.Equal(t, "pkg:generic/keycloak@24.0.1", doc.Components[3].PURL)
+	assert.Equal(t, "pkg:generic/redis@7.2.4", doc.Components[4].PURL)
+
+	require.NotEmpty(t, doc.Dependencies)
+	assert.Equal(t, main.BOMRef, doc.Dependencies[0].Ref)
+	assert.Len(t, doc.Dependencies[0].DependsOn, 5)
+}
+
+func TestInfo_SBOM_SPDX(t *testing.T) {
+	data, err := testSBOMInfo().SBOM(SBOMFormatSPDX)
+	require.NoError(t, err)
+
+	var doc spdxDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+	assert.Equal(t, "CC0-1.0", doc.DataLicense)
+	assert.Equal(t, "SPDXRef-DOCUMENT", doc.SPDXID)
+	assert.Equal(t, "sbom-app-2.1.0", doc.Name)
+	assert.Contains(t, doc.DocumentNamespace, "https://spdx.org/spdxdocs/sbom-app-2.1.0-")
+	assert.Equal(t, "2025-06-01T12:00:00Z", doc.CreationInfo.Created)
+
+	require.Len(t, doc.Packages, 6)
+	main := doc.Packages[0]
+	assert.Equal(t, "SPDXRef-Package-sbom-app", main.SPDXID)
+	assert.Equal(t, "git+https://github.com/acme/sbom-app.git@0123456789abcdef0123456789abcdef01234567", main.DownloadLocation)
+
+	zap := doc.Packages[1]
+	assert.Equal(t, "SPDXRef-Module-go.uber.org-zap", zap.SPDXID)
+	assert.Equal(t, "NOASSERTION", zap.DownloadLocation)
+	assert.Equal(t, "SHA256", zap.Checksums[0].Algorithm)
+	assert.Equal(t, "pkg:golang/go.uber.org/zap@v1.27.0", zap.ExternalRefs[0].ReferenceLocator)
+
+	assert.Equal(t, "SPDXRef-Component-keycloak", doc.Packages[4].SPDXID)
+
+	require.Len(t, doc.Relationships, 6)
+	assert.Equal(t, spdxRelationship{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: main.SPDXID},
+		doc.Relationships[0])
+	for _, rel := range doc.Relationships[1:] {
+		assert.Equal(t, main.SPDXID, rel.SPDXElementID)
+		assert.Equal(t, "DEPENDS_ON", rel.RelationshipType)
+	}
+}
+
+func TestInfo_SBOM_Deterministic(t *testing.T) {
+	for _, format := range []SBOMFormat{SBOMFormatCycloneDX, SBOMFormatSPDX} {
+		first, err := testSBOMInfo().SBOM(format)
+		require.NoError(t, err)
+		second, err := testSBOMInfo().SBOM(format)
+		require.NoError(t, err)
+		assert.Equal(t, string(first), string(second), format)
+	}
+}
+
+func TestInfo_SBOM_UnsupportedFormat(t *testing.T) {
+	_, err := testSBOMInfo().SBOM("swid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported SBOM format 'swid'")
+	assert.Contains(t, err.Error(), "Hint:")
+}
+
+func TestInfo_SBOM_FromLoader(t *testing.T) {
+	info, err := New(WithEmbedded([]byte(testManifestForOverrides)), WithBuildInfo())
+	require.NoError(t, err)
+
+	data, err := info.SBOM(SBOMFormatCycloneDX)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"pkg:golang/github.com/stretchr/testify@`)
+	assert.Contains(t, string(data), `"name": "Cache"`)
+}
+
+func TestGoSumToSHA256(t *testing.T) {
+	tests := map[string]struct {
+		sum  string
+		want string
+	}{
+		"valid":         {sum: "h1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=", want: "0000000000000000000000000000000000000000000000000000000000000000"},
+		"empty":         {sum: "", want: ""},
+		"other_hash":    {sum: "h2:AAAA", want: ""},
+		"invalid_b64":   {sum: "h1:!!!", want: ""},
+		"wrong_length":  {sum: "h1:AAAA", want: ""},
+		"missing_colon": {sum: "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=", want: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, goSumToSHA256(tt.sum))
+		})
+	}
+}
+
+func TestSPDXID(t *testing.T) {
+	used := make(map[string]bool)
+	assert.Equal(t, "SPDXRef-Module-github.com-a-b", spdxID(SPDXIDPrefixModule, "github.com/a_b", used))
+	assert.Equal(t, "SPDXRef-Module-github.com-a-b-2", spdxID(SPDXIDPrefixModule, "github.com/a-b", used))
+	assert.Equal(t, "SPDXRef-Component-caf-", spdxID(SPDXIDPrefixComponent, "café", used))
+}
+
+func TestSBOMHandler(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	require.NoError(t, Initialize(WithEmbedded([]byte(testManifestForOverrides))))
+
+	tests := map[string]struct {
+		target      string
+		wantStatus  int
+		wantType    string
+		wantContent string
+	}{
+		"default_cyclonedx": {target: "/sbom", wantStatus: http.StatusOK, wantType: HTTPContentTypeCycloneDX, wantContent: `"bomFormat": "CycloneDX"`},
+		"spdx":              {target: "/sbom?format=spdx", wantStatus: http.StatusOK, wantType: HTTPContentTypeSPDX, wantContent: `"spdxVersion": "SPDX-2.3"`},
+		"unsupported":       {target: "/sbom?format=swid", wantStatus: http.StatusBadRequest},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			SBOMHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, http.NoBody))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, tt.wantType, w.Header().Get("Content-Type"))
+				assert.Contains(t, w.Body.String(), tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestSBOMHandler_MethodNotAllowed(t *testing.T) {
+	w := httptest.NewRecorder()
+	SBOMHandler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/sbom", http.NoBody))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -0,0 +1,129 @@
+package version
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSlogInfo returns an Info with optional git values and dimensions
+func testSlogInfo() *Info {
+	return &Info{
+		Project:     ProjectVersion{Name: "slog-app", Version: "1.2.3"},
+		environment: "staging",
+		Git:         GitInfo{Commit: "abc123", Tag: "v1.2.3", TreeState: GitTreeStateClean},
+		Build:       BuildInfo{Time: "2025-06-01T12:00:00Z", GoVersion: "go1.24.6"},
+		schemas:     map[string]string{"redis": "3", "postgres_main": "45"},
+		apis:        map[string]string{"rest_v1": "v1.15"},
+	}
+}
+
+// decodeLogLine decodes a single JSON log line
+func decodeLogLine(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line), buf.String())
+	buf.Reset()
+	return line
+}
+
+func TestInfo_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("started", "version", testSlogInfo())
+
+	line := decodeLogLine(t, &buf)
+	assert.Equal(t, map[string]any{
+		"project":     map[string]any{"name": "slog-app", "version": "1.2.3"},
+		"environment": "staging",
+		"git":         map[string]any{"commit": "abc123", "tag": "v1.2.3", "tree_state": "clean"},
+		"build":       map[string]any{"time": "2025-06-01T12:00:00Z", "go_version": "go1.24.6"},
+		"schemas":     map[string]any{"postgres_main": "45", "redis": "3"},
+		"apis":        map[string]any{"rest_v1": "v1.15"},
+	}, line["version"])
+}
+
+func TestInfo_SlogAttrs(t *testing.T) {
+	tests := map[string]struct {
+		info     *Info
+		wantKeys []string
+	}{
+		"full":    {info: testSlogInfo(), wantKeys: []string{"project", "environment", "git", "build", "schemas", "apis"}},
+		"minimal": {info: &Info{}, wantKeys: []string{"project", "git", "build"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var keys []string
+			for _, attr := range tt.info.SlogAttrs() {
+				keys = append(keys, attr.Key)
+			}
+			assert.Equal(t, tt.wantKeys, keys)
+		})
+	}
+}
+
+func TestSlogHandler(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	require.NoError(t, Initialize(WithEmbedded([]byte(testManifestForOverrides)), WithoutGitInfo()))
+
+	var buf bytes.Buffer
+	logger := slog.New(SlogHandler(slog.NewJSONHandler(&buf, nil)))
+
+	logger.Info("plain", "status", 200)
+	line := decodeLogLine(t, &buf)
+	assert.Equal(t, "plain", line["msg"])
+	assert.EqualValues(t, 200, line["status"])
+	require.IsType(t, map[string]any{}, line["version"])
+	assert.Equal(t, map[string]any{"name": "override-app", "version": "1.2.3"}, line["version"].(map[string]any)["project"])
+
+	// The version group stays at the top level of grouped loggers
+	logger.With("request_id", "r1").WithGroup("http").Info("grouped", "method", "GET")
+	line = decodeLogLine(t, &buf)
+	assert.Equal(t, "r1", line["request_id"])
+	assert.Equal(t, map[string]any{"method": "GET"}, line["http"])
+	assert.Contains(t, line, "version")
+}
+
+func TestSlogHandler_FollowsReload(t *testing.T) {
+	reg := newTestRegistry(t, "billing", "1.0.0")
+
+	var buf bytes.Buffer
+	logger := slog.New(reg.SlogHandler(slog.NewJSONHandler(&buf, nil))).With("component", "worker")
+
+	logger.Info("before")
+	line := decodeLogLine(t, &buf)
+	assert.Equal(t, "1.0.0", line["version"].(map[string]any)["project"].(map[string]any)["version"])
+
+	reloaded := *reg.MustGet()
+	reloaded.Project.Version = "1.1.0"
+	reg.instance.Store(&reloaded)
+
+	logger.Info("after")
+	line = decodeLogLine(t, &buf)
+	assert.Equal(t, "1.1.0", line["version"].(map[string]any)["project"].(map[string]any)["version"])
+	assert.Equal(t, "worker", line["component"])
+}
+
+func TestSlogHandler_Unavailable(t *testing.T) {
+	var buf bytes.Buffer
+	handler := newSlogHandler(func() (*Info, error) { return nil, ErrNotInitialized }, slog.NewJSONHandler(&buf, nil))
+
+	slog.New(handler).Info("no version")
+	line := decodeLogLine(t, &buf)
+	assert.Equal(t, "no version", line["msg"])
+	assert.NotContains(t, line, "version")
+}
+
+func TestSlogHandler_Enabled(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(SlogHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	logger.Info("dropped")
+	assert.Empty(t, buf.String())
+}
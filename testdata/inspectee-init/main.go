@@ -0,0 +1,28 @@
+// Command inspectee-init is built by the Inspect tests with an embedded
+// manifest and, like go-version init, the unrendered manifest template.
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+
+	"github.com/itsatony/go-version"
+	"github.com/itsatony/go-version/internal/manifesttmpl"
+)
+
+//go:embed versions.yaml
+var manifest []byte
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "init" {
+		_, _ = os.Stdout.Write(manifesttmpl.Render("example"))
+		return
+	}
+
+	info, err := version.New(version.WithEmbedded(manifest))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(info.String())
+}
@@ -0,0 +1,21 @@
+// Command inspectee is built by the Inspect tests with an embedded manifest
+// and ldflags-injected version variables.
+package main
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/itsatony/go-version"
+)
+
+//go:embed versions.yaml
+var manifest []byte
+
+func main() {
+	info, err := version.New(version.WithEmbedded(manifest))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(info.String())
+}
@@ -0,0 +1,112 @@
+package version
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTextInfo returns an Info with fixed git and build values
+func testTextInfo() *Info {
+	return &Info{
+		Project:    ProjectVersion{Name: "text-app", Version: "1.2.3"},
+		Git:        GitInfo{Commit: "abc123", Tag: "v1.2.3", TreeState: GitTreeStateClean},
+		Build:      BuildInfo{Time: "2025-06-01T12:00:00Z", GoVersion: "go1.24.6"},
+		schemas:    map[string]string{"redis": "3", "postgres_main": "45"},
+		components: map[string]string{"Cache": "1.0.0"},
+		custom:     map[string]interface{}{"region": "us-east-1"},
+	}
+}
+
+func TestInfo_WriteText(t *testing.T) {
+	tests := map[string]struct {
+		section InfoSection
+		want    string
+	}{
+		"full": {
+			want: `Project Information:
+  Name:     text-app
+  Version:  1.2.3
+
+Git Information:
+  Commit:      abc123
+  Tag:         v1.2.3
+  Tree State:  clean
+
+Build Information:
+  Time:        2025-06-01T12:00:00Z
+  Go Version:  go1.24.6
+
+Database Schemas:
+  postgres_main:  45
+  redis:          3
+
+Component Versions:
+  Cache:  1.0.0
+
+Custom Metadata:
+  region:  us-east-1
+`,
+		},
+		"git": {
+			section: SectionGit,
+			want:    "Git Information:\n  Commit:      abc123\n  Tag:         v1.2.3\n  Tree State:  clean\n",
+		},
+		"schemas": {
+			section: SectionSchemas,
+			want:    "Database Schemas:\n  postgres_main:  45\n  redis:          3\n",
+		},
+		"empty_apis": {
+			section: SectionAPIs,
+			want:    "No API versions defined\n",
+		},
+		"empty_modules": {
+			section: SectionModules,
+			want:    "No module dependencies recorded\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, testTextInfo().WriteText(&buf, tt.section))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestInfo_WriteText_UnsupportedSection(t *testing.T) {
+	var buf bytes.Buffer
+	err := testTextInfo().WriteText(&buf, "custom")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported section 'custom'")
+	assert.Empty(t, buf.String())
+}
+
+func TestParseInfoSection(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		want    InfoSection
+		wantErr bool
+	}{
+		"git":        {name: "git", want: SectionGit},
+		"components": {name: "components", want: SectionComponents},
+		"modules":    {name: "modules", want: SectionModules},
+		"unknown":    {name: "project", wantErr: true},
+		"empty":      {name: "", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			section, err := ParseInfoSection(tt.name)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, section)
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package version
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/itsatony/go-version/internal/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// BumpPart selects which part of a version Bump increments.
+type BumpPart string
+
+// Supported bump parts
+const (
+	// BumpMajor increments the major version (1.4.2 -> 2.0.0)
+	BumpMajor BumpPart = "major"
+
+	// BumpMinor increments the minor version (1.4.2 -> 1.5.0)
+	BumpMinor BumpPart = "minor"
+
+	// BumpPatch increments the patch version (1.4.2 -> 1.4.3)
+	BumpPatch BumpPart = "patch"
+
+	// BumpPrerelease increments the prerelease number (1.5.0-rc.1 -> 1.5.0-rc.2)
+	BumpPrerelease BumpPart = "prerelease"
+)
+
+// Bump returns the next version for the given part. Build metadata is dropped.
+//
+// Major, minor and patch bumps of a prerelease first release it when the
+// prerelease already targets that part (1.5.0-rc.2 minor -> 1.5.0). With a
+// non-empty preID they start a prerelease of the bumped version instead
+// (1.4.2 minor "rc" -> 1.5.0-rc.0).
+//
+// Prerelease bumps increment the trailing number of the current prerelease
+// (1.5.0-rc.1 -> 1.5.0-rc.2), switch to preID if it differs (1.5.0-beta.3
+// "rc" -> 1.5.0-rc.0), or start a prerelease of the next patch version for
+// release versions (1.4.2 -> 1.4.3-rc.0, using BumpDefaultPrereleaseID if
+// preID is empty).
+//
+// Returns an error for unknown parts, invalid identifiers and bumps that would
+// not increase the version (e.g. 1.5.0-rc.1 prerelease "beta").
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	next, err := version.MustParseSemVer("1.4.2").Bump(version.BumpMinor, "")
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(next) // "1.5.0"
+func (v *SemVer) Bump(part BumpPart, preID string) (*SemVer, error) {
+	if preID != "" {
+		if strings.Contains(preID, ".") {
+			return nil, fmt.Errorf(ErrFmtInvalidPrereleaseID, preID, errors.New("must be a single identifier"))
+		}
+		if _, err := semver.ParseStrict("0.0.0-" + preID); err != nil {
+			return nil, fmt.Errorf(ErrFmtInvalidPrereleaseID, preID, err)
+		}
+	}
+
+	cur := v.internal
+	next := &semver.Version{Major: cur.Major, Minor: cur.Minor, Patch: cur.Patch}
+
+	switch part {
+	case BumpMajor:
+		if cur.Prerelease == "" || cur.Minor != 0 || cur.Patch != 0 || preID != "" {
+			next.Major, next.Minor, next.Patch = cur.Major+1, 0, 0
+		}
+	case BumpMinor:
+		if cur.Prerelease == "" || cur.Patch != 0 || preID != "" {
+			next.Minor, next.Patch = cur.Minor+1, 0
+		}
+	case BumpPatch:
+		if cur.Prerelease == "" || preID != "" {
+			next.Patch = cur.Patch + 1
+		}
+	case BumpPrerelease:
+		next.Prerelease = nextPrerelease(cur, preID)
+		if cur.Prerelease == "" {
+			next.Patch = cur.Patch + 1
+		}
+	default:
+		return nil, fmt.Errorf(ErrFmtUnsupportedBumpPart, part)
+	}
+
+	if part != BumpPrerelease && preID != "" {
+		next.Prerelease = preID + ".0"
+	}
+
+	if next.Compare(cur) <= 0 {
+		return nil, fmt.Errorf(ErrFmtBumpBackwards, v, next)
+	}
+	return &SemVer{internal: next}, nil
+}
+
+// nextPrerelease computes the prerelease of a BumpPrerelease bump.
+func nextPrerelease(cur *semver.Version, preID string) string {
+	if cur.Prerelease == "" {
+		if preID == "" {
+			preID = BumpDefaultPrereleaseID
+		}
+		return preID + ".0"
+	}
+
+	ids := strings.Split(cur.Prerelease, ".")
+	if preID != "" && ids[0] != preID {
+		return preID + ".0"
+	}
+
+	last := ids[len(ids)-1]
+	if n, err := strconv.Atoi(last); err == nil {
+		ids[len(ids)-1] = strconv.Itoa(n + 1)
+		return strings.Join(ids, ".")
+	}
+	return cur.Prerelease + ".0"
+}
+
+// BumpResult describes a version bump applied to a manifest.
+type BumpResult struct {
+	// Dimension is the bumped manifest value (e.g. "project.version", "apis.rest_v1")
+	Dimension string
+
+	// Old is the version before the bump, as written in the manifest
+	Old string
+
+	// New is the version after the bump, as written to the manifest
+	New string
+}
+
+// BumpManifest bumps a version in the YAML manifest at path and rewrites the
+// file in place.
+//
+// dimension selects the value: "project" (or "" or "project.version") for the
+// project version, or "<section>.<name>" for an existing schemas, apis or
+// components entry. part and preID are applied as described for SemVer.Bump.
+// The new value keeps the old one's "v" prefix, quoting and number of
+// components where possible (schema "47" becomes "48", API "v1.15" becomes
+// "v1.16"); everything else in the file, including comments, key order and
+// formatting, is left untouched.
+//
+// JSON and TOML manifests are not supported. The file is replaced atomically.
+//
+// Example:
+//
+//	result, err := version.BumpManifest("versions.yaml", "apis.rest_v1", version.BumpMinor, "")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%s: %s -> %s\n", result.Dimension, result.Old, result.New)
+func BumpManifest(path, dimension string, part BumpPart, preID string) (*BumpResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, wrapError(err, CategoryManifest, ErrMsgBumpManifest)
+	}
+	if format := detectManifestFormat(ManifestFormatAuto, path, data); format != ManifestFormatYAML {
+		return nil, newCategoryErrorWithHint(CategoryManifest,
+			fmt.Sprintf(ErrFmtBumpUnsupportedFormat, format, path), ErrHintBumpFormat)
+	}
+
+	section, key, err := parseBumpDimension(dimension)
+	if err != nil {
+		return nil, err
+	}
+	result := &BumpResult{Dimension: section + BumpDimensionSeparator + key}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, wrapErrorWithHint(err, CategoryManifest, ErrMsgBumpManifest, ErrHintParseYAML)
+	}
+	node := findManifestScalar(&doc, section, key)
+	if node == nil {
+		return nil, newCategoryErrorWithHint(CategoryManifest,
+			fmt.Sprintf(ErrFmtBumpDimensionNotFound, result.Dimension, path), ErrHintBumpDimension)
+	}
+	result.Old = node.Value
+
+	current, err := ParseSemVer(node.Value)
+	if err != nil {
+		return nil, wrapError(fmt.Errorf(ErrFmtBumpInvalidVersion, result.Dimension, node.Value, err),
+			CategoryValidation, ErrMsgBumpManifest)
+	}
+	next, err := current.Bump(part, preID)
+	if err != nil {
+		return nil, wrapError(err, CategoryValidation, ErrMsgBumpManifest)
+	}
+	result.New = formatBumpedVersion(node.Value, next)
+
+	updated, err := replaceScalar(data, node, result.New)
+	if err != nil {
+		return nil, wrapError(err, CategoryManifest, ErrMsgBumpManifest)
+	}
+	if _, err := decodeManifest(updated, ManifestFormatYAML); err != nil {
+		return nil, wrapError(err, CategoryManifest, ErrMsgBumpManifest)
+	}
+	if err := writeFileAtomic(path, updated); err != nil {
+		return nil, wrapError(err, CategoryManifest, ErrMsgBumpManifest)
+	}
+	return result, nil
+}
+
+// parseBumpDimension splits a bump dimension into manifest section and key.
+func parseBumpDimension(dimension string) (section, key string, err error) {
+	if dimension == "" || dimension == DimensionProject {
+		dimension = BumpDefaultDimension
+	}
+	section, key, ok := strings.Cut(dimension, BumpDimensionSeparator)
+	valid := ok && key != ""
+	switch section {
+	case DimensionProject:
+		valid = valid && key == "version"
+	case DimensionSchemas, DimensionAPIs, DimensionComponents:
+	default:
+		valid = false
+	}
+	if !valid {
+		return "", "", newCategoryErrorWithHint(CategoryValidation,
+			fmt.Sprintf(ErrFmtInvalidBumpDimension, dimension), ErrHintBumpDimension)
+	}
+	return section, key, nil
+}
+
+// findManifestScalar returns the scalar value node at section.key of a YAML
+// document, or nil.
+func findManifestScalar(doc *yaml.Node, section, key string) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	node := mappingValue(doc.Content[0], section)
+	if node == nil {
+		return nil
+	}
+	node = mappingValue(node, key)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return nil
+	}
+	return node
+}
+
+// mappingValue returns the value node for key in a YAML mapping node, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// formatBumpedVersion renders next in the style of old: a "v" prefix is kept,
+// and short forms ("47", "1.15") stay short while the dropped components are zero.
+func formatBumpedVersion(old string, next *SemVer) string {
+	prefix := ""
+	if strings.HasPrefix(old, "v") {
+		prefix = "v"
+	}
+	core, _, _ := strings.Cut(strings.TrimPrefix(old, "v"), "-")
+	core, _, _ = strings.Cut(core, "+")
+	components := strings.Count(core, ".") + 1
+
+	v := next.internal
+	switch {
+	case v.Prerelease != "" || v.Build != "":
+		return prefix + v.String()
+	case components == 1 && v.Minor == 0 && v.Patch == 0:
+		return prefix + strconv.Itoa(v.Major)
+	case components <= 2 && v.Patch == 0:
+		return prefix + strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor)
+	default:
+		return prefix + v.String()
+	}
+}
+
+// replaceScalar replaces the source text of a plain or quoted scalar node with
+// value, keeping the node's quoting. All other bytes are left as they are.
+func replaceScalar(data []byte, node *yaml.Node, value string) ([]byte, error) {
+	var quote string
+	switch node.Style {
+	case 0:
+	case yaml.DoubleQuotedStyle:
+		quote = `"`
+	case yaml.SingleQuotedStyle:
+		quote = `'`
+	default:
+		return nil, fmt.Errorf("line %d: unsupported scalar style for version value", node.Line)
+	}
+	oldText := quote + node.Value + quote
+
+	start, ok := lineColumnOffset(data, node.Line, node.Column)
+	if !ok || !bytes.HasPrefix(data[start:], []byte(oldText)) {
+		return nil, fmt.Errorf("line %d: cannot locate version value %q", node.Line, node.Value)
+	}
+
+	out := make([]byte, 0, len(data)+len(value)-len(node.Value))
+	out = append(out, data[:start]...)
+	out = append(out, quote+value+quote...)
+	out = append(out, data[start+len(oldText):]...)
+	return out, nil
+}
+
+// lineColumnOffset converts a 1-based line and character column into a byte offset.
+func lineColumnOffset(data []byte, line, col int) (int, bool) {
+	offset := 0
+	for l := 1; l < line; l++ {
+		idx := bytes.IndexByte(data[offset:], '\n')
+		if idx < 0 {
+			return 0, false
+		}
+		offset += idx + 1
+	}
+	for c := 1; c < col; c++ {
+		if offset >= len(data) || data[offset] == '\n' {
+			return 0, false
+		}
+		_, size := utf8.DecodeRune(data[offset:])
+		offset += size
+	}
+	return offset, offset <= len(data)
+}
+
+// writeFileAtomic replaces the file at path with data, keeping its permissions.
+func writeFileAtomic(path string, data []byte) error {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(stat.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,296 @@
+package version
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ConventionalCommit is a commit message parsed according to the
+// Conventional Commits specification (https://www.conventionalcommits.org).
+type ConventionalCommit struct {
+	// Hash is the full commit hash
+	Hash string
+
+	// Type is the lower-case commit type (e.g. "feat", "fix"); empty if the
+	// message does not follow the specification
+	Type string
+
+	// Scope is the optional scope (e.g. "cli" in "feat(cli): ...")
+	Scope string
+
+	// Description is the text after the type, or the subject line of
+	// messages that do not follow the specification
+	Description string
+
+	// Breaking is set by a "!" after type or scope and by a
+	// "BREAKING CHANGE:" footer
+	Breaking bool
+}
+
+// conventionalCommitHeader matches "type(scope)!: description"
+var conventionalCommitHeader = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?: +(\S.*)$`)
+
+// changelogSections maps commit types to Keep a Changelog sections. Types
+// that are not user-facing (docs, test, chore, ci, build, style) are left out.
+var changelogSections = map[string]string{
+	"feat":      ChangelogAdded,
+	"fix":       ChangelogFixed,
+	"perf":      ChangelogChanged,
+	"refactor":  ChangelogChanged,
+	"revert":    ChangelogChanged,
+	"deprecate": ChangelogDeprecated,
+	"remove":    ChangelogRemoved,
+	"security":  ChangelogSecurity,
+}
+
+// changelogSectionOrder is the order of sections in a release
+var changelogSectionOrder = []string{
+	ChangelogAdded, ChangelogChanged, ChangelogDeprecated, ChangelogRemoved, ChangelogFixed, ChangelogSecurity,
+}
+
+// ParseConventionalCommit parses a raw commit message.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	c := version.ParseConventionalCommit(hash, "feat(cli)!: drop the -v flag")
+//	fmt.Println(c.Type, c.Scope, c.Breaking) // "feat cli true"
+func ParseConventionalCommit(hash, message string) ConventionalCommit {
+	subject, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	commit := ConventionalCommit{Hash: hash, Description: strings.TrimSpace(subject)}
+
+	match := conventionalCommitHeader.FindStringSubmatch(commit.Description)
+	if match == nil {
+		return commit
+	}
+	commit.Type = strings.ToLower(match[1])
+	commit.Scope = strings.TrimSpace(match[2])
+	commit.Breaking = match[3] != ""
+	commit.Description = strings.TrimSpace(match[4])
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, CommitBreakingChangeFooter) || strings.HasPrefix(line, CommitBreakingChangeFooterHyphen) {
+			commit.Breaking = true
+		}
+	}
+	return commit
+}
+
+// ChangelogSection returns the Keep a Changelog section the commit is listed
+// under, or "" if it is left out. Breaking changes of types without a
+// section are listed under Changed.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	version.ParseConventionalCommit("", "fix: handle empty tags").ChangelogSection() // "Fixed"
+func (c ConventionalCommit) ChangelogSection() string {
+	if section, ok := changelogSections[c.Type]; ok {
+		return section
+	}
+	if c.Breaking {
+		return ChangelogChanged
+	}
+	return ""
+}
+
+// ReleaseNotes are the commits since the last release.
+type ReleaseNotes struct {
+	// PreviousTag is the most recent tag reachable from HEAD (empty if there is none)
+	PreviousTag string
+
+	// Commits are the commits after PreviousTag, oldest first
+	Commits []ConventionalCommit
+}
+
+// ReadReleaseNotes reads the commits between the most recent tag and HEAD
+// of the repository in the working directory, or all commits if there is no
+// tag. Like the git fallback of the loader, it runs the git binary.
+//
+// Returns an error if git is not available, the working directory is not in
+// a repository, git fails to list the commits or there are no commits since
+// the tag.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	notes, err := version.ReadReleaseNotes()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(notes.SuggestedBump(), len(notes.Commits))
+func ReadReleaseNotes() (*ReleaseNotes, error) {
+	if _, err := runGitOutput(GitCmdRevParse, GitArgHead); err != nil {
+		return nil, wrapErrorWithHint(err, CategoryBuildInfo, ErrMsgReadCommits, ErrHintReadCommits)
+	}
+
+	// describe fails when no tag is reachable: then all commits are listed
+	notes := &ReleaseNotes{PreviousTag: runGit(GitCmdDescribe, GitArgTags, GitArgAbbrevZero)}
+	revisions := GitArgHead
+	if notes.PreviousTag != "" {
+		revisions = notes.PreviousTag + GitRevisionRange + GitArgHead
+	}
+
+	output, err := runGitOutput(GitCmdLog, GitArgCommitLogFormat, GitArgEndOfOptions, revisions)
+	if err != nil {
+		return nil, wrapErrorWithHint(err, CategoryBuildInfo, ErrMsgReadCommits, ErrHintReadCommits)
+	}
+	notes.Commits = parseCommitLog(output)
+	if len(notes.Commits) == 0 {
+		return nil, newCategoryErrorWithHint(CategoryBuildInfo, ErrMsgNoCommits, ErrHintNoCommits)
+	}
+	return notes, nil
+}
+
+// parseCommitLog parses git log output in GitArgCommitLogFormat and returns
+// the commits oldest first.
+func parseCommitLog(output string) []ConventionalCommit {
+	var commits []ConventionalCommit
+	for _, record := range strings.Split(output, "\x1e") {
+		hash, message, ok := strings.Cut(strings.TrimSpace(record), "\x1f")
+		if !ok || !isValidCommitHash(hash) {
+			continue
+		}
+		commits = append(commits, ParseConventionalCommit(hash, message))
+	}
+	// git log lists the newest commit first
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits
+}
+
+// SuggestedBump returns the version bump the commits call for: major for
+// breaking changes, minor for features and patch otherwise.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	next, err := current.Bump(notes.SuggestedBump(), "")
+func (n *ReleaseNotes) SuggestedBump() BumpPart {
+	part := BumpPatch
+	for _, commit := range n.Commits {
+		if commit.Breaking {
+			return BumpMajor
+		}
+		if commit.Type == "feat" {
+			part = BumpMinor
+		}
+	}
+	return part
+}
+
+// Section renders the commits as a Keep a Changelog release section,
+// grouped into Added, Changed, Deprecated, Removed, Fixed and Security.
+// Commits that are not user-facing or not conventional are left out.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	section := notes.Section("1.5.0", time.Now())
+//	// ## [1.5.0] - 2025-06-01
+//	//
+//	// ### Added
+//	// - **cli:** diff command
+func (n *ReleaseNotes) Section(version string, date time.Time) string {
+	grouped := make(map[string][]string)
+	for _, commit := range n.Commits {
+		section := commit.ChangelogSection()
+		if section == "" {
+			continue
+		}
+		entry := commit.Description
+		if commit.Scope != "" {
+			entry = "**" + commit.Scope + ":** " + entry
+		}
+		if commit.Breaking {
+			entry = "**BREAKING:** " + entry
+		}
+		grouped[section] = append(grouped[section], entry)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## [%s] - %s\n", version, date.Format(ChangelogDateFormat))
+	for _, section := range changelogSectionOrder {
+		if len(grouped[section]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n", section)
+		for _, entry := range grouped[section] {
+			fmt.Fprintf(&b, "- %s\n", entry)
+		}
+	}
+	return b.String()
+}
+
+// PrependChangelog inserts a release section into the Keep a Changelog file
+// at path: after the Unreleased section and before the previous release. The
+// file is created with the standard header if it does not exist. Returns an
+// error if the changelog already has a section for the release.
+//
+// Thread-safe for concurrent use by multiple goroutines, but not for
+// concurrent updates of the same file.
+//
+// Example:
+//
+//	err := version.PrependChangelog("CHANGELOG.md", notes.Section("1.5.0", time.Now()))
+func PrependChangelog(path, section string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		content := ChangelogHeader + "\n" + section
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return wrapError(err, CategoryManifest, ErrMsgUpdateChangelog)
+		}
+		return nil
+	}
+	if err != nil {
+		return wrapError(err, CategoryManifest, ErrMsgUpdateChangelog)
+	}
+
+	heading, _, _ := strings.Cut(section, "]")
+	heading += "]"
+	offset := -1
+	for pos := 0; pos < len(data); {
+		line := data[pos:]
+		if end := bytes.IndexByte(line, '\n'); end >= 0 {
+			line = line[:end+1]
+		}
+		text := strings.TrimSpace(string(line))
+		if strings.HasPrefix(text, heading) {
+			return wrapError(fmt.Errorf(ErrFmtChangelogReleaseExists, path, heading), CategoryValidation, ErrMsgUpdateChangelog)
+		}
+		if offset < 0 && strings.HasPrefix(text, "## ") &&
+			!strings.EqualFold(strings.TrimPrefix(text, "## "), "["+ChangelogUnreleased+"]") {
+			offset = pos
+		}
+		pos += len(line)
+	}
+
+	var updated []byte
+	if offset < 0 {
+		updated = append(bytes.TrimRight(data, "\n"), "\n\n"...)
+		updated = append(updated, section...)
+	} else {
+		updated = append(updated, data[:offset]...)
+		updated = append(updated, section...)
+		updated = append(updated, '\n')
+		updated = append(updated, data[offset:]...)
+	}
+
+	if err := writeFileAtomic(path, updated); err != nil {
+		return wrapError(err, CategoryManifest, ErrMsgUpdateChangelog)
+	}
+	return nil
+}
@@ -8,8 +8,186 @@ const (
 	// ManifestFilenameYAML is the default YAML manifest filename
 	ManifestFilenameYAML = "versions.yaml"
 
-	// ManifestFilenameJSON is the default JSON manifest filename (future support)
+	// ManifestFilenameYML is the alternative YAML manifest filename
+	ManifestFilenameYML = "versions.yml"
+
+	// ManifestFilenameJSON is the default JSON manifest filename
 	ManifestFilenameJSON = "versions.json"
+
+	// ManifestFilenameTOML is the default TOML manifest filename
+	ManifestFilenameTOML = "versions.toml"
+)
+
+// Environment variables
+const (
+	// EnvVarEnvironment selects the environment overlay when WithEnvironment is not used
+	EnvVarEnvironment = "GOVERSION_ENV"
+
+	// DefaultEnvOverridePrefix is the conventional prefix for WithEnvOverrides
+	DefaultEnvOverridePrefix = "GOVERSION"
+
+	// EnvOverrideProjectName is the variable suffix that overrides project.name
+	EnvOverrideProjectName = "PROJECT_NAME"
+
+	// EnvOverrideProjectVersion is the variable suffix that overrides project.version
+	EnvOverrideProjectVersion = "PROJECT_VERSION"
+
+	// EnvOverrideSchemasPrefix is the variable suffix prefix for schema overrides
+	EnvOverrideSchemasPrefix = "SCHEMAS_"
+
+	// EnvOverrideAPIsPrefix is the variable suffix prefix for API overrides
+	EnvOverrideAPIsPrefix = "APIS_"
+
+	// EnvOverrideComponentsPrefix is the variable suffix prefix for component overrides
+	EnvOverrideComponentsPrefix = "COMPONENTS_"
+
+	// EnvOverrideCustomPrefix is the variable suffix prefix for custom dimension overrides
+	EnvOverrideCustomPrefix = "CUSTOM_"
+
+	// OverrideSourceEnvPrefix prefixes the variable name in recorded override sources
+	OverrideSourceEnvPrefix = "env:"
+)
+
+// Version dimensions (manifest sections)
+const (
+	// DimensionProject is the project section
+	DimensionProject = "project"
+
+	// DimensionSchemas is the database schemas section
+	DimensionSchemas = "schemas"
+
+	// DimensionAPIs is the API versions section
+	DimensionAPIs = "apis"
+
+	// DimensionComponents is the component versions section
+	DimensionComponents = "components"
+
+	// DimensionCustom is the custom dimensions section
+	DimensionCustom = "custom"
+
+	// DimensionModules is the Go module dependencies (from build info, not the manifest)
+	DimensionModules = "modules"
+
+	// DiffProjectName and DiffProjectVersion name the project values in Diff changes
+	DiffProjectName    = "name"
+	DiffProjectVersion = "version"
+)
+
+// Field paths of WithHiddenFields and manifest "private" entries; the
+// dimensions above address their parts as well
+const (
+	// FieldPathSeparator separates a part and its field or entry (e.g. "build.user")
+	FieldPathSeparator = "."
+
+	// FieldPathEnvironment is the applied environment overlay
+	FieldPathEnvironment = "environment"
+
+	// FieldPathGit is the git information
+	FieldPathGit = "git"
+
+	// FieldPathBuild is the build information
+	FieldPathBuild = "build"
+
+	// FieldPathOverrides is the sources of applied overrides
+	FieldPathOverrides = "overrides"
+
+	// FieldPathBuildUser is the user who built the binary
+	FieldPathBuildUser = FieldPathBuild + FieldPathSeparator + "user"
+
+	// FieldPathGitRemoteURL is the git remote URL
+	FieldPathGitRemoteURL = FieldPathGit + FieldPathSeparator + "remote_url"
+)
+
+// Manifest template constants
+const (
+	// ManifestTemplatePlaceholder stands for the project name in the manifest
+	// template of go-version init. It is not valid YAML, so the unrendered
+	// template never parses as a manifest.
+	ManifestTemplatePlaceholder = "{{PROJECT_NAME}}"
+)
+
+// Version bump constants
+const (
+	// BumpDefaultPrereleaseID is the prerelease identifier used when bumping a
+	// release version to a prerelease without an explicit identifier
+	BumpDefaultPrereleaseID = "rc"
+
+	// BumpDefaultDimension is the manifest value bumped when no dimension is given
+	BumpDefaultDimension = DimensionProject + ".version"
+
+	// BumpDimensionSeparator separates section and key in bump dimensions (e.g. "apis.rest_v1")
+	BumpDimensionSeparator = "."
+)
+
+// Binary inspection constants
+const (
+	// PackageImportPath is the import path of this package; ldflags variables
+	// are named PackageImportPath + "." + variable in symbol tables
+	PackageImportPath = "github.com/itsatony/go-version"
+
+	// InspectManifestMarker locates YAML manifests embedded in inspected binaries
+	InspectManifestMarker = "manifest_version:"
+
+	// InspectMaxManifestSize bounds the size of an embedded manifest
+	InspectMaxManifestSize = 64 << 10
+
+	// InspectMaxManifestLines bounds the number of lines of an embedded manifest
+	InspectMaxManifestLines = 1000
+
+	// InspectMaxLeadingLines bounds how many lines before the marker are tried
+	// as manifest start (for manifests that do not begin with manifest_version)
+	InspectMaxLeadingLines = 50
+)
+
+// Changelog constants
+const (
+	// ChangelogDefaultPath is the changelog updated by go-version changelog
+	ChangelogDefaultPath = "CHANGELOG.md"
+
+	// ChangelogHeader starts a new Keep a Changelog file
+	ChangelogHeader = "# Changelog\n\n" +
+		"All notable changes to this project will be documented in this file.\n\n" +
+		"The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),\n" +
+		"and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).\n"
+
+	// ChangelogUnreleased is the name of the Keep a Changelog section for unreleased changes
+	ChangelogUnreleased = "Unreleased"
+
+	// ChangelogDateFormat formats release dates in section headings
+	ChangelogDateFormat = "2006-01-02"
+
+	// Keep a Changelog section names, in the order they are rendered
+	ChangelogAdded      = "Added"
+	ChangelogChanged    = "Changed"
+	ChangelogDeprecated = "Deprecated"
+	ChangelogRemoved    = "Removed"
+	ChangelogFixed      = "Fixed"
+	ChangelogSecurity   = "Security"
+
+	// CommitBreakingChangeFooters mark breaking changes in a commit body
+	CommitBreakingChangeFooter       = "BREAKING CHANGE:"
+	CommitBreakingChangeFooterHyphen = "BREAKING-CHANGE:"
+)
+
+// ldflags generation constants
+const (
+	// Names of the ldflags-injectable variables of this package
+	LdflagsVarGitCommit    = "GitCommit"
+	LdflagsVarGitTag       = "GitTag"
+	LdflagsVarGitTreeState = "GitTreeState"
+	LdflagsVarGitBranch    = "GitBranch"
+	LdflagsVarGitDescribe  = "GitDescribe"
+	LdflagsVarGitRemoteURL = "GitRemoteURL"
+	LdflagsVarBuildTime    = "BuildTime"
+	LdflagsVarBuildUser    = "BuildUser"
+
+	// EnvSourceDateEpoch is the reproducible-builds.org variable that fixes the
+	// build time (seconds since the Unix epoch)
+	EnvSourceDateEpoch = "SOURCE_DATE_EPOCH"
+
+	// EnvUser and EnvUsername name the build user if it cannot be looked up
+	EnvUser     = "USER"
+	EnvUsername = "USERNAME"
 )
 
 // Default values for version information
@@ -18,7 +196,13 @@ const (
 	DefaultGitCommit = "dev"
 
 	// DefaultGitTreeState is used when git status cannot be determined
-	DefaultGitTreeState = "clean"
+	DefaultGitTreeState = GitTreeStateUnknown
+
+	// GitShortCommitLength is the length of GitInfo.ShortCommit (git's default abbreviation)
+	GitShortCommitLength = 7
+
+	// GitRemoteName is the remote whose URL is reported in GitInfo.RemoteURL
+	GitRemoteName = "origin"
 
 	// DefaultBuildTime is used when build time is not injected
 	DefaultBuildTime = "unknown"
@@ -70,6 +254,203 @@ const (
 
 	// HTTPHealthErrorMessage is the error message in health check responses
 	HTTPHealthErrorMessage = "version not available"
+
+	// HTTPPathSBOM is the default path for the SBOM endpoint
+	HTTPPathSBOM = "/sbom"
+
+	// HTTPQueryFormat is the query parameter selecting a response format
+	HTTPQueryFormat = "format"
+
+	// HTTPContentTypeCycloneDX is the content type for CycloneDX JSON SBOMs
+	HTTPContentTypeCycloneDX = "application/vnd.cyclonedx+json; version=" + CycloneDXSpecVersion
+
+	// HTTPContentTypeSPDX is the content type for SPDX JSON SBOMs
+	HTTPContentTypeSPDX = "application/spdx+json"
+
+	// HTTPErrorUnsupportedSBOMFormat is the error message for unknown ?format= values
+	HTTPErrorUnsupportedSBOMFormat = "Unsupported SBOM format (use cyclonedx or spdx)"
+
+	// HTTPErrorSBOMUnavailable is the error message when the SBOM cannot be generated
+	HTTPErrorSBOMUnavailable = "Failed to generate SBOM"
+
+	// HTTPPathMetrics is the default path for the metrics endpoint
+	HTTPPathMetrics = "/metrics"
+
+	// HTTPContentTypePrometheus is the content type of the Prometheus text exposition format
+	HTTPContentTypePrometheus = "text/plain; version=0.0.4; charset=utf-8"
+
+	// HTTPErrorMetricsUnavailable is the error message when metrics cannot be rendered
+	HTTPErrorMetricsUnavailable = "Failed to render metrics"
+
+	// HTTPHeaderAccept is the request header used for content negotiation
+	HTTPHeaderAccept = "Accept"
+
+	// HTTPHeaderVary is the response header listing the request headers a response depends on
+	HTTPHeaderVary = "Vary"
+
+	// HTTPQuerySection is the query parameter selecting a part of the version info
+	HTTPQuerySection = "section"
+
+	// HTTPContentTypeYAML is the content type for YAML responses
+	HTTPContentTypeYAML = "application/yaml"
+
+	// HTTPContentTypeText is the content type for human-readable text responses
+	HTTPContentTypeText = "text/plain; charset=utf-8"
+
+	// HTTPFormatJSON selects JSON output of the version handler (?format=json)
+	HTTPFormatJSON = "json"
+
+	// HTTPFormatYAML selects YAML output of the version handler (?format=yaml)
+	HTTPFormatYAML = "yaml"
+
+	// HTTPFormatText selects the human-readable text layout of the version handler (?format=text)
+	HTTPFormatText = "text"
+
+	// HTTPFormatPrometheus selects Prometheus metrics output of the version handler (?format=prometheus)
+	HTTPFormatPrometheus = "prometheus"
+
+	// HTTPErrorNotAcceptable is the error message when no supported content type is acceptable
+	HTTPErrorNotAcceptable = "Not acceptable (supported: application/json, application/yaml, text/plain, " +
+		"text/plain; version=0.0.4)"
+
+	// HTTPErrorUnsupportedFormat is the error message for unknown ?format= values of the version handler
+	HTTPErrorUnsupportedFormat = "Unsupported format (use json, yaml, text or prometheus)"
+
+	// HTTPErrorUnsupportedSection is the error message for unknown ?section= values
+	HTTPErrorUnsupportedSection = "Unsupported section (use git, build, schemas, apis, components or modules)"
+
+	// HTTPErrorSectionNotSupported is the error message for ?section= with Prometheus output
+	HTTPErrorSectionNotSupported = "Sections are not supported for Prometheus output"
+
+	// HTTPErrorRenderFailed is the error message when the version info cannot be rendered
+	HTTPErrorRenderFailed = "Failed to render version info"
+
+	// HTTPHeaderETag is the response header carrying the entity tag of the version payload
+	HTTPHeaderETag = "ETag"
+
+	// HTTPHeaderLastModified is the response header carrying the load time of the version info
+	HTTPHeaderLastModified = "Last-Modified"
+
+	// HTTPHeaderIfNoneMatch is the request header with entity tags the client already has
+	HTTPHeaderIfNoneMatch = "If-None-Match"
+
+	// HTTPHeaderIfModifiedSince is the request header with the time of the client's copy
+	HTTPHeaderIfModifiedSince = "If-Modified-Since"
+
+	// HTTPETagHashBytes is the number of SHA-256 bytes encoded in ETags
+	HTTPETagHashBytes = 16
+
+	// HTTPHeaderOrigin is the request header naming the origin of cross-origin requests
+	HTTPHeaderOrigin = "Origin"
+
+	// HTTPHeaderCORSAllowOrigin is the CORS response header with the allowed origin
+	HTTPHeaderCORSAllowOrigin = "Access-Control-Allow-Origin"
+
+	// HTTPHeaderCORSAllowMethods is the CORS preflight response header with the allowed methods
+	HTTPHeaderCORSAllowMethods = "Access-Control-Allow-Methods"
+
+	// HTTPHeaderCORSAllowHeaders is the CORS preflight response header with the allowed request headers
+	HTTPHeaderCORSAllowHeaders = "Access-Control-Allow-Headers"
+
+	// HTTPHeaderCORSExposeHeaders is the CORS response header listing headers readable by scripts
+	HTTPHeaderCORSExposeHeaders = "Access-Control-Expose-Headers"
+
+	// HTTPHeaderCORSRequestMethod is the preflight request header with the method of the actual request
+	HTTPHeaderCORSRequestMethod = "Access-Control-Request-Method"
+
+	// HTTPCORSAllowMethods are the methods allowed in CORS preflight responses
+	HTTPCORSAllowMethods = "GET, HEAD, OPTIONS"
+
+	// HTTPCORSAllowHeaders are the request headers allowed in CORS preflight responses
+	HTTPCORSAllowHeaders = "Accept, Authorization, If-None-Match, If-Modified-Since"
+
+	// HTTPHeaderAuthorization is the request header carrying credentials
+	HTTPHeaderAuthorization = "Authorization"
+
+	// HTTPCacheControlPrivate is the cache control header value for the full view served to authorized requests
+	HTTPCacheControlPrivate = "private, no-cache"
+)
+
+// OpenTelemetry constants
+const (
+	// EnvOTelResourceAttributes is the variable OpenTelemetry SDKs read resource attributes from
+	EnvOTelResourceAttributes = "OTEL_RESOURCE_ATTRIBUTES"
+
+	// DefaultResourceAttributePrefix prefixes schema, API and component attributes
+	DefaultResourceAttributePrefix = "app."
+
+	// OTelAttrServiceName is the semantic convention key for the service name
+	OTelAttrServiceName = "service.name"
+
+	// OTelAttrServiceVersion is the semantic convention key for the service version
+	OTelAttrServiceVersion = "service.version"
+
+	// OTelAttrDeploymentEnvironment is the semantic convention key for the deployment environment
+	OTelAttrDeploymentEnvironment = "deployment.environment.name"
+
+	// OTelAttrVCSRevision is the semantic convention key for the git commit
+	OTelAttrVCSRevision = "vcs.repository.ref.revision"
+
+	// OTelAttrVCSRefName is the semantic convention key for the tag or branch name
+	OTelAttrVCSRefName = "vcs.repository.ref.name"
+
+	// OTelAttrVCSRefType is the semantic convention key for the kind of ref ("tag" or "branch")
+	OTelAttrVCSRefType = "vcs.repository.ref.type"
+
+	// OTelAttrVCSRepositoryURL is the semantic convention key for the repository URL
+	OTelAttrVCSRepositoryURL = "vcs.repository.url.full"
+
+	// OTelAttrRuntimeName is the semantic convention key for the runtime name
+	OTelAttrRuntimeName = "process.runtime.name"
+
+	// OTelAttrRuntimeVersion is the semantic convention key for the runtime version
+	OTelAttrRuntimeVersion = "process.runtime.version"
+
+	// OTelRefTypeTag and OTelRefTypeBranch are the values of OTelAttrVCSRefType
+	OTelRefTypeTag    = "tag"
+	OTelRefTypeBranch = "branch"
+
+	// OTelRuntimeNameGo is the value of OTelAttrRuntimeName
+	OTelRuntimeNameGo = "go"
+
+	// OTelAttrSchemaPrefix, OTelAttrAPIPrefix and OTelAttrComponentPrefix follow
+	// the configurable prefix in dimension attribute keys (e.g. "app.schema.postgres_main")
+	OTelAttrSchemaPrefix    = "schema."
+	OTelAttrAPIPrefix       = "api."
+	OTelAttrComponentPrefix = "component."
+)
+
+// Metrics constants
+const (
+	// MetricBuildInfo is the name of the build information gauge
+	MetricBuildInfo = "build_info"
+
+	// MetricSchemaVersionInfo is the name of the per-schema version gauge
+	MetricSchemaVersionInfo = "schema_version_info"
+
+	// MetricAPIVersionInfo is the name of the per-API version gauge
+	MetricAPIVersionInfo = "api_version_info"
+
+	// MetricComponentVersionInfo is the name of the per-component version gauge
+	MetricComponentVersionInfo = "component_version_info"
+
+	// MetricLabelName is the label holding the project or dimension entry name
+	MetricLabelName = "name"
+
+	// MetricLabelVersion is the label holding a version
+	MetricLabelVersion = "version"
+
+	// MetricLabelCommit is the label holding the git commit
+	MetricLabelCommit = "commit"
+
+	// MetricLabelTag is the label holding the git tag
+	MetricLabelTag = "tag"
+
+	// MetricLabelTreeState is the label holding the git tree state
+	MetricLabelTreeState = "tree_state"
+
+	// MetricLabelGoVersion is the label holding the Go version the binary was built with
+	MetricLabelGoVersion = "go_version"
 )
 
 // Git tree states
@@ -79,6 +460,15 @@ const (
 
 	// GitTreeStateDirty indicates uncommitted changes present
 	GitTreeStateDirty = "dirty"
+
+	// GitTreeStateUnknown indicates that no source reported the tree state
+	GitTreeStateUnknown = "unknown"
+)
+
+// Git describe formatting
+const (
+	// GitDescribeFormat formats "<tag>-<distance>-g<short commit>" like `git describe --tags`
+	GitDescribeFormat = "%s-%d-g%s"
 )
 
 // Git command components
@@ -95,6 +485,9 @@ const (
 	// GitCmdStatus is the git status subcommand
 	GitCmdStatus = "status"
 
+	// GitCmdConfig is the git config subcommand
+	GitCmdConfig = "config"
+
 	// GitArgHead is the HEAD argument
 	GitArgHead = "HEAD"
 
@@ -106,6 +499,32 @@ const (
 
 	// GitArgPorcelain is the --porcelain argument
 	GitArgPorcelain = "--porcelain"
+
+	// GitArgAbbrevRef is the --abbrev-ref argument
+	GitArgAbbrevRef = "--abbrev-ref"
+
+	// GitArgGet is the --get argument
+	GitArgGet = "--get"
+
+	// GitArgRemoteURL is the config key of the origin remote URL
+	GitArgRemoteURL = "remote." + GitRemoteName + ".url"
+
+	// GitCmdLog is the git log subcommand
+	GitCmdLog = "log"
+
+	// GitArgAbbrevZero is the --abbrev=0 argument (describe: nearest tag only)
+	GitArgAbbrevZero = "--abbrev=0"
+
+	// GitArgCommitLogFormat prints hash and raw message of each commit, separated
+	// by unit (0x1f) and record (0x1e) separators
+	GitArgCommitLogFormat = "--format=%H%x1f%B%x1e"
+
+	// GitArgEndOfOptions ends option parsing, so the arguments after it (such
+	// as tag names read from the repository) are never taken for options
+	GitArgEndOfOptions = "--end-of-options"
+
+	// GitRevisionRange separates the ends of a revision range ("v1.0.0..HEAD")
+	GitRevisionRange = ".."
 )
 
 // VCS build info keys (from runtime/debug.BuildInfo)
@@ -121,6 +540,126 @@ const (
 
 	// VCSValueTrue is the string value "true" for VCS modified flag
 	VCSValueTrue = "true"
+
+	// VCSValueFalse is the string value "false" for VCS modified flag
+	VCSValueFalse = "false"
+
+	// VCSVersionDevel is the main module version of untagged builds
+	VCSVersionDevel = "(devel)"
+
+	// VCSVersionDirtySuffix is appended to the main module version of modified builds
+	VCSVersionDirtySuffix = "+dirty"
+)
+
+// Build setting keys (from runtime/debug.BuildInfo)
+const (
+	// BuildSettingGOOS is the key for the target operating system
+	BuildSettingGOOS = "GOOS"
+
+	// BuildSettingGOARCH is the key for the target architecture
+	BuildSettingGOARCH = "GOARCH"
+
+	// BuildSettingCGOEnabled is the key for the cgo flag ("0" or "1")
+	BuildSettingCGOEnabled = "CGO_ENABLED"
+
+	// BuildSettingTags is the key for the comma-separated -tags flag
+	BuildSettingTags = "-tags"
+
+	// BuildSettingTrimpath is the key for the -trimpath flag
+	BuildSettingTrimpath = "-trimpath"
+
+	// BuildSettingValueEnabled is the value of CGO_ENABLED when cgo is on
+	BuildSettingValueEnabled = "1"
+)
+
+// SBOM constants
+const (
+	// SBOMToolName is the tool name recorded in generated SBOMs
+	SBOMToolName = "go-version"
+
+	// CycloneDXBOMFormat is the bomFormat value of CycloneDX documents
+	CycloneDXBOMFormat = "CycloneDX"
+
+	// CycloneDXSpecVersion is the CycloneDX specification version produced
+	CycloneDXSpecVersion = "1.5"
+
+	// CycloneDXTypeApplication is the component type of the described binary
+	CycloneDXTypeApplication = "application"
+
+	// CycloneDXTypeLibrary is the component type of modules and manifest components
+	CycloneDXTypeLibrary = "library"
+
+	// CycloneDXHashSHA256 is the CycloneDX name of the SHA-256 algorithm
+	CycloneDXHashSHA256 = "SHA-256"
+
+	// CycloneDXReferenceVCS is the external reference type of the git remote
+	CycloneDXReferenceVCS = "vcs"
+
+	// SPDXVersion is the SPDX specification version produced
+	SPDXVersion = "SPDX-2.3"
+
+	// SPDXDataLicense is the license of SPDX document metadata (mandated by the spec)
+	SPDXDataLicense = "CC0-1.0"
+
+	// SPDXDocumentID is the SPDX identifier of the document itself
+	SPDXDocumentID = "SPDXRef-DOCUMENT"
+
+	// SPDXNoAssertion is the SPDX value for unknown fields
+	SPDXNoAssertion = "NOASSERTION"
+
+	// SPDXNamespaceBase prefixes the generated document namespace
+	SPDXNamespaceBase = "https://spdx.org/spdxdocs/"
+
+	// SPDXChecksumSHA256 is the SPDX name of the SHA-256 algorithm
+	SPDXChecksumSHA256 = "SHA256"
+
+	// SPDXIDPrefixPackage prefixes the SPDX identifier of the described package
+	SPDXIDPrefixPackage = "SPDXRef-Package-"
+
+	// SPDXIDPrefixModule prefixes the SPDX identifiers of Go modules
+	SPDXIDPrefixModule = "SPDXRef-Module-"
+
+	// SPDXIDPrefixComponent prefixes the SPDX identifiers of manifest components
+	SPDXIDPrefixComponent = "SPDXRef-Component-"
+
+	// SPDXCreatorToolPrefix prefixes the tool name in creationInfo.creators
+	SPDXCreatorToolPrefix = "Tool: "
+
+	// SPDXRefCategoryPackageManager is the external reference category of package URLs
+	SPDXRefCategoryPackageManager = "PACKAGE-MANAGER"
+
+	// SPDXRefTypePURL is the external reference type of package URLs
+	SPDXRefTypePURL = "purl"
+
+	// SPDXDownloadLocationGitPrefix prefixes git remote URLs used as download location
+	SPDXDownloadLocationGitPrefix = "git+"
+
+	// SPDXCommentReplacedBy prefixes the comment of modules replaced by a local directory
+	SPDXCommentReplacedBy = "replaced by local directory "
+
+	// SPDXRelationshipDescribes relates the document to the described package
+	SPDXRelationshipDescribes = "DESCRIBES"
+
+	// SPDXRelationshipDependsOn relates the described package to its dependencies
+	SPDXRelationshipDependsOn = "DEPENDS_ON"
+
+	// SBOMSerialPrefix prefixes the CycloneDX serial number
+	SBOMSerialPrefix = "urn:uuid:"
+
+	// PURLPrefixGolang is the package URL prefix of Go modules
+	PURLPrefixGolang = "pkg:golang/"
+
+	// PURLPrefixGeneric is the package URL prefix of manifest components
+	PURLPrefixGeneric = "pkg:generic/"
+
+	// SBOMPropertyGitCommit is the property name of the git commit
+	SBOMPropertyGitCommit = "go-version:git:commit"
+
+	// SBOMPropertyReplacedBy is the property name of a local replace directive target
+	SBOMPropertyReplacedBy = "go-version:replaced_by"
+
+	// GoSumHashPrefix prefixes go.sum module hashes (base64 SHA-256)
+	GoSumHashPrefix = "h1:"
 )
 
 // Error messages
@@ -152,6 +691,39 @@ const (
 	// ErrMsgParseYAML is returned when YAML parsing fails
 	ErrMsgParseYAML = "failed to parse YAML"
 
+	// ErrMsgParseJSON is returned when JSON parsing fails
+	ErrMsgParseJSON = "failed to parse JSON"
+
+	// ErrMsgParseTOML is returned when TOML parsing fails
+	ErrMsgParseTOML = "failed to parse TOML"
+
+	// ErrMsgEncodeSBOM is returned when an SBOM cannot be encoded
+	ErrMsgEncodeSBOM = "failed to encode SBOM"
+
+	// ErrMsgBumpManifest is returned when a manifest version cannot be bumped
+	ErrMsgBumpManifest = "failed to bump manifest version"
+
+	// ErrMsgInspectBinary is returned when a binary cannot be inspected
+	ErrMsgInspectBinary = "failed to inspect binary"
+
+	// ErrMsgInspectAmbiguousManifest is returned when a binary embeds several different manifests
+	ErrMsgInspectAmbiguousManifest = "binary embeds several different manifests"
+
+	// ErrMsgReadCommits is returned when the commits since the last tag cannot be read
+	ErrMsgReadCommits = "failed to read commits"
+
+	// ErrMsgGitNotAvailable is returned when the git binary is not installed in a trusted location
+	ErrMsgGitNotAvailable = "git binary not available"
+
+	// ErrMsgNoCommits is returned when there are no commits since the last tag
+	ErrMsgNoCommits = "no commits since the last tag"
+
+	// ErrMsgUpdateChangelog is returned when a changelog cannot be updated
+	ErrMsgUpdateChangelog = "failed to update changelog"
+
+	// ErrMsgSourceDateEpoch is returned when SOURCE_DATE_EPOCH is not a Unix timestamp
+	ErrMsgSourceDateEpoch = "invalid " + EnvSourceDateEpoch
+
 	// ErrMsgProjectNameRequired is returned when project name is missing from manifest
 	ErrMsgProjectNameRequired = "project name is required in manifest"
 
@@ -189,6 +761,60 @@ const (
 	// ErrHintParseYAML provides guidance for YAML parsing errors
 	ErrHintParseYAML = "Check YAML syntax at https://www.yamllint.com/ or validate with: yamllint versions.yaml"
 
+	// ErrHintParseManifest provides guidance for manifest parsing errors in any format
+	ErrHintParseManifest = "Check the manifest syntax at the reported line and column. Validate with:\n" +
+		"  YAML: yamllint versions.yaml\n" +
+		"  JSON: jq . versions.json\n" +
+		"  TOML: taplo check versions.toml"
+
+	// ErrHintInvalidEnvironment provides guidance for invalid environment names
+	ErrHintInvalidEnvironment = "Environment names may only contain letters, digits, '_' and '-' (e.g. \"staging\")"
+
+	// ErrHintSBOMFormat provides guidance for unsupported SBOM formats
+	ErrHintSBOMFormat = "Use one of the supported formats: version.SBOMFormatCycloneDX (\"cyclonedx\") " +
+		"or version.SBOMFormatSPDX (\"spdx\")"
+
+	// ErrHintPrivateField provides guidance for invalid private field paths
+	ErrHintPrivateField = "Private fields are JSON paths: a part (custom, components, modules, ...), " +
+		"a git or build field (build.user, git.remote_url) or an entry (custom.owner, components.billing-internal)"
+
+	// ErrHintSection provides guidance for unsupported info sections
+	ErrHintSection = "Use one of the supported sections: git, build, schemas, apis, components or modules"
+
+	// ErrHintGitSource provides guidance for unsupported git sources
+	ErrHintGitSource = "Use version.GitSourceAuto (default), version.GitSourceDirectory (\"directory\") " +
+		"or version.GitSourceCommand (\"command\")"
+
+	// ErrHintBumpDimension provides guidance for invalid bump dimensions
+	ErrHintBumpDimension = "Use \"project\" or <section>.<name> with section schemas, apis or components " +
+		"(e.g. \"apis.rest_v1\"); the entry must already exist in the manifest"
+
+	// ErrHintBumpFormat provides guidance when bumping a non-YAML manifest
+	ErrHintBumpFormat = "Bumping preserves comments and key order and is only supported for YAML manifests " +
+		"(versions.yaml, versions.yml); edit JSON and TOML manifests by hand"
+
+	// ErrHintInspectBinary provides guidance when a binary cannot be inspected
+	ErrHintInspectBinary = "Inspect only works on Go executables (ELF, Mach-O or PE) built with module support. " +
+		"Check the path and verify with: go version -m <binary>"
+
+	// ErrHintInspectAmbiguousManifest provides guidance when a binary embeds several manifests
+	ErrHintInspectAmbiguousManifest = "Inspect cannot tell which manifest belongs to the program; " +
+		"embed a single versions.yaml per binary"
+
+	// ErrHintReadCommits provides guidance when commits cannot be read
+	ErrHintReadCommits = "Run inside a git repository with at least one commit; the git binary must be installed"
+
+	// ErrHintNoCommits provides guidance when HEAD is already tagged
+	ErrHintNoCommits = "HEAD is already tagged; commit changes before preparing the next release"
+
+	// ErrHintSourceDateEpoch provides guidance for invalid SOURCE_DATE_EPOCH values
+	ErrHintSourceDateEpoch = EnvSourceDateEpoch + " must be the build time in seconds since the Unix epoch, " +
+		"e.g. SOURCE_DATE_EPOCH=$(git log -1 --format=%ct)"
+
+	// ErrHintManifestFormat provides guidance for unsupported manifest formats
+	ErrHintManifestFormat = "Use one of the supported formats: version.ManifestFormatYAML, " +
+		"version.ManifestFormatJSON or version.ManifestFormatTOML"
+
 	// ErrHintInitializeMultiple provides guidance for multiple initialization
 	ErrHintInitializeMultiple = "The singleton can only be initialized once. Options:\n" +
 		"  1. Call Initialize() at application startup before any Get()/MustGet() calls\n" +
@@ -213,6 +839,9 @@ const (
 
 	// ErrHintVersionTooOld provides guidance when version doesn't meet requirements
 	ErrHintVersionTooOld = "Update the version in your manifest to meet the minimum requirement"
+
+	// ErrHintConstraintUnsatisfied provides guidance when version doesn't satisfy a constraint
+	ErrHintConstraintUnsatisfied = "Update the version in your manifest or relax the constraint in the validator"
 )
 
 // Validation error message formats
@@ -246,6 +875,72 @@ const (
 
 	// ErrFmtComponentTooOld is the format string for component version too old errors
 	ErrFmtComponentTooOld = "component '%s' version %s is less than required minimum %s"
+
+	// ErrFmtInvalidConstraint is the format string for invalid constraint errors
+	ErrFmtInvalidConstraint = "invalid version constraint '%s' for validator: %w"
+
+	// ErrFmtConstraintUnsatisfied is the format string for Constraint.Validate errors
+	ErrFmtConstraintUnsatisfied = "version %s does not satisfy constraint '%s': %s"
+
+	// ErrFmtSchemaConstraintUnsatisfied is the format string for unsatisfied schema constraint errors
+	ErrFmtSchemaConstraintUnsatisfied = "schema '%s' version %s does not satisfy constraint '%s' (%s)"
+
+	// ErrFmtAPIConstraintUnsatisfied is the format string for unsatisfied API constraint errors
+	ErrFmtAPIConstraintUnsatisfied = "API '%s' version %s does not satisfy constraint '%s' (%s)"
+
+	// ErrFmtComponentConstraintUnsatisfied is the format string for unsatisfied component constraint errors
+	ErrFmtComponentConstraintUnsatisfied = "component '%s' version %s does not satisfy constraint '%s' (%s)"
+)
+
+// Manifest error format strings
+const (
+	// ErrFmtUnsupportedManifestFormat is the format string for unsupported manifest format errors
+	ErrFmtUnsupportedManifestFormat = "unsupported manifest format '%s'"
+
+	// ErrFmtInvalidEnvironment is the format string for invalid environment name errors
+	ErrFmtInvalidEnvironment = "invalid environment name '%s'"
+
+	// ErrFmtLoadEnvironmentManifest is the format string for environment overlay file errors
+	ErrFmtLoadEnvironmentManifest = "failed to load environment manifest %s"
+
+	// ErrFmtUnsupportedSBOMFormat is the format string for unsupported SBOM format errors
+	ErrFmtUnsupportedSBOMFormat = "unsupported SBOM format '%s'"
+
+	// ErrFmtInvalidPrivateField is the format string for invalid private field paths in manifests
+	ErrFmtInvalidPrivateField = "invalid private field '%s'"
+
+	// ErrFmtUnsupportedSection is the format string for unsupported info section errors
+	ErrFmtUnsupportedSection = "unsupported section '%s'"
+
+	// ErrFmtUnsupportedGitSource is the format string for unsupported git source errors
+	ErrFmtUnsupportedGitSource = "unsupported git source '%s'"
+
+	// ErrFmtUnsupportedBumpPart is the format string for unknown bump parts
+	ErrFmtUnsupportedBumpPart = "unsupported bump part '%s' (use major, minor, patch or prerelease)"
+
+	// ErrFmtInvalidPrereleaseID is the format string for invalid prerelease identifiers
+	ErrFmtInvalidPrereleaseID = "invalid prerelease identifier '%s': %w"
+
+	// ErrFmtBumpBackwards is the format string for bumps that would lower the version
+	ErrFmtBumpBackwards = "bumping %s to %s would go backwards"
+
+	// ErrFmtInvalidBumpDimension is the format string for unknown bump dimensions
+	ErrFmtInvalidBumpDimension = "invalid bump dimension '%s'"
+
+	// ErrFmtBumpDimensionNotFound is the format string for bump dimensions missing from the manifest
+	ErrFmtBumpDimensionNotFound = "'%s' not found in manifest %s"
+
+	// ErrFmtBumpInvalidVersion is the format string for manifest values that are not versions
+	ErrFmtBumpInvalidVersion = "'%s' value '%s' is not a version: %w"
+
+	// ErrFmtBumpUnsupportedFormat is the format string for bumping non-YAML manifests
+	ErrFmtBumpUnsupportedFormat = "cannot bump %s manifest %s"
+
+	// ErrFmtManifestPosition is the format string for locating manifest parse errors
+	ErrFmtManifestPosition = "line %d, column %d: %w"
+
+	// ErrFmtChangelogReleaseExists is the format string for releases already in the changelog
+	ErrFmtChangelogReleaseExists = "%s already has a section for %s"
 )
 
 // Error wrapping format strings
@@ -260,7 +955,7 @@ const (
 	ErrFmtMustGetPanic = "version.MustGet: %v"
 )
 
-// Logging field names for structured logging (zap, logrus, etc.)
+// Logging field names for flat structured logging (zap, logr, logrus, etc.)
 const (
 	// LogFieldProjectName is the field name for project name
 	LogFieldProjectName = "project_name"
@@ -277,6 +972,18 @@ const (
 	// LogFieldGitTreeState is the field name for git tree state
 	LogFieldGitTreeState = "git_tree_state"
 
+	// LogFieldGitShortCommit is the field name for the abbreviated git commit
+	LogFieldGitShortCommit = "git_short_commit"
+
+	// LogFieldGitBranch is the field name for git branch
+	LogFieldGitBranch = "git_branch"
+
+	// LogFieldGitDescribe is the field name for git describe output
+	LogFieldGitDescribe = "git_describe"
+
+	// LogFieldGitRemoteURL is the field name for git remote URL
+	LogFieldGitRemoteURL = "git_remote_url"
+
 	// LogFieldBuildTime is the field name for build time
 	LogFieldBuildTime = "build_time"
 
@@ -287,6 +994,36 @@ const (
 	LogFieldGoVersion = "go_version"
 )
 
+// slog attribute keys. Project, schema, API and component groups are named
+// after their dimension (DimensionProject, DimensionSchemas, ...).
+const (
+	// SlogGroupVersion is the group SlogHandler adds to every record
+	SlogGroupVersion = "version"
+
+	// SlogGroupGit is the group holding git attributes
+	SlogGroupGit = "git"
+
+	// SlogGroupBuild is the group holding build attributes
+	SlogGroupBuild = "build"
+
+	// SlogKeyEnvironment is the key of the applied environment overlay
+	SlogKeyEnvironment = "environment"
+
+	// Keys within the project, git and build groups, matching the JSON output
+	SlogKeyName        = "name"
+	SlogKeyVersion     = "version"
+	SlogKeyCommit      = "commit"
+	SlogKeyShortCommit = "short_commit"
+	SlogKeyTag         = "tag"
+	SlogKeyBranch      = "branch"
+	SlogKeyDescribe    = "describe"
+	SlogKeyRemoteURL   = "remote_url"
+	SlogKeyTreeState   = "tree_state"
+	SlogKeyTime        = "time"
+	SlogKeyUser        = "user"
+	SlogKeyGoVersion   = "go_version"
+)
+
 // String formatting
 const (
 	// StringFormatSeparator is the separator used in String() method
@@ -0,0 +1,113 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itsatony/go-version/internal/semver"
+)
+
+// Constraint represents a version range such as "^1.15", "~1.4.0" or ">=2.0 <3.0".
+//
+// Supported syntax:
+//   - Comparison: "=1.2.3", ">1.2", ">=1.2.3", "<2", "<=1.4", "!=1.3.0"
+//   - Caret: "^1.2.3" allows changes that keep the left-most non-zero component (>=1.2.3 <2.0.0)
+//   - Tilde: "~1.4.0" or "~>1.4.0" allows patch-level changes (>=1.4.0 <1.5.0)
+//   - Wildcards: "1.x", "1.2.*", "*" and bare partial versions ("45" matches 45.x.x)
+//   - AND: comparators separated by whitespace or commas (">=2.0 <3.0", ">=2.0, <3.0")
+//   - OR: clauses separated by "||" ("^1.15 || ^2.0")
+//
+// Missing components are treated as wildcards, so ">1.2" means ">=1.3.0",
+// "<=1.2" means "<1.3.0" and "!=1.2" excludes every 1.2.x version. Prerelease
+// versions are compared by normal SemVer precedence.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	c, err := version.ParseConstraint(">=45 <60")
+//	if err != nil {
+//	    return err
+//	}
+//	if c.Check(version.MustParseSemVer("47")) {
+//	    fmt.Println("schema is supported")
+//	}
+type Constraint struct {
+	internal *semver.Constraint
+}
+
+// ParseConstraint parses a version constraint expression.
+//
+// Returns an error if the expression is empty, contains an invalid version,
+// or contains a comparator that can never be satisfied (e.g. "<*").
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	c, err := version.ParseConstraint("^1.15 || ^2.0")
+//	if err != nil {
+//	    return err
+//	}
+func ParseConstraint(s string) (*Constraint, error) {
+	internal, err := semver.ParseConstraint(s)
+	if err != nil {
+		return nil, err
+	}
+	return &Constraint{internal: internal}, nil
+}
+
+// MustParseConstraint parses a version constraint expression or panics on error.
+//
+// Use this for constraints defined as constants in code.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	var supportedAPI = version.MustParseConstraint("^1.15")
+func MustParseConstraint(s string) *Constraint {
+	c, err := ParseConstraint(s)
+	if err != nil {
+		panic(fmt.Sprintf("version.MustParseConstraint: %v", err))
+	}
+	return c
+}
+
+// Check returns true if v satisfies the constraint.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	c := version.MustParseConstraint("~1.4.0")
+//	c.Check(version.MustParseSemVer("1.4.7")) // true
+//	c.Check(version.MustParseSemVer("1.5.0")) // false
+func (c *Constraint) Check(v *SemVer) bool {
+	return c.internal.Check(v.internal)
+}
+
+// Validate returns nil if v satisfies the constraint, or an error naming the
+// clause (and the comparator within it) that failed.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	c := version.MustParseConstraint("^1.15")
+//	err := c.Validate(version.MustParseSemVer("1.14.0"))
+//	// err: version 1.14.0 does not satisfy constraint '^1.15': ^1.15 requires >=1.15.0
+func (c *Constraint) Validate(v *SemVer) error {
+	reasons := c.internal.Explain(v.internal)
+	if len(reasons) == 0 {
+		return nil
+	}
+	return fmt.Errorf(ErrFmtConstraintUnsatisfied, v, c, strings.Join(reasons, "; "))
+}
+
+// String returns the normalized constraint expression.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+func (c *Constraint) String() string {
+	return c.internal.String()
+}
@@ -0,0 +1,64 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConstraint(t *testing.T) {
+	tests := map[string]struct {
+		constraint  string
+		version     string
+		expected    bool
+		expectError bool
+	}{
+		"caret_match":      {constraint: "^1.15", version: "1.20.0", expected: true},
+		"caret_no_match":   {constraint: "^1.15", version: "2.0.0", expected: false},
+		"range_match":      {constraint: ">=45 <60", version: "47", expected: true},
+		"or_match":         {constraint: "^1 || ^3", version: "3.1.0", expected: true},
+		"ne_partial_minor": {constraint: "!=1.2", version: "1.2.5", expected: false},
+		"ne_partial_next":  {constraint: "!=1.2", version: "1.3.0", expected: true},
+		"ne_partial_exact": {constraint: "!=1.2", version: "1.2.0", expected: false},
+		"invalid":          {constraint: ">=abc", expectError: true},
+		"empty":            {constraint: "", expectError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, err := ParseConstraint(tc.constraint)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, c)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, c.Check(MustParseSemVer(tc.version)))
+		})
+	}
+}
+
+func TestMustParseConstraint(t *testing.T) {
+	assert.NotPanics(t, func() {
+		MustParseConstraint("~1.4.0")
+	})
+	assert.Panics(t, func() {
+		MustParseConstraint("~")
+	})
+}
+
+func TestConstraint_Validate(t *testing.T) {
+	c := MustParseConstraint("^1.15")
+
+	assert.NoError(t, c.Validate(MustParseSemVer("1.15.3")))
+
+	err := c.Validate(MustParseSemVer("1.14.0"))
+	require.Error(t, err)
+	assert.Equal(t, "version 1.14.0 does not satisfy constraint '^1.15': ^1.15 requires >=1.15.0", err.Error())
+}
+
+func TestConstraint_String(t *testing.T) {
+	assert.Equal(t, ">=2.0 <3.0", MustParseConstraint(">=2.0   <3.0").String())
+}
@@ -29,6 +29,12 @@
 // All functions and methods in this package are safe for concurrent use by
 // multiple goroutines. The Info struct is immutable after creation.
 //
+// # Hot Reload
+//
+// With WithReload, the singleton is replaced atomically when the manifest file
+// changes. Callers holding an *Info keep a consistent snapshot; Subscribe is
+// notified of every reload attempt, including failed ones.
+//
 // # Version Sources
 //
 // Version information is loaded from multiple sources with this precedence:
@@ -50,19 +56,22 @@ import (
 )
 
 var (
-	// instance holds the singleton Info instance using atomic for fast reads
-	instance atomic.Value // stores *Info
-
-	// initOnce ensures initialization happens exactly once
-	initOnce sync.Once
-
-	// initError stores any error from initialization
-	initError error
+	// defaultRegistryPtr holds the Registry behind the package-level functions
+	defaultRegistryPtr atomic.Pointer[Registry]
 
 	// resetMu protects Reset() operations from concurrent access
 	resetMu sync.Mutex
 )
 
+func init() {
+	defaultRegistryPtr.Store(&Registry{})
+}
+
+// defaultRegistry returns the Registry used by the package-level functions.
+func defaultRegistry() *Registry {
+	return defaultRegistryPtr.Load()
+}
+
 // Initialize configures and initializes the version singleton.
 //
 // ⚠️  SINGLETON BEHAVIOR: This function can only be called ONCE per process.
@@ -79,6 +88,10 @@ var (
 //   - Call it BEFORE any Get()/MustGet() calls to ensure your config is used
 //   - In tests, use version.Reset() (test-only) to allow re-initialization
 //
+// When to use NewRegistry():
+//   - If several applications share one process and each needs its own
+//     handlers, middleware and reloads
+//
 // When to use New():
 //   - If you need multiple version info instances with different configs
 //   - When building libraries that should avoid global state
@@ -95,6 +108,7 @@ var (
 //	        version.WithValidators(
 //	            version.NewSchemaValidator("postgres_main", "45"),
 //	        ),
+//	        version.WithReload(30*time.Second), // optional hot reload
 //	    )
 //	    if err != nil {
 //	        log.Fatal("Failed to initialize version:", err)
@@ -105,23 +119,14 @@ var (
 //	    log.Printf("Starting %s v%s", info.Project.Name, info.Project.Version)
 //	}
 func Initialize(opts ...Option) error {
-	didRun := false
-
-	initOnce.Do(func() {
-		didRun = true
-		var info *Info
-		info, initError = loadVersionInfo(opts...)
-		if initError == nil {
-			instance.Store(info)
-		}
-	})
+	didRun, err := defaultRegistry().initialize(opts...)
 
 	// If Do() didn't run, it means Initialize was already called
 	if !didRun {
 		return fmt.Errorf("%s\nHint: %s", ErrMsgInitializeMultiple, ErrHintInitializeMultiple)
 	}
 
-	return initError
+	return err
 }
 
 // Get returns the singleton version info instance.
@@ -145,41 +150,7 @@ func Initialize(opts ...Option) error {
 //
 //	log.Printf("Running version %s", info.Project.Version)
 func Get() (*Info, error) {
-	// Fast path: already initialized
-	if v := instance.Load(); v != nil {
-		if info, ok := v.(*Info); ok && info != nil {
-			return info, nil
-		}
-	}
-
-	// Slow path: auto-initialize with defaults
-	initOnce.Do(func() {
-		var info *Info
-		info, initError = loadVersionInfo(
-			WithGitInfo(),
-			WithBuildInfo(),
-		)
-		if initError == nil {
-			instance.Store(info)
-		}
-	})
-
-	if initError != nil {
-		return nil, initError
-	}
-
-	v := instance.Load()
-	if v == nil {
-		// This should never happen, but handle it gracefully
-		return nil, ErrNotInitialized
-	}
-
-	info, ok := v.(*Info)
-	if !ok || info == nil {
-		return nil, ErrNotInitialized
-	}
-
-	return info, nil
+	return defaultRegistry().Get()
 }
 
 // MustGet returns the singleton version info instance or panics if unavailable.
@@ -195,16 +166,11 @@ func Get() (*Info, error) {
 //
 //	func main() {
 //	    v := version.MustGet()
-//	    logger := zap.NewProduction()
-//	    logger = logger.With(v.LogFields()...)
+//	    logger := slog.Default().With("version", v)
 //	    logger.Info("Application started")
 //	}
 func MustGet() *Info {
-	info, err := Get()
-	if err != nil {
-		panic(fmt.Sprintf(ErrFmtMustGetPanic, err))
-	}
-	return info
+	return defaultRegistry().MustGet()
 }
 
 // New creates a new Info instance without using the singleton.
@@ -253,12 +219,7 @@ func New(opts ...Option) (*Info, error) {
 //	    log.Println("Version information not available")
 //	}
 func IsInitialized() bool {
-	v := instance.Load()
-	if v == nil {
-		return false
-	}
-	info, ok := v.(*Info)
-	return ok && info != nil
+	return defaultRegistry().IsInitialized()
 }
 
 // Reset clears the singleton instance. USE WITH EXTREME CAUTION.
@@ -274,10 +235,12 @@ func IsInitialized() bool {
 //   - Only call during test setup/teardown with no concurrent goroutines
 //
 // Thread Safety Limitations:
-//   - sync.Once cannot be atomically reset, creating race conditions
-//   - Other goroutines may observe partial reset state
+//   - The default Registry is swapped atomically, but handlers and goroutines
+//     that already hold the old Info keep using it
 //   - Only safe when called with exclusive access (no concurrent version usage)
 //
+// Prefer NewRegistry() in new tests: a private Registry needs no Reset().
+//
 // Best Practices:
 //   - Call Reset() at the start or end of each test that needs it
 //   - Never call from production code (it will panic)
@@ -307,13 +270,7 @@ func Reset() {
 	resetMu.Lock()
 	defer resetMu.Unlock()
 
-	// Clear the singleton instance
-	var nilInfo *Info
-	instance.Store(nilInfo)
-
-	// Reset initialization state
-	// Note: sync.Once cannot be truly reset atomically, but this is sufficient
-	// for single-threaded test scenarios where Reset is intended to be used
-	initOnce = sync.Once{}
-	initError = nil
+	// Stop the old registry's reload watcher and start from a fresh registry
+	defaultRegistry().StopReload()
+	defaultRegistryPtr.Store(&Registry{})
 }
@@ -0,0 +1,188 @@
+package version
+
+import (
+	"sort"
+)
+
+// ChangeKind classifies a change reported by Diff.
+type ChangeKind string
+
+// Supported change kinds
+const (
+	// ChangeAdded is a value that only exists in the new Info
+	ChangeAdded ChangeKind = "added"
+
+	// ChangeRemoved is a value that only exists in the old Info
+	ChangeRemoved ChangeKind = "removed"
+
+	// ChangeUpgraded is a value with a higher version in the new Info
+	ChangeUpgraded ChangeKind = "upgraded"
+
+	// ChangeDowngraded is a value with a lower version in the new Info
+	ChangeDowngraded ChangeKind = "downgraded"
+
+	// ChangeModified is a value that differs without a version ordering, e.g.
+	// a project name, a non-SemVer value or a change in build metadata only
+	ChangeModified ChangeKind = "changed"
+)
+
+// Change is a single difference between two Infos.
+type Change struct {
+	// Dimension is the section of the value: project, schemas, apis, components or modules
+	Dimension string `json:"dimension"`
+
+	// Name is the key within the dimension ("name" or "version" for project, the module path for modules)
+	Name string `json:"name"`
+
+	// Kind classifies the change
+	Kind ChangeKind `json:"kind"`
+
+	// Old is the value in the old Info (empty if added)
+	Old string `json:"old,omitempty"`
+
+	// New is the value in the new Info (empty if removed)
+	New string `json:"new,omitempty"`
+
+	// Bump is the most significant version part that differs for upgrades
+	// and downgrades (e.g. BumpMinor for 1.4.2 -> 1.5.0)
+	Bump BumpPart `json:"bump,omitempty"`
+}
+
+// Changes is the result of Diff, ordered by dimension (project, schemas,
+// apis, components, modules) and name.
+type Changes []Change
+
+// HasDowngrades reports whether any value was downgraded.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	if version.Diff(deployed, candidate).HasDowngrades() {
+//	    log.Fatal("release would downgrade a version")
+//	}
+func (c Changes) HasDowngrades() bool {
+	for _, change := range c {
+		if change.Kind == ChangeDowngraded {
+			return true
+		}
+	}
+	return false
+}
+
+// diffDimensions is the order of dimensions in Changes
+var diffDimensions = []string{DimensionProject, DimensionSchemas, DimensionAPIs, DimensionComponents, DimensionModules}
+
+// Diff compares the project, schema, API, component and module versions of
+// two Infos, e.g. two manifests or two /version payloads during release
+// review. Versions are compared as SemVer (short forms such as "45" or
+// "v1.15" included); values that do not parse are reported as changed.
+// Module versions are those of GetModuleVersion, i.e. of the replacement for
+// replaced modules; modules are only compared if both Infos report some, as
+// manifests carry none. Git, build and custom values are not compared.
+//
+// A nil Info is treated as empty, so Diff(nil, info) lists everything as added.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	for _, c := range version.Diff(oldInfo, newInfo) {
+//	    fmt.Printf("%s.%s: %s %s -> %s (%s)\n", c.Dimension, c.Name, c.Kind, c.Old, c.New, c.Bump)
+//	}
+func Diff(a, b *Info) Changes {
+	var changes Changes
+	for _, dimension := range diffDimensions {
+		before, after := diffValues(a, dimension), diffValues(b, dimension)
+		if dimension == DimensionModules && (len(before) == 0 || len(after) == 0) {
+			continue
+		}
+
+		names := make([]string, 0, len(before)+len(after))
+		for name := range before {
+			names = append(names, name)
+		}
+		for name := range after {
+			if _, ok := before[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			oldValue, inOld := before[name]
+			newValue, inNew := after[name]
+			change := Change{Dimension: dimension, Name: name, Old: oldValue, New: newValue}
+			switch {
+			case !inOld:
+				change.Kind = ChangeAdded
+			case !inNew:
+				change.Kind = ChangeRemoved
+			case oldValue == newValue:
+				continue
+			case dimension == DimensionProject && name == DiffProjectName:
+				change.Kind = ChangeModified
+			default:
+				change.Kind, change.Bump = compareVersions(oldValue, newValue)
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// diffValues returns the values of one dimension of info keyed by name.
+func diffValues(info *Info, dimension string) map[string]string {
+	if info == nil {
+		return nil
+	}
+	switch dimension {
+	case DimensionProject:
+		return map[string]string{DiffProjectName: info.Project.Name, DiffProjectVersion: info.Project.Version}
+	case DimensionSchemas:
+		return info.GetSchemas()
+	case DimensionAPIs:
+		return info.GetAPIs()
+	case DimensionComponents:
+		return info.GetComponents()
+	case DimensionModules:
+		values := make(map[string]string, len(info.modules))
+		for _, mod := range info.modules {
+			values[mod.Path], _ = info.GetModuleVersion(mod.Path)
+		}
+		return values
+	}
+	return nil
+}
+
+// compareVersions classifies a version change and the most significant
+// part that differs.
+func compareVersions(oldValue, newValue string) (ChangeKind, BumpPart) {
+	a, errA := ParseSemVer(oldValue)
+	b, errB := ParseSemVer(newValue)
+	if errA != nil || errB != nil {
+		return ChangeModified, ""
+	}
+
+	var part BumpPart
+	switch {
+	case a.Major() != b.Major():
+		part = BumpMajor
+	case a.Minor() != b.Minor():
+		part = BumpMinor
+	case a.Patch() != b.Patch():
+		part = BumpPatch
+	case a.Prerelease() != b.Prerelease():
+		part = BumpPrerelease
+	}
+
+	switch cmp := a.Compare(b); {
+	case cmp < 0:
+		return ChangeUpgraded, part
+	case cmp > 0:
+		return ChangeDowngraded, part
+	default:
+		// Same precedence, e.g. "v1.15" and "1.15.0" or differing build metadata
+		return ChangeModified, ""
+	}
+}
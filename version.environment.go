@@ -0,0 +1,177 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestOverlay contains environment-specific values that are deep-merged
+// over the base manifest.
+//
+// Overlays are declared inline under the manifest's "environments" key or in
+// a sibling file next to the manifest (versions.<env>.yaml, versions.<env>.json,
+// versions.<env>.toml). Empty fields leave the base value untouched; map entries
+// are merged key by key, and nested custom maps are merged recursively.
+//
+// Example:
+//
+//	project:
+//	  name: "my-app"
+//	  version: "1.2.3"
+//	apis:
+//	  rest_v1: "1.15.0"
+//	environments:
+//	  staging:
+//	    apis:
+//	      rest_v1: "1.16.0-rc.1"
+//	    custom:
+//	      region: "eu-staging"
+type ManifestOverlay struct {
+	// Project overrides the project name and/or version
+	Project ProjectManifest `yaml:"project,omitempty" json:"project,omitempty" toml:"project,omitempty"`
+
+	// Schemas overrides or adds database schema versions
+	Schemas map[string]string `yaml:"schemas,omitempty" json:"schemas,omitempty" toml:"schemas,omitempty"`
+
+	// APIs overrides or adds API versions
+	APIs map[string]string `yaml:"apis,omitempty" json:"apis,omitempty" toml:"apis,omitempty"`
+
+	// Components overrides or adds component versions
+	Components map[string]string `yaml:"components,omitempty" json:"components,omitempty" toml:"components,omitempty"`
+
+	// Custom overrides or adds custom dimensions (nested maps are merged recursively)
+	Custom map[string]interface{} `yaml:"custom,omitempty" json:"custom,omitempty" toml:"custom,omitempty"`
+}
+
+// resolveEnvironment returns the environment selected via WithEnvironment,
+// falling back to the GOVERSION_ENV environment variable.
+func (o *LoadOptions) resolveEnvironment() string {
+	if o.environment != "" {
+		return o.environment
+	}
+	return strings.TrimSpace(os.Getenv(EnvVarEnvironment))
+}
+
+// applyEnvironment merges the overlay for env into manifest.
+//
+// The inline "environments" block is applied first, then the sibling file
+// next to manifestPath (if any), whose format is detected from its extension
+// or content. A missing overlay is not an error: the base manifest is used
+// as-is and no environment is recorded.
+//
+// SECURITY: The environment name is restricted to [A-Za-z0-9_-] because it is
+// used to build the sibling file path.
+func applyEnvironment(manifest *Manifest, env, manifestPath string) error {
+	if env == "" {
+		return nil
+	}
+
+	if !isValidEnvironmentName(env) {
+		return newCategoryErrorWithHint(CategoryManifest, fmt.Sprintf(ErrFmtInvalidEnvironment, env), ErrHintInvalidEnvironment)
+	}
+
+	applied := false
+
+	if overlay, ok := manifest.Environments[env]; ok {
+		mergeOverlay(manifest, &overlay)
+		applied = true
+	}
+
+	if manifestPath != "" {
+		siblingPath := environmentManifestPath(manifestPath, env)
+		sibling, err := decodeManifestFile(siblingPath, ManifestFormatAuto)
+		switch {
+		case err == nil:
+			mergeOverlay(manifest, overlayFromManifest(sibling))
+			applied = true
+		case !os.IsNotExist(err):
+			return wrapError(err, CategoryManifest, fmt.Sprintf(ErrFmtLoadEnvironmentManifest, siblingPath))
+		}
+	}
+
+	if applied {
+		manifest.environment = env
+	}
+
+	return nil
+}
+
+// environmentManifestPath returns the sibling overlay path for env.
+// For example, "config/versions.yaml" and "prod" yield "config/versions.prod.yaml".
+func environmentManifestPath(manifestPath, env string) string {
+	ext := filepath.Ext(manifestPath)
+	return strings.TrimSuffix(manifestPath, ext) + "." + env + ext
+}
+
+// isValidEnvironmentName reports whether env only contains [A-Za-z0-9_-]
+func isValidEnvironmentName(env string) bool {
+	for _, c := range env {
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return env != ""
+}
+
+// overlayFromManifest converts a sibling manifest file into an overlay.
+func overlayFromManifest(m *Manifest) *ManifestOverlay {
+	return &ManifestOverlay{
+		Project:    m.Project,
+		Schemas:    m.Schemas,
+		APIs:       m.APIs,
+		Components: m.Components,
+		Custom:     m.Custom,
+	}
+}
+
+// mergeOverlay deep-merges overlay into manifest.
+func mergeOverlay(manifest *Manifest, overlay *ManifestOverlay) {
+	if overlay.Project.Name != "" {
+		manifest.Project.Name = overlay.Project.Name
+	}
+	if overlay.Project.Version != "" {
+		manifest.Project.Version = overlay.Project.Version
+	}
+
+	manifest.Schemas = mergeStringMap(manifest.Schemas, overlay.Schemas)
+	manifest.APIs = mergeStringMap(manifest.APIs, overlay.APIs)
+	manifest.Components = mergeStringMap(manifest.Components, overlay.Components)
+	manifest.Custom = mergeCustomMap(manifest.Custom, overlay.Custom)
+}
+
+// mergeStringMap returns base with all entries of overlay applied.
+func mergeStringMap(base, overlay map[string]string) map[string]string {
+	if len(overlay) == 0 {
+		return base
+	}
+	if base == nil {
+		base = make(map[string]string, len(overlay))
+	}
+	for k, v := range overlay {
+		base[k] = v
+	}
+	return base
+}
+
+// mergeCustomMap returns base with overlay merged recursively.
+// Nested maps are merged key by key; any other value replaces the base value.
+func mergeCustomMap(base, overlay map[string]interface{}) map[string]interface{} {
+	if len(overlay) == 0 {
+		return base
+	}
+	if base == nil {
+		base = make(map[string]interface{}, len(overlay))
+	}
+	for k, v := range overlay {
+		overlayMap, overlayIsMap := v.(map[string]interface{})
+		baseMap, baseIsMap := base[k].(map[string]interface{})
+		if overlayIsMap && baseIsMap {
+			base[k] = mergeCustomMap(baseMap, overlayMap)
+			continue
+		}
+		base[k] = v
+	}
+	return base
+}
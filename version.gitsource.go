@@ -0,0 +1,198 @@
+package version
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/itsatony/go-version/internal/gitrepo"
+)
+
+// GitSource selects where git metadata is read from when it was not injected
+// via ldflags or recorded in runtime/debug.BuildInfo.
+type GitSource string
+
+// Supported git sources
+const (
+	// GitSourceAuto reads the .git directory first and falls back to the git
+	// binary for anything still missing (default)
+	GitSourceAuto GitSource = ""
+
+	// GitSourceDirectory only reads the .git directory (no process execution).
+	// Works in distroless and scratch containers without a git binary.
+	GitSourceDirectory GitSource = "directory"
+
+	// GitSourceCommand only executes the git binary (the previous behaviour)
+	GitSourceCommand GitSource = "command"
+)
+
+// validateGitSource checks that source is one of the supported git sources.
+func validateGitSource(source GitSource) error {
+	switch source {
+	case GitSourceAuto, GitSourceDirectory, GitSourceCommand:
+		return nil
+	}
+	return newCategoryErrorWithHint(CategoryValidation, fmt.Sprintf(ErrFmtUnsupportedGitSource, source), ErrHintGitSource)
+}
+
+// applyGitSourceFallback fills missing git metadata from the configured source.
+func applyGitSourceFallback(info *Info, source GitSource) {
+	if source == GitSourceAuto || source == GitSourceDirectory {
+		applyGitDirectory(info)
+	}
+	if source == GitSourceAuto || source == GitSourceCommand {
+		applyGitCommandFallback(info)
+	}
+}
+
+// applyGitDirectory reads HEAD, the current branch, tags, describe output,
+// the origin remote URL and the commit time from the .git directory
+// containing the working directory.
+//
+// Everything but the commit is only taken from the checkout if it is the
+// commit being reported, so a binary built elsewhere (commit injected via
+// ldflags) is never labelled with the branch of an unrelated repository.
+//
+// The tree state is left alone: determining it requires hashing the working
+// tree, which is what the git binary is for.
+func applyGitDirectory(info *Info) {
+	if info.Git.Commit != DefaultGitCommit && info.Git.Tag != "" && info.Git.Branch != "" &&
+		info.Git.Describe != "" && info.Git.RemoteURL != "" && info.Git.CommitTime != "" {
+		return
+	}
+
+	repo, err := gitrepo.Discover(".")
+	if err != nil {
+		return
+	}
+	defer repo.Close()
+	head, err := repo.Head()
+	if err != nil || !isValidCommitHash(head.Commit) {
+		return
+	}
+
+	if info.Git.Commit == DefaultGitCommit {
+		info.Git.Commit = head.Commit
+	}
+	if !sameCommit(info.Git.Commit, head.Commit) {
+		return
+	}
+
+	if info.Git.Branch == "" {
+		info.Git.Branch = head.Branch
+	}
+	if info.Git.Tag == "" {
+		if tags, err := repo.TagsAt(head.Commit); err == nil {
+			info.Git.Tag = preferredTag(tags)
+		}
+	}
+	if info.Git.Describe == "" {
+		if desc, err := repo.Describe(head.Commit); err == nil {
+			info.Git.Describe = formatDescribe(preferredTag(desc.Tags), desc.Distance, head.Commit)
+		}
+	}
+	if info.Git.RemoteURL == "" {
+		if remote, err := repo.RemoteURL(GitRemoteName); err == nil {
+			info.Git.RemoteURL = remote
+		}
+	}
+	if info.Git.CommitTime == "" {
+		if commit, err := repo.ReadCommit(head.Commit); err == nil && !commit.Time.IsZero() {
+			info.Git.CommitTime = commit.Time.UTC().Format(time.RFC3339)
+		}
+	}
+}
+
+// formatDescribe renders describe output the way `git describe --tags` does:
+// the bare tag on a tagged commit, "<tag>-<distance>-g<short commit>" otherwise.
+func formatDescribe(tag string, distance int, commit string) string {
+	if distance == 0 {
+		return tag
+	}
+	return fmt.Sprintf(GitDescribeFormat, tag, distance, ShortCommit(commit))
+}
+
+// sameCommit reports whether commit (possibly abbreviated) identifies full.
+func sameCommit(commit, full string) bool {
+	return len(commit) >= 7 && strings.HasPrefix(strings.ToLower(full), strings.ToLower(commit))
+}
+
+// preferredTag picks the tag to report when several point at the same commit:
+// annotated tags win over lightweight ones, then the highest SemVer, then the
+// lexically greatest name. Returns "" if tags is empty.
+func preferredTag(tags []gitrepo.Tag) string {
+	var best *gitrepo.Tag
+	for i := range tags {
+		if best == nil || tagLess(*best, tags[i]) {
+			best = &tags[i]
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return best.Name
+}
+
+// tagLess reports whether tag a ranks below tag b (see preferredTag).
+func tagLess(a, b gitrepo.Tag) bool {
+	if a.Annotated != b.Annotated {
+		return !a.Annotated
+	}
+
+	va, errA := ParseSemVer(a.Name)
+	vb, errB := ParseSemVer(b.Name)
+	switch {
+	case errA == nil && errB == nil:
+		if cmp := va.Compare(vb); cmp != 0 {
+			return cmp < 0
+		}
+	case errA == nil:
+		return false
+	case errB == nil:
+		return true
+	}
+
+	return a.Name < b.Name
+}
+
+// sanitizeRemoteURL strips credentials from a remote URL so that it is safe to
+// expose in logs and version endpoints.
+//
+// For http(s) URLs the user info, query and fragment are dropped entirely
+// (tokens are commonly passed as user name or query parameter). For other
+// schemes (ssh://, git://) only the password is dropped, keeping the "git@"
+// user. scp-like URLs ("git@host:path") carry no password. Unparseable URLs
+// that look like URLs are dropped rather than risk leaking a secret.
+func sanitizeRemoteURL(remote string) string {
+	if remote == "" {
+		return ""
+	}
+	if !strings.Contains(remote, "://") {
+		// scp-like syntax: [user@]host:path (a "user:secret@" prefix is not valid
+		// scp syntax, but strip it defensively)
+		if at := strings.Index(remote, "@"); at >= 0 {
+			if colon := strings.Index(remote[:at], ":"); colon >= 0 {
+				remote = remote[:colon] + remote[at:]
+			}
+		}
+		return remote
+	}
+
+	u, err := url.Parse(remote)
+	if err != nil {
+		return ""
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		u.User = nil
+		u.RawQuery = ""
+		u.Fragment = ""
+		u.RawFragment = ""
+	default:
+		if u.User != nil {
+			u.User = url.User(u.User.Username())
+		}
+	}
+	return u.String()
+}
@@ -1,19 +1,45 @@
 package version
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
-// Handler returns an http.Handler that serves version information as JSON.
+// Handler returns an http.Handler that serves version information.
 // The handler responds to GET requests with the current version info.
 //
+// The response format is negotiated from the Accept header, or selected
+// explicitly with the "format" query parameter, which takes precedence:
+//
+//	application/json (default, ?format=json)    Info.MarshalJSON() output
+//	application/yaml (?format=yaml)             the same document as YAML
+//	text/plain (?format=text)                   the layout of the go-version CLI
+//	text/plain; version=0.0.4 (?format=prometheus)  Prometheus metrics (see WriteMetrics)
+//
+// Requests without an Accept header, or accepting */*, get JSON. If none of
+// the supported types is acceptable, returns 406 Not Acceptable; unknown
+// ?format= values get 400 Bad Request.
+//
+// The "section" query parameter limits the response to git, build, schemas,
+// apis, components or modules, like the section flags of the CLI. Unknown
+// sections, and sections combined with Prometheus output, get 400 Bad Request.
+//
+// Responses carry a strong ETag (a hash of the body) and a Last-Modified
+// header with Info.LoadedAt(), both following reloads. Requests with a
+// matching If-None-Match, or without one and an If-Modified-Since not before
+// the load time, get 304 Not Modified. HEAD is answered like GET, without
+// the body.
+//
 // If the version singleton is not initialized, it will auto-initialize with defaults.
 // If initialization fails, returns 500 Internal Server Error.
 //
-// Response format matches Info.MarshalJSON() output.
-//
 // Thread-safe for concurrent use by multiple goroutines.
 //
 // Example:
@@ -21,9 +47,63 @@ import (
 //	mux := http.NewServeMux()
 //	mux.Handle("/version", version.Handler())
 //	http.ListenAndServe(":8080", mux)
+//	// curl -H 'Accept: application/yaml' http://localhost:8080/version
+//	// curl http://localhost:8080/version?format=text&section=git
 func Handler() http.Handler {
+	return newHandler(Get, defaultHandlerOptions())
+}
+
+// Handler returns an http.Handler that serves the registry's version info.
+// See the package-level Handler for details.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	mux.Handle("/plugins/search/version", reg.Handler())
+func (r *Registry) Handler() http.Handler {
+	return newHandler(r.Get, defaultHandlerOptions())
+}
+
+// HandlerWithOptions returns a version handler like Handler, configured with
+// opts: cache policy (WithCacheControl), CORS (WithCORS), indented JSON
+// (WithPrettyJSON), fields left out of responses (WithHiddenFields) and a
+// public view for unauthorized requests (WithAuthorizer). Without options it
+// behaves exactly like Handler.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	mux.Handle("/version", version.HandlerWithOptions(
+//	    version.WithCacheControl("public, max-age=60"),
+//	    version.WithCORS("*"),
+//	    version.WithHiddenFields("build.user", "git.remote_url", "custom"),
+//	))
+func HandlerWithOptions(opts ...HandlerOption) http.Handler {
+	return newHandler(Get, newHandlerOptions(opts...))
+}
+
+// HandlerWithOptions returns a version handler for the registry's Info,
+// configured with opts. See the package-level HandlerWithOptions for details.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	mux.Handle("/plugins/search/version", reg.HandlerWithOptions(version.WithPrettyJSON()))
+func (r *Registry) HandlerWithOptions(opts ...HandlerOption) http.Handler {
+	return newHandler(r.Get, newHandlerOptions(opts...))
+}
+
+// newHandler builds the version handler on top of get, which is called on
+// every request so that reloaded Info is served immediately.
+func newHandler(get func() (*Info, error), options *HandlerOptions) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+		if options.applyCORS(w, r) {
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
 			http.Error(w, HTTPErrorMethodNotAllowed, http.StatusMethodNotAllowed)
 			return
 		}
@@ -31,24 +111,222 @@ func Handler() http.Handler {
 		// Defensive: limit request body size even for GET (defense in depth)
 		r.Body = http.MaxBytesReader(w, r.Body, 1024)
 
-		info, err := Get()
+		w.Header().Add(HTTPHeaderVary, HTTPHeaderAccept)
+
+		format, status := negotiateFormat(r)
+		if status != http.StatusOK {
+			message := HTTPErrorNotAcceptable
+			if status == http.StatusBadRequest {
+				message = HTTPErrorUnsupportedFormat
+			}
+			http.Error(w, message, status)
+			return
+		}
+
+		var section InfoSection
+		if name := r.URL.Query().Get(HTTPQuerySection); name != "" {
+			parsed, err := ParseInfoSection(name)
+			if err != nil {
+				http.Error(w, HTTPErrorUnsupportedSection, http.StatusBadRequest)
+				return
+			}
+			if format == HTTPFormatPrometheus {
+				http.Error(w, HTTPErrorSectionNotSupported, http.StatusBadRequest)
+				return
+			}
+			section = parsed
+		}
+
+		info, err := get()
 		if err != nil {
 			http.Error(w, HTTPErrorVersionUnavailable, http.StatusInternalServerError)
 			return
 		}
 
-		w.Header().Set("Content-Type", HTTPContentTypeJSON)
-		w.Header().Set("Cache-Control", HTTPCacheControl)
-		w.WriteHeader(http.StatusOK)
+		view, cacheControl := options.view(w, r, info)
+		body, contentType, err := renderVersion(view, format, section, options.pretty)
+		if err != nil {
+			http.Error(w, HTTPErrorRenderFailed, http.StatusInternalServerError)
+			return
+		}
+
+		etag := contentETag(body)
+		w.Header().Set(HTTPHeaderETag, etag)
+		if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		lastModified := info.LoadedAt()
+		if !lastModified.IsZero() {
+			w.Header().Set(HTTPHeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+		}
 
-		if err := json.NewEncoder(w).Encode(info); err != nil {
-			// If encoding fails mid-stream, log but can't send error to client
-			// because headers are already sent
+		if notModified(r, etag, lastModified) {
+			w.WriteHeader(http.StatusNotModified)
 			return
 		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method != http.MethodHead {
+			_, _ = w.Write(body)
+		}
 	})
 }
 
+// contentETag returns a strong ETag for a response body: the quoted, hex
+// encoded start of its SHA-256 hash. Identical Info renders identical
+// bodies, so the ETag only changes when the served content does.
+func contentETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:HTTPETagHashBytes]) + `"`
+}
+
+// notModified evaluates the conditional headers of a request. If-None-Match
+// takes precedence and matches etag weakly (W/ prefixes are ignored);
+// otherwise If-Modified-Since is compared with lastModified at the one-second
+// resolution of HTTP dates.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get(HTTPHeaderIfNoneMatch); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get(HTTPHeaderIfModifiedSince))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// renderVersion renders section of info (all of it if empty) in format and
+// returns the body with its content type. JSON is indented if pretty is set.
+func renderVersion(info *Info, format string, section InfoSection, pretty bool) ([]byte, string, error) {
+	switch format {
+	case HTTPFormatYAML:
+		body, err := MarshalYAML(info.sectionValue(section))
+		return body, HTTPContentTypeYAML, err
+	case HTTPFormatText:
+		var buf bytes.Buffer
+		err := info.WriteText(&buf, section)
+		return buf.Bytes(), HTTPContentTypeText, err
+	case HTTPFormatPrometheus:
+		var buf bytes.Buffer
+		err := info.WriteMetrics(&buf)
+		return buf.Bytes(), HTTPContentTypePrometheus, err
+	}
+	body, err := marshalJSON(info.sectionValue(section), pretty)
+	return body, HTTPContentTypeJSON, err
+}
+
+// marshalJSON encodes v followed by a newline, indented if pretty is set.
+func marshalJSON(v interface{}, pretty bool) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	if pretty {
+		encoder.SetIndent("", "  ")
+	}
+	err := encoder.Encode(v)
+	return buf.Bytes(), err
+}
+
+// negotiateFormat selects the response format of the version handler from
+// the "format" query parameter or, if absent, the Accept header. It returns
+// 400 for unknown formats and 406 if no supported type is acceptable.
+func negotiateFormat(r *http.Request) (string, int) {
+	if format := r.URL.Query().Get(HTTPQueryFormat); format != "" {
+		switch format {
+		case HTTPFormatJSON, HTTPFormatYAML, HTTPFormatText, HTTPFormatPrometheus:
+			return format, http.StatusOK
+		}
+		return "", http.StatusBadRequest
+	}
+
+	accept := strings.Join(r.Header.Values(HTTPHeaderAccept), ",")
+	if strings.TrimSpace(accept) == "" {
+		return HTTPFormatJSON, http.StatusOK
+	}
+
+	type mediaRange struct {
+		formats []string
+		quality float64
+		exact   bool
+	}
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if formats := acceptFormats(mediaType, params); formats != nil {
+			ranges = append(ranges, mediaRange{formats: formats, quality: quality, exact: !strings.HasSuffix(mediaType, "/*")})
+		}
+	}
+
+	// Exact ranges with q=0 exclude their format, also from wildcard matches
+	excluded := map[string]bool{}
+	for _, mr := range ranges {
+		if mr.exact && mr.quality <= 0 {
+			excluded[mr.formats[0]] = true
+		}
+	}
+
+	// The first range with the highest quality and an acceptable format wins
+	best, bestQuality := "", 0.0
+	for _, mr := range ranges {
+		if mr.quality <= bestQuality {
+			continue
+		}
+		for _, format := range mr.formats {
+			if !excluded[format] {
+				best, bestQuality = format, mr.quality
+				break
+			}
+		}
+	}
+	if best == "" {
+		return "", http.StatusNotAcceptable
+	}
+	return best, http.StatusOK
+}
+
+// acceptFormats maps an Accept media range to the version handler formats
+// it matches in order of preference, or nil if it matches none of them.
+func acceptFormats(mediaType string, params map[string]string) []string {
+	switch mediaType {
+	case "*/*":
+		return []string{HTTPFormatJSON, HTTPFormatYAML, HTTPFormatText}
+	case "application/*":
+		return []string{HTTPFormatJSON, HTTPFormatYAML}
+	case "text/*":
+		return []string{HTTPFormatText, HTTPFormatYAML}
+	case "application/json":
+		return []string{HTTPFormatJSON}
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return []string{HTTPFormatYAML}
+	case "text/plain":
+		if _, ok := params["version"]; ok {
+			return []string{HTTPFormatPrometheus}
+		}
+		return []string{HTTPFormatText}
+	}
+	return nil
+}
+
 // HealthHandler returns an http.Handler that serves a health check endpoint.
 // The handler checks if version info is available and responds accordingly.
 //
@@ -78,7 +356,52 @@ func Handler() http.Handler {
 //	mux.Handle("/health", version.HealthHandler())
 //	http.ListenAndServe(":8080", mux)
 func HealthHandler() http.Handler {
+	return newHealthHandler(Get, defaultHandlerOptions())
+}
+
+// HealthHandler returns an http.Handler that reports whether the registry's
+// version info is available. See the package-level HealthHandler for details.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	mux.Handle("/plugins/search/health", reg.HealthHandler())
+func (r *Registry) HealthHandler() http.Handler {
+	return newHealthHandler(r.Get, defaultHandlerOptions())
+}
+
+// HealthHandlerWithOptions returns a health handler like HealthHandler,
+// configured with opts: status strings (WithHealthStatus), CORS (WithCORS)
+// and indented JSON (WithPrettyJSON).
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	mux.Handle("/health", version.HealthHandlerWithOptions(version.WithHealthStatus("UP", "DOWN")))
+func HealthHandlerWithOptions(opts ...HandlerOption) http.Handler {
+	return newHealthHandler(Get, newHandlerOptions(opts...))
+}
+
+// HealthHandlerWithOptions returns a health handler for the registry's Info,
+// configured with opts. See the package-level HealthHandlerWithOptions.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	mux.Handle("/plugins/search/health", reg.HealthHandlerWithOptions(version.WithPrettyJSON()))
+func (r *Registry) HealthHandlerWithOptions(opts ...HandlerOption) http.Handler {
+	return newHealthHandler(r.Get, newHandlerOptions(opts...))
+}
+
+// newHealthHandler builds the health handler on top of get.
+func newHealthHandler(get func() (*Info, error), options *HandlerOptions) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if options.applyCORS(w, r) {
+			return
+		}
 		if r.Method != http.MethodGet {
 			http.Error(w, HTTPErrorMethodNotAllowed, http.StatusMethodNotAllowed)
 			return
@@ -94,15 +417,20 @@ func HealthHandler() http.Handler {
 			Timestamp time.Time `json:"timestamp"`
 		}
 
-		info, err := Get()
+		info, err := get()
 		timestamp := time.Now().UTC()
 
 		w.Header().Set("Content-Type", HTTPContentTypeJSON)
 
+		encoder := json.NewEncoder(w)
+		if options.pretty {
+			encoder.SetIndent("", "  ")
+		}
+
 		if err != nil {
 			w.WriteHeader(http.StatusServiceUnavailable)
-			_ = json.NewEncoder(w).Encode(healthResponse{
-				Status:    HTTPStatusError,
+			_ = encoder.Encode(healthResponse{
+				Status:    options.healthStatusError,
 				Error:     HTTPHealthErrorMessage,
 				Timestamp: timestamp,
 			})
@@ -111,8 +439,8 @@ func HealthHandler() http.Handler {
 		}
 
 		w.WriteHeader(http.StatusOK)
-		_ = json.NewEncoder(w).Encode(healthResponse{
-			Status:    HTTPStatusOK,
+		_ = encoder.Encode(healthResponse{
+			Status:    options.healthStatusOK,
 			Version:   info.Project.Version,
 			Timestamp: timestamp,
 		})
@@ -120,6 +448,138 @@ func HealthHandler() http.Handler {
 	})
 }
 
+// SBOMHandler returns an http.Handler that serves a software bill of materials
+// for the running binary (see Info.SBOM), so security scanners can pull it
+// straight from a service.
+//
+// The format is selected with the "format" query parameter: "cyclonedx"
+// (default) or "spdx". Unknown formats get 400 Bad Request. If version info is
+// unavailable, returns 500 Internal Server Error.
+//
+// The handler is not mounted by default; expose it only where scanners can
+// reach it, as it lists every dependency and its version.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/sbom", version.SBOMHandler())
+//	// curl http://localhost:8080/sbom?format=spdx
+func SBOMHandler() http.Handler {
+	return newSBOMHandler(Get)
+}
+
+// SBOMHandler returns an http.Handler that serves the registry's SBOM.
+// See the package-level SBOMHandler for details.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	mux.Handle("/plugins/search/sbom", reg.SBOMHandler())
+func (r *Registry) SBOMHandler() http.Handler {
+	return newSBOMHandler(r.Get)
+}
+
+// newSBOMHandler builds the SBOM handler on top of get.
+func newSBOMHandler(get func() (*Info, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, HTTPErrorMethodNotAllowed, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Defensive: limit request body size even for GET (defense in depth)
+		r.Body = http.MaxBytesReader(w, r.Body, 1024)
+
+		format := SBOMFormat(r.URL.Query().Get(HTTPQueryFormat))
+		if format == "" {
+			format = SBOMFormatCycloneDX
+		}
+		if format.ContentType() == "" {
+			http.Error(w, HTTPErrorUnsupportedSBOMFormat, http.StatusBadRequest)
+			return
+		}
+
+		info, err := get()
+		if err != nil {
+			http.Error(w, HTTPErrorVersionUnavailable, http.StatusInternalServerError)
+			return
+		}
+
+		sbom, err := info.SBOM(format)
+		if err != nil {
+			http.Error(w, HTTPErrorSBOMUnavailable, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Cache-Control", HTTPCacheControl)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(sbom)
+	})
+}
+
+// MetricsHandler returns an http.Handler that serves version information in
+// the Prometheus text exposition format (see Info.WriteMetrics): a build_info
+// gauge labelled with project name, version, commit, tag, tree state and Go
+// version, plus one series per schema, API and component.
+//
+// Mount it next to an existing /metrics endpoint or scrape it as a separate
+// target. If version info is unavailable, returns 500 Internal Server Error.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/metrics/version", version.MetricsHandler())
+func MetricsHandler() http.Handler {
+	return newMetricsHandler(Get)
+}
+
+// MetricsHandler returns an http.Handler that serves the registry's version
+// info as Prometheus metrics. See the package-level MetricsHandler for details.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	mux.Handle("/plugins/search/metrics", reg.MetricsHandler())
+func (r *Registry) MetricsHandler() http.Handler {
+	return newMetricsHandler(r.Get)
+}
+
+// newMetricsHandler builds the metrics handler on top of get.
+func newMetricsHandler(get func() (*Info, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, HTTPErrorMethodNotAllowed, http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Defensive: limit request body size even for GET (defense in depth)
+		r.Body = http.MaxBytesReader(w, r.Body, 1024)
+
+		info, err := get()
+		if err != nil {
+			http.Error(w, HTTPErrorVersionUnavailable, http.StatusInternalServerError)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := info.WriteMetrics(&buf); err != nil {
+			http.Error(w, HTTPErrorMetricsUnavailable, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", HTTPContentTypePrometheus)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+	})
+}
+
 // HandlerFunc is a convenience function that returns an http.HandlerFunc
 // instead of http.Handler. It's equivalent to Handler() but returns a function type.
 //
@@ -127,10 +587,16 @@ func HealthHandler() http.Handler {
 //
 //	http.HandleFunc("/version", version.HandlerFunc())
 func HandlerFunc() http.HandlerFunc {
-	h := Handler()
-	return func(w http.ResponseWriter, r *http.Request) {
-		h.ServeHTTP(w, r)
-	}
+	return Handler().ServeHTTP
+}
+
+// HandlerFunc is equivalent to Handler() but returns an http.HandlerFunc.
+//
+// Example:
+//
+//	mux.HandleFunc("/plugins/search/version", reg.HandlerFunc())
+func (r *Registry) HandlerFunc() http.HandlerFunc {
+	return r.Handler().ServeHTTP
 }
 
 // HealthHandlerFunc is a convenience function that returns an http.HandlerFunc
@@ -140,10 +606,16 @@ func HandlerFunc() http.HandlerFunc {
 //
 //	http.HandleFunc("/health", version.HealthHandlerFunc())
 func HealthHandlerFunc() http.HandlerFunc {
-	h := HealthHandler()
-	return func(w http.ResponseWriter, r *http.Request) {
-		h.ServeHTTP(w, r)
-	}
+	return HealthHandler().ServeHTTP
+}
+
+// HealthHandlerFunc is equivalent to HealthHandler() but returns an http.HandlerFunc.
+//
+// Example:
+//
+//	mux.HandleFunc("/plugins/search/health", reg.HealthHandlerFunc())
+func (r *Registry) HealthHandlerFunc() http.HandlerFunc {
+	return r.HealthHandler().ServeHTTP
 }
 
 // Middleware returns an http middleware that adds version information to response headers.
@@ -163,12 +635,56 @@ func HealthHandlerFunc() http.HandlerFunc {
 //	// Wrap the entire mux with version middleware
 //	http.ListenAndServe(":8080", version.Middleware(mux))
 func Middleware(next http.Handler) http.Handler {
+	return newMiddleware(Get, next, defaultHandlerOptions())
+}
+
+// Middleware returns an http middleware that adds the registry's version to
+// response headers. See the package-level Middleware for details.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	mux.Handle("/plugins/search/", reg.Middleware(searchHandler))
+func (r *Registry) Middleware(next http.Handler) http.Handler {
+	return newMiddleware(r.Get, next, defaultHandlerOptions())
+}
+
+// MiddlewareWithOptions returns a version header middleware like Middleware,
+// configured with opts: the header names are set with WithHeaderNames.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	handler := version.MiddlewareWithOptions(mux, version.WithHeaderNames("X-Service-Version", "X-Service-Commit"))
+func MiddlewareWithOptions(next http.Handler, opts ...HandlerOption) http.Handler {
+	return newMiddleware(Get, next, newHandlerOptions(opts...))
+}
+
+// MiddlewareWithOptions returns a version header middleware for the
+// registry's Info, configured with opts. See the package-level
+// MiddlewareWithOptions for details.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	mux.Handle("/plugins/search/", reg.MiddlewareWithOptions(searchHandler, version.WithHeaderNames("X-Search-Version", "")))
+func (r *Registry) MiddlewareWithOptions(next http.Handler, opts ...HandlerOption) http.Handler {
+	return newMiddleware(r.Get, next, newHandlerOptions(opts...))
+}
+
+// newMiddleware builds the version header middleware on top of get.
+func newMiddleware(get func() (*Info, error), next http.Handler, options *HandlerOptions) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Try to get version info, but don't block on failure
-		if info, err := Get(); err == nil {
-			w.Header().Set(HTTPHeaderAppVersion, info.Project.Version)
-			if info.Git.Commit != DefaultGitCommit {
-				w.Header().Set(HTTPHeaderGitCommit, info.Git.Commit)
+		if info, err := get(); err == nil {
+			if options.appVersionHeader != "" {
+				w.Header().Set(options.appVersionHeader, info.Project.Version)
+			}
+			if options.gitCommitHeader != "" && info.Git.Commit != DefaultGitCommit {
+				w.Header().Set(options.gitCommitHeader, info.Git.Commit)
 			}
 		}
 
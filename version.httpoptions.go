@@ -0,0 +1,357 @@
+package version
+
+import (
+	"net/http"
+	"strings"
+)
+
+// HandlerOptions contains configuration for the HTTP handlers and middleware.
+// Use the With* functions returning HandlerOption to configure options.
+type HandlerOptions struct {
+	// cacheControl is the Cache-Control header of version responses (omitted if empty)
+	cacheControl string
+
+	// corsOrigins are the origins allowed to read responses cross-origin ("*" for any)
+	corsOrigins []string
+
+	// pretty enables indented JSON output
+	pretty bool
+
+	// hiddenFields are the JSON paths left out of version responses
+	hiddenFields []string
+
+	// authorizer selects the full view for a request; others get Info.Public (full view for all if nil)
+	authorizer Authorizer
+
+	// healthStatusOK is the status string of successful health checks
+	healthStatusOK string
+
+	// healthStatusError is the status string of failed health checks
+	healthStatusError string
+
+	// appVersionHeader is the middleware's version header name (omitted if empty)
+	appVersionHeader string
+
+	// gitCommitHeader is the middleware's commit header name (omitted if empty)
+	gitCommitHeader string
+}
+
+// defaultHandlerOptions returns the default handler options, which match
+// the behaviour of Handler, HealthHandler and Middleware.
+func defaultHandlerOptions() *HandlerOptions {
+	return &HandlerOptions{
+		cacheControl:      HTTPCacheControl,
+		healthStatusOK:    HTTPStatusOK,
+		healthStatusError: HTTPStatusError,
+		appVersionHeader:  HTTPHeaderAppVersion,
+		gitCommitHeader:   HTTPHeaderGitCommit,
+	}
+}
+
+// newHandlerOptions returns the default handler options with opts applied.
+func newHandlerOptions(opts ...HandlerOption) *HandlerOptions {
+	options := defaultHandlerOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// HandlerOption is a functional option for configuring the HTTP handlers and
+// middleware.
+type HandlerOption func(*HandlerOptions)
+
+// WithCacheControl sets the Cache-Control header of version responses.
+// An empty value omits the header. Default: "public, max-age=300".
+//
+// Applies to HandlerWithOptions.
+//
+// Example:
+//
+//	h := version.HandlerWithOptions(version.WithCacheControl("no-cache"))
+func WithCacheControl(value string) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.cacheControl = value
+	}
+}
+
+// WithCORS allows browsers on the given origins to read responses, e.g. a
+// status page on another domain. "*" allows any origin. Preflight requests
+// (OPTIONS) are answered with 204 No Content, and the ETag and Last-Modified
+// headers are exposed to scripts.
+//
+// Applies to HandlerWithOptions and HealthHandlerWithOptions.
+//
+// Example:
+//
+//	h := version.HandlerWithOptions(version.WithCORS("https://status.example.com"))
+func WithCORS(origins ...string) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.corsOrigins = append(o.corsOrigins, origins...)
+	}
+}
+
+// WithPrettyJSON indents JSON responses by two spaces.
+//
+// Applies to HandlerWithOptions and HealthHandlerWithOptions.
+//
+// Example:
+//
+//	h := version.HandlerWithOptions(version.WithPrettyJSON())
+func WithPrettyJSON() HandlerOption {
+	return func(o *HandlerOptions) {
+		o.pretty = true
+	}
+}
+
+// WithHiddenFields leaves fields out of version responses, in every format.
+// Fields are addressed by their path in the JSON output: a whole part
+// ("environment", "git", "build", "schemas", "apis", "components", "custom",
+// "overrides", "modules"), a git or build field ("build.user",
+// "git.remote_url") or a single schema, API, component or custom entry
+// ("custom.owner", "components.billing-internal"). Paths that do not exist
+// are ignored.
+//
+// Applies to HandlerWithOptions.
+//
+// Example:
+//
+//	public := version.HandlerWithOptions(version.WithHiddenFields("build.user", "git.remote_url", "custom"))
+func WithHiddenFields(paths ...string) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.hiddenFields = append(o.hiddenFields, paths...)
+	}
+}
+
+// Authorizer decides whether a request may see the full version info, e.g.
+// by checking a bearer token or the client certificate. It must be safe for
+// concurrent use.
+type Authorizer func(*http.Request) bool
+
+// WithAuthorizer lets one version endpoint serve two audiences: requests
+// the authorizer accepts get the full version info, all others the public
+// view of Info.Public. Full views are sent with "Cache-Control: private,
+// no-cache" so shared caches do not store them, and responses vary on the
+// Authorization header. Hidden fields are left out of both views.
+//
+// To serve only the public view, use an authorizer that always returns false.
+//
+// Applies to HandlerWithOptions.
+//
+// Example:
+//
+//	h := version.HandlerWithOptions(version.WithAuthorizer(func(r *http.Request) bool {
+//	    return subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) == 1
+//	}))
+func WithAuthorizer(authorizer Authorizer) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.authorizer = authorizer
+	}
+}
+
+// WithHealthStatus sets the status strings of health responses.
+// Defaults: "ok" and "error".
+//
+// Applies to HealthHandlerWithOptions.
+//
+// Example:
+//
+//	h := version.HealthHandlerWithOptions(version.WithHealthStatus("UP", "DOWN"))
+func WithHealthStatus(ok, failed string) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.healthStatusOK = ok
+		o.healthStatusError = failed
+	}
+}
+
+// WithHeaderNames sets the response header names of the middleware. An
+// empty name omits that header. Defaults: "X-App-Version" and "X-Git-Commit".
+//
+// Applies to MiddlewareWithOptions.
+//
+// Example:
+//
+//	h := version.MiddlewareWithOptions(mux, version.WithHeaderNames("X-Service-Version", ""))
+func WithHeaderNames(appVersion, gitCommit string) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.appVersionHeader = appVersion
+		o.gitCommitHeader = gitCommit
+	}
+}
+
+// applyCORS sets the CORS headers for an allowed Origin and reports whether
+// r is a preflight request, which has then been answered.
+func (o *HandlerOptions) applyCORS(w http.ResponseWriter, r *http.Request) bool {
+	if len(o.corsOrigins) == 0 {
+		return false
+	}
+
+	origin := r.Header.Get(HTTPHeaderOrigin)
+	allowed := ""
+	for _, candidate := range o.corsOrigins {
+		if candidate == "*" {
+			allowed = "*"
+			break
+		}
+		if origin != "" && strings.EqualFold(candidate, origin) {
+			allowed = origin
+		}
+	}
+	if allowed != "*" {
+		w.Header().Add(HTTPHeaderVary, HTTPHeaderOrigin)
+	}
+	if allowed == "" {
+		return false
+	}
+
+	w.Header().Set(HTTPHeaderCORSAllowOrigin, allowed)
+	w.Header().Set(HTTPHeaderCORSExposeHeaders, HTTPHeaderETag+", "+HTTPHeaderLastModified)
+	if r.Method != http.MethodOptions || r.Header.Get(HTTPHeaderCORSRequestMethod) == "" {
+		return false
+	}
+
+	w.Header().Set(HTTPHeaderCORSAllowMethods, HTTPCORSAllowMethods)
+	w.Header().Set(HTTPHeaderCORSAllowHeaders, HTTPCORSAllowHeaders)
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+// view returns the version info r may see and the Cache-Control header to
+// send with it: the public view, unless no authorizer is configured or it
+// accepts r, minus the hidden fields.
+func (o *HandlerOptions) view(w http.ResponseWriter, r *http.Request, info *Info) (*Info, string) {
+	cacheControl := o.cacheControl
+	if o.authorizer != nil {
+		w.Header().Add(HTTPHeaderVary, HTTPHeaderAuthorization)
+		if o.authorizer(r) {
+			cacheControl = HTTPCacheControlPrivate
+		} else {
+			info = info.Public()
+		}
+	}
+	return info.withoutFields(o.hiddenFields), cacheControl
+}
+
+// withoutFields returns a copy of i with the fields at paths (JSON paths as
+// accepted by WithHiddenFields) cleared. i itself is not modified.
+func (i *Info) withoutFields(paths []string) *Info {
+	if len(paths) == 0 {
+		return i
+	}
+
+	redacted := *i
+	for _, path := range paths {
+		part, key, nested := strings.Cut(path, FieldPathSeparator)
+		if !nested {
+			switch part {
+			case FieldPathEnvironment:
+				redacted.environment = ""
+			case FieldPathGit:
+				redacted.Git = GitInfo{}
+			case FieldPathBuild:
+				redacted.Build = BuildInfo{}
+			case DimensionSchemas:
+				redacted.schemas = nil
+			case DimensionAPIs:
+				redacted.apis = nil
+			case DimensionComponents:
+				redacted.components = nil
+			case DimensionCustom:
+				redacted.custom = nil
+			case FieldPathOverrides:
+				redacted.overrides = nil
+			case DimensionModules:
+				redacted.modules = nil
+			}
+			continue
+		}
+
+		switch part {
+		case FieldPathGit:
+			if clearField, ok := gitFields[key]; ok {
+				clearField(&redacted.Git)
+			}
+		case FieldPathBuild:
+			if clearField, ok := buildFields[key]; ok {
+				clearField(&redacted.Build)
+			}
+		case DimensionSchemas:
+			redacted.schemas = withoutKey(redacted.schemas, key)
+		case DimensionAPIs:
+			redacted.apis = withoutKey(redacted.apis, key)
+		case DimensionComponents:
+			redacted.components = withoutKey(redacted.components, key)
+		case DimensionCustom:
+			if _, ok := redacted.custom[key]; ok {
+				custom := make(map[string]interface{}, len(redacted.custom))
+				for k, v := range redacted.custom {
+					if k != key {
+						custom[k] = v
+					}
+				}
+				redacted.custom = custom
+			}
+		}
+	}
+	return &redacted
+}
+
+// isFieldPath reports whether path addresses a field as described for
+// WithHiddenFields. Map entries are not checked for existence.
+func isFieldPath(path string) bool {
+	part, key, nested := strings.Cut(path, FieldPathSeparator)
+	switch part {
+	case FieldPathEnvironment, FieldPathOverrides, DimensionModules:
+		return !nested
+	case FieldPathGit:
+		_, ok := gitFields[key]
+		return !nested || ok
+	case FieldPathBuild:
+		_, ok := buildFields[key]
+		return !nested || ok
+	case DimensionSchemas, DimensionAPIs, DimensionComponents, DimensionCustom:
+		return !nested || key != ""
+	}
+	return false
+}
+
+// withoutKey returns m without key, copying it only if key is present.
+func withoutKey(m map[string]string, key string) map[string]string {
+	if _, ok := m[key]; !ok {
+		return m
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if k != key {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// gitFields clear the git field with the given JSON name.
+var gitFields = map[string]func(*GitInfo){
+	"commit":       func(g *GitInfo) { g.Commit = "" },
+	"short_commit": func(g *GitInfo) { g.ShortCommit = "" },
+	"tag":          func(g *GitInfo) { g.Tag = "" },
+	"branch":       func(g *GitInfo) { g.Branch = "" },
+	"describe":     func(g *GitInfo) { g.Describe = "" },
+	"remote_url":   func(g *GitInfo) { g.RemoteURL = "" },
+	"tree_state":   func(g *GitInfo) { g.TreeState = "" },
+	"commit_time":  func(g *GitInfo) { g.CommitTime = "" },
+}
+
+// buildFields clear the build field with the given JSON name.
+var buildFields = map[string]func(*BuildInfo){
+	"time":         func(b *BuildInfo) { b.Time = "" },
+	"user":         func(b *BuildInfo) { b.User = "" },
+	"go_version":   func(b *BuildInfo) { b.GoVersion = "" },
+	"path":         func(b *BuildInfo) { b.Path = "" },
+	"main_module":  func(b *BuildInfo) { b.MainModule = "" },
+	"main_version": func(b *BuildInfo) { b.MainVersion = "" },
+	"goos":         func(b *BuildInfo) { b.GOOS = "" },
+	"goarch":       func(b *BuildInfo) { b.GOARCH = "" },
+	"cgo_enabled":  func(b *BuildInfo) { b.CGOEnabled = false },
+	"tags":         func(b *BuildInfo) { b.Tags = nil },
+	"trimpath":     func(b *BuildInfo) { b.Trimpath = false },
+}
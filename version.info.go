@@ -3,39 +3,52 @@ package version
 import (
 	"encoding/json"
 	"time"
-
-	"go.uber.org/zap"
 )
 
 // Manifest represents the structure of a versions.yaml file.
 // This is the file format that users create to define their version information.
+// The same structure is used for versions.json and versions.toml manifests.
 type Manifest struct {
 	// ManifestVersion is the version of the manifest format itself
-	ManifestVersion string `yaml:"manifest_version" json:"manifest_version"`
+	ManifestVersion string `yaml:"manifest_version" json:"manifest_version" toml:"manifest_version"`
 
 	// Project contains the main project version information
-	Project ProjectManifest `yaml:"project" json:"project"`
+	Project ProjectManifest `yaml:"project" json:"project" toml:"project"`
 
 	// Schemas contains database schema versions (e.g., "postgres_main": "47")
-	Schemas map[string]string `yaml:"schemas,omitempty" json:"schemas,omitempty"`
+	Schemas map[string]string `yaml:"schemas,omitempty" json:"schemas,omitempty" toml:"schemas,omitempty"`
 
 	// APIs contains API version numbers (e.g., "rest_v1": "1.15.0")
-	APIs map[string]string `yaml:"apis,omitempty" json:"apis,omitempty"`
+	APIs map[string]string `yaml:"apis,omitempty" json:"apis,omitempty" toml:"apis,omitempty"`
 
 	// Components contains dependency/component versions (e.g., "aigentchat": "3.4.1")
-	Components map[string]string `yaml:"components,omitempty" json:"components,omitempty"`
+	Components map[string]string `yaml:"components,omitempty" json:"components,omitempty" toml:"components,omitempty"`
 
 	// Custom contains any custom version dimensions defined by the user
-	Custom map[string]interface{} `yaml:"custom,omitempty" json:"custom,omitempty"`
+	Custom map[string]interface{} `yaml:"custom,omitempty" json:"custom,omitempty" toml:"custom,omitempty"`
+
+	// Private lists fields left out of the public view (see Info.Public) by
+	// their JSON path, e.g. "custom.owner" or "components.billing-internal"
+	Private []string `yaml:"private,omitempty" json:"private,omitempty" toml:"private,omitempty"`
+
+	// Environments contains per-environment overlays (e.g., "dev", "staging", "prod")
+	// that are deep-merged over the base manifest when selected via WithEnvironment
+	Environments map[string]ManifestOverlay `yaml:"environments,omitempty" json:"environments,omitempty" toml:"environments,omitempty"`
+
+	// environment is the name of the overlay applied by the loader (empty if none)
+	environment string
+
+	// overrides records fields set from environment variables ("apis.rest_v1" -> "env:GOVERSION_APIS_REST_V1")
+	overrides map[string]string
 }
 
 // ProjectManifest represents the project section of the manifest
 type ProjectManifest struct {
 	// Name is the project/application name
-	Name string `yaml:"name" json:"name"`
+	Name string `yaml:"name" json:"name" toml:"name"`
 
 	// Version is the semantic version of the project
-	Version string `yaml:"version" json:"version"`
+	Version string `yaml:"version" json:"version" toml:"version"`
 }
 
 // Info contains the complete runtime version information for an application.
@@ -68,6 +81,18 @@ type Info struct {
 	// custom contains any custom version dimensions (unexported for immutability)
 	custom map[string]interface{}
 
+	// environment is the name of the applied environment overlay (empty if none)
+	environment string
+
+	// overrides records the source of values overridden at load time (unexported for immutability)
+	overrides map[string]string
+
+	// modules contains the Go module dependencies compiled into the binary (unexported for immutability)
+	modules []Module
+
+	// private lists the JSON paths of fields left out of the public view
+	private []string
+
 	// loadedAt is the time this Info was created (internal use)
 	loadedAt time.Time
 }
@@ -81,15 +106,43 @@ type ProjectVersion struct {
 	Version string `json:"version"`
 }
 
+// SemVer parses the project version as a semantic version.
+//
+// Example:
+//
+//	v, err := info.Project.SemVer()
+//	if err == nil && v.Major() >= 2 {
+//	    // ...
+//	}
+//
+// Thread-safe for concurrent use by multiple goroutines.
+func (p ProjectVersion) SemVer() (*SemVer, error) {
+	return ParseSemVer(p.Version)
+}
+
 // GitInfo contains git metadata injected at build time or extracted from runtime
 type GitInfo struct {
 	// Commit is the full git commit hash
 	Commit string `json:"commit"`
 
+	// ShortCommit is the commit abbreviated to 7 characters (empty if Commit is not a hash)
+	ShortCommit string `json:"short_commit,omitempty"`
+
 	// Tag is the git tag (if any) for this commit
 	Tag string `json:"tag,omitempty"`
 
-	// TreeState indicates whether there were uncommitted changes ("clean" or "dirty")
+	// Branch is the checked-out branch (empty for a detached HEAD)
+	Branch string `json:"branch,omitempty"`
+
+	// Describe is the nearest tag and distance in `git describe --tags` form,
+	// e.g. "v1.4.0" on a tagged commit or "v1.4.0-12-gabc1234" twelve commits later
+	Describe string `json:"describe,omitempty"`
+
+	// RemoteURL is the URL of the "origin" remote with credentials stripped
+	RemoteURL string `json:"remote_url,omitempty"`
+
+	// TreeState indicates whether there were uncommitted changes
+	// ("clean", "dirty", or "unknown" if no source reported it)
 	TreeState string `json:"tree_state"`
 
 	// CommitTime is the timestamp of the commit
@@ -106,6 +159,47 @@ type BuildInfo struct {
 
 	// GoVersion is the version of Go used to build the binary
 	GoVersion string `json:"go_version"`
+
+	// Path is the import path of the main package (e.g. "github.com/acme/app/cmd/server")
+	Path string `json:"path,omitempty"`
+
+	// MainModule is the path of the main module
+	MainModule string `json:"main_module,omitempty"`
+
+	// MainVersion is the version of the main module as recorded by the go command
+	// (a tag, a pseudo-version, or "(devel)")
+	MainVersion string `json:"main_version,omitempty"`
+
+	// GOOS is the target operating system
+	GOOS string `json:"goos,omitempty"`
+
+	// GOARCH is the target architecture
+	GOARCH string `json:"goarch,omitempty"`
+
+	// CGOEnabled reports whether the binary was built with cgo
+	CGOEnabled bool `json:"cgo_enabled"`
+
+	// Tags are the build tags passed via -tags
+	Tags []string `json:"tags,omitempty"`
+
+	// Trimpath reports whether the binary was built with -trimpath
+	Trimpath bool `json:"trimpath,omitempty"`
+}
+
+// Module describes a Go module compiled into the binary, as recorded in
+// runtime/debug.BuildInfo.Deps.
+type Module struct {
+	// Path is the module path (e.g. "go.uber.org/zap")
+	Path string `json:"path"`
+
+	// Version is the module version (e.g. "v1.27.0")
+	Version string `json:"version"`
+
+	// Sum is the go.sum checksum of the module (empty for replaced modules)
+	Sum string `json:"sum,omitempty"`
+
+	// Replace is the module this one was replaced by via a replace directive, if any
+	Replace *Module `json:"replace,omitempty"`
 }
 
 // GetSchemaVersion returns the version for a named schema.
@@ -204,6 +298,127 @@ func (i *Info) GetCustom() map[string]interface{} {
 	return copy
 }
 
+// Environment returns the name of the environment overlay that was merged
+// into the manifest, or an empty string if no overlay was applied.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+func (i *Info) Environment() string {
+	return i.environment
+}
+
+// GetOverrides returns a defensive copy of the values that were overridden at
+// load time, keyed by dotted field path with the source as value, e.g.
+// "project.version" -> "env:GOVERSION_PROJECT_VERSION".
+// Returns nil if nothing was overridden.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+func (i *Info) GetOverrides() map[string]string {
+	if i.overrides == nil {
+		return nil
+	}
+	copy := make(map[string]string, len(i.overrides))
+	for k, v := range i.overrides {
+		copy[k] = v
+	}
+	return copy
+}
+
+// GetModules returns a defensive copy of the Go module dependencies compiled
+// into the binary, sorted by module path. Returns nil if build info was not
+// loaded (see WithBuildInfo) or the binary was built without module support.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	for _, mod := range info.GetModules() {
+//	    fmt.Println(mod.Path, mod.Version)
+//	}
+func (i *Info) GetModules() []Module {
+	if i.modules == nil {
+		return nil
+	}
+	return copyModules(i.modules)
+}
+
+// GetModuleVersion returns the version of the named module dependency.
+// If the module was replaced, the replacement's version is returned (empty for
+// a replacement by a local directory). Returns empty string and false if the
+// module is not compiled into the binary.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	if v, ok := info.GetModuleVersion("golang.org/x/net"); ok {
+//	    log.Printf("golang.org/x/net %s", v)
+//	}
+func (i *Info) GetModuleVersion(path string) (string, bool) {
+	for _, mod := range i.modules {
+		if mod.Path != path {
+			continue
+		}
+		if mod.Replace != nil {
+			return mod.Replace.Version, true
+		}
+		return mod.Version, true
+	}
+	return "", false
+}
+
+// copyModules returns a deep copy of modules (including Replace pointers).
+func copyModules(modules []Module) []Module {
+	copied := make([]Module, len(modules))
+	for idx, mod := range modules {
+		copied[idx] = mod
+		if mod.Replace != nil {
+			replace := *mod.Replace
+			copied[idx].Replace = &replace
+		}
+	}
+	return copied
+}
+
+// Public returns a view of the version info for unauthenticated audiences:
+// a copy without the build user, the git remote URL, the override sources,
+// the Go module dependencies (paths, versions and checksums) and the fields
+// listed under "private" in the manifest, addressed by their
+// JSON path (see WithHiddenFields):
+//
+//	private:
+//	  - custom.owner
+//	  - components.billing-internal
+//
+// The receiver is not modified; calling Public on a public view returns an
+// equal view.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	data, _ := json.Marshal(version.MustGet().Public())
+func (i *Info) Public() *Info {
+	paths := make([]string, 0, len(defaultPrivateFields)+len(i.private))
+	paths = append(paths, defaultPrivateFields...)
+	paths = append(paths, i.private...)
+	return i.withoutFields(paths)
+}
+
+// PrivateFields returns the JSON paths the manifest marks as private (copy).
+//
+// Thread-safe for concurrent use by multiple goroutines.
+func (i *Info) PrivateFields() []string {
+	if i.private == nil {
+		return nil
+	}
+	return append([]string(nil), i.private...)
+}
+
+// defaultPrivateFields are left out of every public view: they identify
+// people and internal infrastructure rather than the running version, or,
+// for modules, reveal every dependency to scan for known vulnerabilities.
+var defaultPrivateFields = []string{FieldPathBuildUser, FieldPathGitRemoteURL, FieldPathOverrides, DimensionModules}
+
 // LoadedAt returns the time when this version info was loaded.
 // Useful for diagnostics and cache invalidation.
 //
@@ -212,26 +427,30 @@ func (i *Info) LoadedAt() time.Time {
 	return i.loadedAt
 }
 
-// LogFields returns structured logging fields for use with zap logger.
-// This provides a convenient way to include version info in log entries.
+// KeysAndValues returns the version info as alternating keys and values for
+// logr and other loggers that take key/value pairs. The keys are the
+// LogField* names also used by the zap fields of the versionzap package.
 //
 // Example:
 //
-//	logger := zap.NewProduction()
-//	logger = logger.With(info.LogFields()...)
+//	logger = logger.WithValues(info.KeysAndValues()...)
 //	logger.Info("Application started")
 //
 // Thread-safe for concurrent use by multiple goroutines.
-func (i *Info) LogFields() []zap.Field {
-	return []zap.Field{
-		zap.String(LogFieldProjectName, i.Project.Name),
-		zap.String(LogFieldProjectVersion, i.Project.Version),
-		zap.String(LogFieldGitCommit, i.Git.Commit),
-		zap.String(LogFieldGitTag, i.Git.Tag),
-		zap.String(LogFieldGitTreeState, i.Git.TreeState),
-		zap.String(LogFieldBuildTime, i.Build.Time),
-		zap.String(LogFieldBuildUser, i.Build.User),
-		zap.String(LogFieldGoVersion, i.Build.GoVersion),
+func (i *Info) KeysAndValues() []any {
+	return []any{
+		LogFieldProjectName, i.Project.Name,
+		LogFieldProjectVersion, i.Project.Version,
+		LogFieldGitCommit, i.Git.Commit,
+		LogFieldGitTag, i.Git.Tag,
+		LogFieldGitTreeState, i.Git.TreeState,
+		LogFieldGitShortCommit, i.Git.ShortCommit,
+		LogFieldGitBranch, i.Git.Branch,
+		LogFieldGitDescribe, i.Git.Describe,
+		LogFieldGitRemoteURL, i.Git.RemoteURL,
+		LogFieldBuildTime, i.Build.Time,
+		LogFieldBuildUser, i.Build.User,
+		LogFieldGoVersion, i.Build.GoVersion,
 	}
 }
 
@@ -248,23 +467,29 @@ func (i *Info) String() string {
 func (i *Info) MarshalJSON() ([]byte, error) {
 	// Manually construct JSON structure to include unexported fields
 	type jsonInfo struct {
-		Project    ProjectVersion         `json:"project"`
-		Git        GitInfo                `json:"git"`
-		Build      BuildInfo              `json:"build"`
-		Schemas    map[string]string      `json:"schemas,omitempty"`
-		APIs       map[string]string      `json:"apis,omitempty"`
-		Components map[string]string      `json:"components,omitempty"`
-		Custom     map[string]interface{} `json:"custom,omitempty"`
+		Project     ProjectVersion         `json:"project"`
+		Environment string                 `json:"environment,omitempty"`
+		Git         GitInfo                `json:"git"`
+		Build       BuildInfo              `json:"build"`
+		Schemas     map[string]string      `json:"schemas,omitempty"`
+		APIs        map[string]string      `json:"apis,omitempty"`
+		Components  map[string]string      `json:"components,omitempty"`
+		Custom      map[string]interface{} `json:"custom,omitempty"`
+		Overrides   map[string]string      `json:"overrides,omitempty"`
+		Modules     []Module               `json:"modules,omitempty"`
 	}
 
 	return json.Marshal(jsonInfo{
-		Project:    i.Project,
-		Git:        i.Git,
-		Build:      i.Build,
-		Schemas:    i.schemas,
-		APIs:       i.apis,
-		Components: i.components,
-		Custom:     i.custom,
+		Project:     i.Project,
+		Environment: i.environment,
+		Git:         i.Git,
+		Build:       i.Build,
+		Schemas:     i.schemas,
+		APIs:        i.apis,
+		Components:  i.components,
+		Custom:      i.custom,
+		Overrides:   i.overrides,
+		Modules:     i.modules,
 	})
 }
 
@@ -272,13 +497,16 @@ func (i *Info) MarshalJSON() ([]byte, error) {
 func (i *Info) UnmarshalJSON(data []byte) error {
 	// Use a temporary struct with exported fields for unmarshaling
 	type jsonInfo struct {
-		Project    ProjectVersion         `json:"project"`
-		Git        GitInfo                `json:"git"`
-		Build      BuildInfo              `json:"build"`
-		Schemas    map[string]string      `json:"schemas,omitempty"`
-		APIs       map[string]string      `json:"apis,omitempty"`
-		Components map[string]string      `json:"components,omitempty"`
-		Custom     map[string]interface{} `json:"custom,omitempty"`
+		Project     ProjectVersion         `json:"project"`
+		Environment string                 `json:"environment,omitempty"`
+		Git         GitInfo                `json:"git"`
+		Build       BuildInfo              `json:"build"`
+		Schemas     map[string]string      `json:"schemas,omitempty"`
+		APIs        map[string]string      `json:"apis,omitempty"`
+		Components  map[string]string      `json:"components,omitempty"`
+		Custom      map[string]interface{} `json:"custom,omitempty"`
+		Overrides   map[string]string      `json:"overrides,omitempty"`
+		Modules     []Module               `json:"modules,omitempty"`
 	}
 
 	var temp jsonInfo
@@ -288,12 +516,15 @@ func (i *Info) UnmarshalJSON(data []byte) error {
 
 	// Populate Info fields
 	i.Project = temp.Project
+	i.environment = temp.Environment
 	i.Git = temp.Git
 	i.Build = temp.Build
 	i.schemas = temp.Schemas
 	i.apis = temp.APIs
 	i.components = temp.Components
 	i.custom = temp.Custom
+	i.overrides = temp.Overrides
+	i.modules = temp.Modules
 
 	return nil
 }
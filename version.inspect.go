@@ -0,0 +1,283 @@
+package version
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/itsatony/go-version/internal/binscan"
+)
+
+// Inspect reads the version information of another Go binary on disk without
+// executing it, e.g. to verify release artifacts before deploying them.
+//
+// Sources, in order of precedence:
+//   - ldflags-injected variables of this package (GitCommit, GitTag, BuildTime, ...)
+//     read from the binary's data section via its symbol table
+//   - the binary's debug/buildinfo (Go version, main module, dependencies,
+//     build settings and vcs.* stamps)
+//   - a YAML manifest embedded in the binary (e.g. via //go:embed and WithEmbedded)
+//     for project, schemas, APIs, components and custom values
+//
+// Stripped binaries (-ldflags=-s) have no symbol table. For those, the data
+// section is scanned for a unique commit hash and a unique RFC 3339 timestamp,
+// which are reported as Git.Commit and Build.Time; other ldflags values cannot
+// be attributed and fall back to build info. Embedded manifests are found
+// heuristically and only in YAML format; a binary that embeds several
+// different manifests is an error. Without one, the project name is the last
+// element of the main package path and the version is the main module's tag
+// (if any).
+//
+// Nothing from the current process (working directory, git repository,
+// environment) is consulted.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	info, err := version.Inspect("./dist/server-linux-amd64")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if info.Git.Commit != expectedCommit {
+//	    log.Fatalf("artifact built from %s, expected %s", info.Git.Commit, expectedCommit)
+//	}
+func Inspect(path string) (*Info, error) {
+	buildInfo, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return nil, wrapErrorWithHint(err, CategoryBuildInfo, ErrMsgInspectBinary, ErrHintInspectBinary)
+	}
+	file, err := binscan.Open(path)
+	if err != nil {
+		return nil, wrapErrorWithHint(err, CategoryBuildInfo, ErrMsgInspectBinary, ErrHintInspectBinary)
+	}
+
+	manifest, err := findEmbeddedManifest(file.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil {
+		manifest = inspectDefaultManifest(buildInfo.Path, buildInfo.Main.Version)
+	}
+
+	info := manifestToInfo(manifest)
+	values := readLdflagsValues(file)
+
+	applyLdflagsGitValues(info, values)
+	applyBuildInfoGitData(info, buildInfo)
+	finalizeGitInfo(info)
+
+	applyLdflagsBuildValues(info, values)
+	info.Build.GoVersion = buildInfo.GoVersion
+	applyBuildInfoSettings(info, buildInfo)
+
+	info.loadedAt = time.Now()
+	return info, nil
+}
+
+// readLdflagsValues reads this package's ldflags variables from file. Missing
+// variables (e.g. removed by the linker because the binary never loads version
+// info) keep their defaults.
+func readLdflagsValues(file *binscan.File) ldflagsValues {
+	values := ldflagsValues{
+		gitCommit:    DefaultGitCommit,
+		gitTreeState: DefaultGitTreeState,
+		buildTime:    DefaultBuildTime,
+		buildUser:    DefaultBuildUser,
+	}
+
+	if !file.HasSymbols() {
+		scanStrippedLdflags(file.DataStrings(), &values)
+		return values
+	}
+
+	vars := map[string]*string{
+		LdflagsVarGitCommit:    &values.gitCommit,
+		LdflagsVarGitTag:       &values.gitTag,
+		LdflagsVarGitTreeState: &values.gitTreeState,
+		LdflagsVarGitBranch:    &values.gitBranch,
+		LdflagsVarGitDescribe:  &values.gitDescribe,
+		LdflagsVarGitRemoteURL: &values.gitRemoteURL,
+		LdflagsVarBuildTime:    &values.buildTime,
+		LdflagsVarBuildUser:    &values.buildUser,
+	}
+	for name, target := range vars {
+		if value, found, err := file.StringVar(PackageImportPath + "." + name); err == nil && found {
+			*target = value
+		}
+	}
+	return values
+}
+
+// scanStrippedLdflags picks the commit hash and build time out of the strings
+// referenced from a stripped binary's data section. A value is only taken if
+// exactly one candidate exists, so unrelated strings are never misattributed.
+func scanStrippedLdflags(strs []string, values *ldflagsValues) {
+	commits := make(map[string]bool)
+	times := make(map[string]bool)
+	for _, s := range strs {
+		switch {
+		case (len(s) == 40 || len(s) == 64) && isValidCommitHash(s) && strings.ContainsAny(s, "abcdef"):
+			// Requiring a letter skips long decimal constants (e.g. strconv tables)
+			commits[s] = true
+		case len(s) >= len("2006-01-02T15:04:05Z") && len(s) <= len(time.RFC3339Nano):
+			if _, err := time.Parse(time.RFC3339, s); err == nil {
+				times[s] = true
+			}
+		}
+	}
+
+	if len(commits) == 1 {
+		for commit := range commits {
+			values.gitCommit = commit
+		}
+	}
+	if len(times) == 1 {
+		for ts := range times {
+			values.buildTime = ts
+		}
+	}
+}
+
+// inspectDefaultManifest names an inspected binary without embedded manifest
+// after its main package and versions it by its main module tag.
+func inspectDefaultManifest(mainPath, mainVersion string) *Manifest {
+	manifest := defaultManifest()
+	if mainPath != "" {
+		manifest.Project.Name = path.Base(mainPath)
+	}
+	if tag := taggedModuleVersion(mainVersion); tag != "" {
+		manifest.Project.Version = strings.TrimPrefix(tag, "v")
+	}
+	return manifest
+}
+
+// findEmbeddedManifest searches data for a YAML manifest and returns the one
+// that parses and validates, or nil. The unrendered template of go-version
+// init is skipped; several different manifests are an error, as there is no
+// telling which one belongs to the program.
+//
+// Embedded files are stored as plain bytes among other string data, so the
+// manifest's boundaries are unknown. From each "manifest_version:" marker the
+// manifest extends over the following lines that look like YAML; if these
+// lack the project section, up to InspectMaxLeadingLines earlier lines are
+// included one by one.
+func findEmbeddedManifest(data []byte) (*Manifest, error) {
+	marker := []byte(InspectManifestMarker)
+	var found *Manifest
+	var foundText []byte
+	for offset := 0; offset < len(data); {
+		idx := bytes.Index(data[offset:], marker)
+		if idx < 0 {
+			break
+		}
+		pos := offset + idx
+		offset = pos + len(marker)
+
+		manifest, start, end := manifestAt(data, pos)
+		if manifest == nil {
+			continue
+		}
+		// Markers within this manifest (e.g. in comments) start no other one
+		offset = end
+		text := data[start:end]
+		if bytes.Contains(text, []byte(ManifestTemplatePlaceholder)) {
+			continue
+		}
+		if found != nil && !bytes.Equal(text, foundText) {
+			return nil, newCategoryErrorWithHint(CategoryManifest, ErrMsgInspectAmbiguousManifest, ErrHintInspectAmbiguousManifest)
+		}
+		found, foundText = manifest, text
+	}
+	return found, nil
+}
+
+// manifestAt decodes the manifest around the marker at pos and returns it
+// with its boundaries, or nil.
+func manifestAt(data []byte, pos int) (manifest *Manifest, start, end int) {
+	end = manifestEnd(data, pos)
+	if end == pos {
+		return nil, 0, 0
+	}
+
+	start = pos
+	for attempt := 0; attempt <= InspectMaxLeadingLines; attempt++ {
+		manifest, err := decodeManifest(data[start:end], ManifestFormatYAML)
+		if err != nil {
+			return nil, 0, 0
+		}
+		if validateManifest(manifest) == nil {
+			return manifest, start, end
+		}
+
+		// The text decodes but lacks the project section, which may precede
+		// manifest_version: include the previous line
+		if start = previousManifestLine(data, start); start < 0 {
+			return nil, 0, 0
+		}
+	}
+	return nil, 0, 0
+}
+
+// manifestEnd returns the end of the manifest lines starting at pos: the
+// offset after the last complete line before one that cannot be part of a
+// manifest, bounded by InspectMaxManifestLines and InspectMaxManifestSize.
+func manifestEnd(data []byte, pos int) int {
+	limit := len(data)
+	if pos+InspectMaxManifestSize < limit {
+		limit = pos + InspectMaxManifestSize
+	}
+
+	end := pos
+	for lines := 0; lines < InspectMaxManifestLines; lines++ {
+		length := bytes.IndexByte(data[end:limit], '\n')
+		if length < 0 || !isManifestLine(data[end:end+length]) {
+			break
+		}
+		end += length + 1
+	}
+	return end
+}
+
+// previousManifestLine returns the start of the line before start, or -1 if
+// there is none or it cannot be part of a manifest.
+func previousManifestLine(data []byte, start int) int {
+	if start == 0 || data[start-1] != '\n' {
+		return -1
+	}
+	prev := start - 1
+	for prev > 0 && data[prev-1] != '\n' && isManifestByte(data[prev-1]) {
+		prev--
+	}
+	if !isManifestLine(data[prev : start-1]) {
+		return -1
+	}
+	return prev
+}
+
+// isManifestLine reports whether line (without line break) can appear in a
+// YAML manifest: blank, indented, a comment, a list item or a top-level key.
+func isManifestLine(line []byte) bool {
+	for _, b := range line {
+		if !isManifestByte(b) || b == '\n' {
+			return false
+		}
+	}
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	if len(line) == 0 {
+		return true
+	}
+	switch line[0] {
+	case ' ', '\t', '#', '-':
+		return true
+	}
+	colon := bytes.IndexByte(line, ':')
+	return colon > 0 && (colon == len(line)-1 || line[colon+1] == ' ' || line[colon+1] == '\t')
+}
+
+// isManifestByte reports whether b can appear in a YAML manifest.
+func isManifestByte(b byte) bool {
+	return b >= 0x20 && b != 0x7f || b == '\n' || b == '\t' || b == '\r'
+}
@@ -0,0 +1,161 @@
+package version
+
+import (
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ldflags holds values for the ldflags-injectable variables of this package
+// (GitCommit, GitTag, BuildTime, ...). Empty fields are not injected.
+type Ldflags struct {
+	// GitCommit is the full commit hash of HEAD
+	GitCommit string
+
+	// GitTag is the tag pointing at HEAD, if any
+	GitTag string
+
+	// GitTreeState is "clean" or "dirty" (empty if it cannot be determined)
+	GitTreeState string
+
+	// GitBranch is the checked-out branch (empty for a detached HEAD)
+	GitBranch string
+
+	// GitDescribe is the `git describe --tags` output
+	GitDescribe string
+
+	// GitRemoteURL is the origin remote URL with credentials stripped
+	GitRemoteURL string
+
+	// BuildTime is the build timestamp in RFC 3339 format (UTC)
+	BuildTime string
+
+	// BuildUser is the user running the build
+	BuildUser string
+}
+
+// ReadLdflags computes the ldflags values for a build of the git repository
+// containing the working directory, using the same git reader as the library
+// does at runtime (see GitSource). This replaces the usual Makefile snippet
+// of git and date invocations.
+//
+// The build time is the current time, or SOURCE_DATE_EPOCH if set, so that
+// reproducible builds get a fixed timestamp. Outside a git repository only
+// build time and user are set.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	flags, err := version.ReadLdflags(version.GitSourceAuto)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	cmd := exec.Command("go", "build", "-ldflags", flags.String(), "./cmd/server")
+func ReadLdflags(source GitSource) (*Ldflags, error) {
+	buildTime, err := ldflagsBuildTime(os.Getenv(EnvSourceDateEpoch), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{Git: GitInfo{Commit: DefaultGitCommit, TreeState: DefaultGitTreeState}}
+	applyGitSourceFallback(info, source)
+	finalizeGitInfo(info)
+
+	flags := &Ldflags{
+		GitTag:       info.Git.Tag,
+		GitBranch:    info.Git.Branch,
+		GitDescribe:  info.Git.Describe,
+		GitRemoteURL: info.Git.RemoteURL,
+		BuildTime:    buildTime,
+		BuildUser:    ldflagsBuildUser(),
+	}
+	if info.Git.Commit != DefaultGitCommit {
+		flags.GitCommit = info.Git.Commit
+	}
+	if info.Git.TreeState != GitTreeStateUnknown {
+		flags.GitTreeState = info.Git.TreeState
+	}
+	return flags, nil
+}
+
+// ldflagsBuildTime returns now, or the time given by a SOURCE_DATE_EPOCH
+// value, formatted as RFC 3339 in UTC.
+func ldflagsBuildTime(epoch string, now time.Time) (string, error) {
+	if epoch == "" {
+		return now.UTC().Format(time.RFC3339), nil
+	}
+	seconds, err := strconv.ParseInt(strings.TrimSpace(epoch), 10, 64)
+	if err != nil {
+		return "", wrapErrorWithHint(err, CategoryBuildInfo, ErrMsgSourceDateEpoch, ErrHintSourceDateEpoch)
+	}
+	return time.Unix(seconds, 0).UTC().Format(time.RFC3339), nil
+}
+
+// ldflagsBuildUser returns the name of the current user, falling back to
+// $USER and $USERNAME (e.g. in containers without an /etc/passwd entry).
+func ldflagsBuildUser() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	if name := os.Getenv(EnvUser); name != "" {
+		return name
+	}
+	return os.Getenv(EnvUsername)
+}
+
+// vars returns the non-empty values keyed by the fully qualified variable
+// name (e.g. "github.com/itsatony/go-version.GitCommit"), in a fixed order.
+func (l *Ldflags) vars() [][2]string {
+	all := [][2]string{
+		{LdflagsVarGitCommit, l.GitCommit},
+		{LdflagsVarGitTag, l.GitTag},
+		{LdflagsVarGitTreeState, l.GitTreeState},
+		{LdflagsVarGitBranch, l.GitBranch},
+		{LdflagsVarGitDescribe, l.GitDescribe},
+		{LdflagsVarGitRemoteURL, l.GitRemoteURL},
+		{LdflagsVarBuildTime, l.BuildTime},
+		{LdflagsVarBuildUser, l.BuildUser},
+	}
+
+	vars := make([][2]string, 0, len(all))
+	for _, v := range all {
+		if v[1] != "" {
+			vars = append(vars, [2]string{PackageImportPath + "." + v[0], v[1]})
+		}
+	}
+	return vars
+}
+
+// String returns the values as a -ldflags argument of -X flags. Flags
+// containing whitespace are quoted the way go build splits -ldflags.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	fmt.Println(flags.String())
+//	// -X github.com/itsatony/go-version.GitCommit=abc123... -X github.com/itsatony/go-version.BuildTime=2025-01-01T00:00:00Z
+func (l *Ldflags) String() string {
+	vars := l.vars()
+	parts := make([]string, 0, 2*len(vars))
+	for _, v := range vars {
+		parts = append(parts, "-X", quoteLdflag(v[0]+"="+v[1]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteLdflag quotes a field of a -ldflags value if it contains whitespace.
+// go build splits -ldflags on whitespace and accepts single- or double-quoted
+// fields without escapes, so the quote not present in the field is used.
+func quoteLdflag(field string) string {
+	if !strings.ContainsAny(field, " \t\r\n") {
+		return field
+	}
+	if !strings.Contains(field, "'") {
+		return "'" + field + "'"
+	}
+	return `"` + field + `"`
+}
@@ -2,14 +2,15 @@ package version
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"runtime"
 	"runtime/debug"
+	"sort"
 	"strings"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 // Variables for -ldflags injection at build time.
@@ -20,9 +21,15 @@ import (
 // Example Makefile:
 //
 //	LDFLAGS := -X github.com/itsatony/go-version.GitCommit=$(shell git rev-parse HEAD)
-//	LDFLAGS += -X github.com/itsatony/go-version.GitTag=$(shell git describe --tags --always)
+//	LDFLAGS += -X github.com/itsatony/go-version.GitTag=$(shell git describe --tags --exact-match)
+//	LDFLAGS += -X github.com/itsatony/go-version.GitDescribe=$(shell git describe --tags)
+//	LDFLAGS += -X github.com/itsatony/go-version.GitBranch=$(shell git rev-parse --abbrev-ref HEAD)
 //	LDFLAGS += -X github.com/itsatony/go-version.BuildTime=$(shell date -u '+%Y-%m-%dT%H:%M:%SZ')
 //	LDFLAGS += -X github.com/itsatony/go-version.BuildUser=$(shell whoami)
+//
+// Or let the CLI compute them (see ReadLdflags):
+//
+//	go build -ldflags "$(go-version ldflags)" ./cmd/myapp
 var (
 	// GitCommit is the git commit hash, injected via ldflags
 	GitCommit = DefaultGitCommit
@@ -33,6 +40,15 @@ var (
 	// GitTreeState is the git tree state (clean/dirty), injected via ldflags
 	GitTreeState = DefaultGitTreeState
 
+	// GitBranch is the git branch, injected via ldflags
+	GitBranch = ""
+
+	// GitDescribe is the `git describe --tags` output, injected via ldflags
+	GitDescribe = ""
+
+	// GitRemoteURL is the origin remote URL, injected via ldflags (credentials are stripped)
+	GitRemoteURL = ""
+
 	// BuildTime is the build timestamp, injected via ldflags
 	BuildTime = DefaultBuildTime
 
@@ -52,16 +68,16 @@ const gitCommandTimeout = 5 * time.Second
 //  2. File manifest (from WithManifestPath or default "versions.yaml")
 //  3. Defaults (if no manifest found)
 //
-// Then enriches with:
+// Then applies environment variable overrides (if WithEnvOverrides) and enriches with:
 //   - Git info (if WithGitInfo, default true)
 //   - Build info (if WithBuildInfo, default true)
 //
 // Finally runs validators (if any).
 func loadVersionInfo(opts ...Option) (*Info, error) {
 	// Apply options
-	options := defaultLoadOptions()
-	for _, opt := range opts {
-		opt(options)
+	options := newLoadOptions(opts...)
+	if err := validateGitSource(options.gitSource); err != nil {
+		return nil, err
 	}
 
 	// Load manifest
@@ -72,17 +88,22 @@ func loadVersionInfo(opts ...Option) (*Info, error) {
 			if os.IsNotExist(err) {
 				return nil, newCategoryErrorWithHint(CategoryManifest, ErrMsgStrictModeManifestRequired, ErrHintStrictMode)
 			}
-			return nil, wrapErrorWithHint(err, CategoryManifest, ErrMsgLoadManifest, ErrHintParseYAML)
+			return nil, wrapErrorWithHint(err, CategoryManifest, ErrMsgLoadManifest, ErrHintParseManifest)
 		}
 
 		// Non-strict mode: use defaults if manifest not found
 		if !os.IsNotExist(err) {
-			return nil, wrapErrorWithHint(err, CategoryManifest, ErrMsgLoadManifest, ErrHintParseYAML)
+			return nil, wrapErrorWithHint(err, CategoryManifest, ErrMsgLoadManifest, ErrHintParseManifest)
 		}
 		// Use default manifest
 		manifest = defaultManifest()
 	}
 
+	// Apply environment variable overrides before conversion and validation
+	if options.envOverridePrefix != "" {
+		applyEnvOverrides(manifest, options.envOverridePrefix, os.Environ())
+	}
+
 	// Convert manifest to Info
 	info := manifestToInfo(manifest)
 
@@ -92,7 +113,7 @@ func loadVersionInfo(opts ...Option) (*Info, error) {
 
 	// Enrich with git info
 	if options.includeGit {
-		enrichWithGitInfo(info)
+		enrichWithGitInfo(info, options.gitSource)
 	}
 
 	// Enrich with build info
@@ -114,33 +135,85 @@ func loadVersionInfo(opts ...Option) (*Info, error) {
 	return info, nil
 }
 
-// loadManifest loads the manifest from embedded data or file.
-// Precedence: embedded > file
+// loadManifest loads the manifest from embedded data or file, applies the
+// selected environment overlay and validates the merged result.
+// Precedence: embedded > explicit file > default filenames
 func loadManifest(options *LoadOptions) (*Manifest, error) {
+	manifest, path, err := readManifest(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyEnvironment(manifest, options.resolveEnvironment(), path); err != nil {
+		return nil, err
+	}
+
+	if err := validateManifest(manifest); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// readManifest decodes the manifest source without validating it.
+// It returns the path the manifest was read from, or "" for embedded data.
+func readManifest(options *LoadOptions) (*Manifest, string, error) {
 	// Try embedded first
 	if len(options.manifestEmbed) > 0 {
-		return parseManifest(options.manifestEmbed)
+		format := detectManifestFormat(options.manifestFormat, "", options.manifestEmbed)
+		manifest, err := decodeManifest(options.manifestEmbed, format)
+		return manifest, "", err
 	}
 
-	// Try file
+	// Try explicit file
 	if options.manifestPath != "" {
-		data, err := os.ReadFile(options.manifestPath)
-		if err != nil {
-			return nil, err
+		manifest, err := decodeManifestFile(options.manifestPath, options.manifestFormat)
+		return manifest, options.manifestPath, err
+	}
+
+	// Try default filenames in order; their extension names the format
+	for _, name := range defaultManifestFilenames {
+		manifest, err := decodeManifestFile(name, ManifestFormatAuto)
+		if os.IsNotExist(err) {
+			continue
 		}
-		return parseManifest(data)
+		return manifest, name, err
 	}
 
-	return nil, os.ErrNotExist
+	return nil, "", os.ErrNotExist
 }
 
-// parseManifest parses YAML manifest data.
+// decodeManifestFile reads and decodes a manifest file, detecting its format
+// from the extension or content unless format is set.
+func decodeManifestFile(path string, format ManifestFormat) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeManifest(data, detectManifestFormat(format, path, data))
+}
+
+// parseManifest parses manifest data, detecting the format from its content.
 func parseManifest(data []byte) (*Manifest, error) {
-	var manifest Manifest
-	if err := yaml.Unmarshal(data, &manifest); err != nil {
-		return nil, wrapError(err, CategoryManifest, ErrMsgParseYAML)
+	return parseManifestFormat(data, sniffManifestFormat(data))
+}
+
+// parseManifestFormat parses manifest data in the given format and validates it.
+func parseManifestFormat(data []byte, format ManifestFormat) (*Manifest, error) {
+	manifest, err := decodeManifest(data, format)
+	if err != nil {
+		return nil, err
 	}
 
+	if err := validateManifest(manifest); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// validateManifest checks required fields and fills in defaults.
+func validateManifest(manifest *Manifest) error {
 	// Validate manifest version
 	if manifest.ManifestVersion == "" {
 		manifest.ManifestVersion = ManifestVersion
@@ -148,13 +221,19 @@ func parseManifest(data []byte) (*Manifest, error) {
 
 	// Validate project section
 	if manifest.Project.Name == "" {
-		return nil, newCategoryErrorWithHint(CategoryManifest, ErrMsgProjectNameRequired, ErrHintProjectNameRequired)
+		return newCategoryErrorWithHint(CategoryManifest, ErrMsgProjectNameRequired, ErrHintProjectNameRequired)
 	}
 	if manifest.Project.Version == "" {
-		return nil, newCategoryErrorWithHint(CategoryManifest, ErrMsgProjectVersionRequired, ErrHintProjectVersionRequired)
+		return newCategoryErrorWithHint(CategoryManifest, ErrMsgProjectVersionRequired, ErrHintProjectVersionRequired)
 	}
 
-	return &manifest, nil
+	for _, path := range manifest.Private {
+		if !isFieldPath(path) {
+			return newCategoryErrorWithHint(CategoryManifest, fmt.Sprintf(ErrFmtInvalidPrivateField, path), ErrHintPrivateField)
+		}
+	}
+
+	return nil
 }
 
 // defaultManifest returns a default manifest when no file is found.
@@ -175,6 +254,7 @@ func manifestToInfo(m *Manifest) *Info {
 			Name:    m.Project.Name,
 			Version: m.Project.Version,
 		},
+		environment: m.environment,
 		Git: GitInfo{
 			Commit:    DefaultGitCommit,
 			TreeState: DefaultGitTreeState,
@@ -214,6 +294,17 @@ func manifestToInfo(m *Manifest) *Info {
 		}
 	}
 
+	if m.Private != nil {
+		info.private = append([]string(nil), m.Private...)
+	}
+
+	if m.overrides != nil {
+		info.overrides = make(map[string]string, len(m.overrides))
+		for k, v := range m.overrides {
+			info.overrides[k] = v
+		}
+	}
+
 	return info
 }
 
@@ -221,38 +312,96 @@ func manifestToInfo(m *Manifest) *Info {
 // Tries multiple sources in order:
 //  1. Injected ldflags variables
 //  2. runtime/debug.BuildInfo (Go 1.18+)
-//  3. Direct git command execution (fallback)
-func enrichWithGitInfo(info *Info) {
+//  3. The .git directory and/or git command execution, depending on source
+//
+// Each source only fills fields that are still unset. Finally, derived fields
+// (ShortCommit, Describe on a tagged commit) are filled and credentials are
+// stripped from RemoteURL.
+func enrichWithGitInfo(info *Info, source GitSource) {
 	// Use injected ldflags if available
 	applyLdflagsGitInfo(info)
 
 	// Try runtime/debug.BuildInfo
-	applyBuildInfoGitData(info)
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		applyBuildInfoGitData(info, buildInfo)
+	}
+
+	// Fallback: read the repository (if in git repo)
+	applyGitSourceFallback(info, source)
+
+	finalizeGitInfo(info)
+}
 
-	// Fallback: try git command (if in git repo)
-	applyGitCommandFallback(info)
+// ldflagsValues holds the values of the ldflags-injectable variables, either
+// of this binary or read from another one by Inspect.
+type ldflagsValues struct {
+	gitCommit    string
+	gitTag       string
+	gitTreeState string
+	gitBranch    string
+	gitDescribe  string
+	gitRemoteURL string
+	buildTime    string
+	buildUser    string
+}
+
+// injectedLdflags returns the values of this binary's ldflags variables.
+func injectedLdflags() ldflagsValues {
+	return ldflagsValues{
+		gitCommit:    GitCommit,
+		gitTag:       GitTag,
+		gitTreeState: GitTreeState,
+		gitBranch:    GitBranch,
+		gitDescribe:  GitDescribe,
+		gitRemoteURL: GitRemoteURL,
+		buildTime:    BuildTime,
+		buildUser:    BuildUser,
+	}
 }
 
 // applyLdflagsGitInfo applies git information from ldflags injection
 func applyLdflagsGitInfo(info *Info) {
-	if GitCommit != DefaultGitCommit {
-		info.Git.Commit = GitCommit
+	applyLdflagsGitValues(info, injectedLdflags())
+}
+
+// applyLdflagsGitValues applies the git values that differ from their defaults
+func applyLdflagsGitValues(info *Info, values ldflagsValues) {
+	if values.gitCommit != DefaultGitCommit && values.gitCommit != "" {
+		info.Git.Commit = values.gitCommit
 	}
-	if GitTag != "" {
-		info.Git.Tag = GitTag
+	if values.gitTag != "" {
+		info.Git.Tag = values.gitTag
 	}
-	if GitTreeState != DefaultGitTreeState {
-		info.Git.TreeState = GitTreeState
+	if values.gitTreeState != DefaultGitTreeState && values.gitTreeState != "" {
+		info.Git.TreeState = values.gitTreeState
+	}
+	if values.gitBranch != "" {
+		info.Git.Branch = values.gitBranch
+	}
+	if values.gitDescribe != "" {
+		info.Git.Describe = values.gitDescribe
+	}
+	if values.gitRemoteURL != "" {
+		info.Git.RemoteURL = values.gitRemoteURL
 	}
 }
 
-// applyBuildInfoGitData extracts git information from runtime/debug.BuildInfo
-func applyBuildInfoGitData(info *Info) {
-	buildInfo, ok := debug.ReadBuildInfo()
-	if !ok {
-		return
+// applyLdflagsBuildValues applies the build values that differ from their defaults
+func applyLdflagsBuildValues(info *Info, values ldflagsValues) {
+	if values.buildTime != DefaultBuildTime && values.buildTime != "" {
+		info.Build.Time = values.buildTime
+	}
+	if values.buildUser != DefaultBuildUser {
+		info.Build.User = values.buildUser
 	}
+}
 
+// applyBuildInfoGitData extracts git information from runtime/debug.BuildInfo.
+//
+// Besides the vcs.* settings, a tagged main module version (set by go build
+// since Go 1.24, or by go install pkg@version) is used as tag and describe
+// output. Pseudo-versions and "(devel)" are ignored.
+func applyBuildInfoGitData(info *Info, buildInfo *debug.BuildInfo) {
 	for _, setting := range buildInfo.Settings {
 		switch setting.Key {
 		case VCSKeyRevision:
@@ -264,48 +413,207 @@ func applyBuildInfoGitData(info *Info) {
 				info.Git.CommitTime = setting.Value
 			}
 		case VCSKeyModified:
-			if setting.Value == VCSValueTrue {
+			switch {
+			case setting.Value == VCSValueTrue:
 				info.Git.TreeState = GitTreeStateDirty
+			case setting.Value == VCSValueFalse && info.Git.TreeState == DefaultGitTreeState:
+				info.Git.TreeState = GitTreeStateClean
 			}
 		}
 	}
+
+	if tag := taggedModuleVersion(buildInfo.Main.Version); tag != "" {
+		if info.Git.Tag == "" {
+			info.Git.Tag = tag
+		}
+		if info.Git.Describe == "" {
+			info.Git.Describe = tag
+		}
+	}
+}
+
+// taggedModuleVersion returns the tag a main module version was built from,
+// or "" for "(devel)", pseudo-versions and non-SemVer strings.
+func taggedModuleVersion(version string) string {
+	version = strings.TrimSuffix(version, VCSVersionDirtySuffix)
+	if version == "" || version == VCSVersionDevel || isPseudoVersion(version) {
+		return ""
+	}
+	if _, err := ParseSemVerStrict(strings.TrimPrefix(version, "v")); err != nil {
+		return ""
+	}
+	return version
 }
 
-// applyGitCommandFallback tries to get git information via command execution
+// isPseudoVersion reports whether version is a Go module pseudo-version such as
+// "v0.0.0-20240101120000-abcdef123456" or "v1.2.4-0.20240101120000-abcdef123456".
+func isPseudoVersion(version string) bool {
+	// The last two dash-separated fields are a 14-digit timestamp (optionally
+	// prefixed with "0.") and a 12-character commit hash
+	parts := strings.Split(version, "-")
+	if len(parts) < 3 {
+		return false
+	}
+	hash := parts[len(parts)-1]
+	timestamp := parts[len(parts)-2]
+	if i := strings.LastIndex(timestamp, "."); i >= 0 {
+		timestamp = timestamp[i+1:]
+	}
+	if len(hash) != 12 || len(timestamp) != 14 || !isValidCommitHash(hash) {
+		return false
+	}
+	for _, c := range timestamp {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// applyGitCommandFallback tries to get git information via command execution.
+//
+// Branch, tag, describe output, remote and tree state describe the working
+// copy, so they are only taken if its HEAD is the commit being reported.
 func applyGitCommandFallback(info *Info) {
+	if info.Git.Commit != DefaultGitCommit && info.Git.Tag != "" && info.Git.Branch != "" &&
+		info.Git.Describe != "" && info.Git.RemoteURL != "" && info.Git.TreeState != DefaultGitTreeState {
+		return
+	}
+
+	head := getGitCommit()
+	if head == "" {
+		return
+	}
 	if info.Git.Commit == DefaultGitCommit {
-		if commit := getGitCommit(); commit != "" {
-			info.Git.Commit = commit
-		}
+		info.Git.Commit = head
+	}
+	if !sameCommit(info.Git.Commit, head) {
+		return
 	}
+
 	if info.Git.Tag == "" {
-		if tag := getGitTag(); tag != "" {
-			info.Git.Tag = tag
-		}
+		info.Git.Tag = getGitTag()
+	}
+	if info.Git.Branch == "" {
+		info.Git.Branch = getGitBranch()
+	}
+	if info.Git.Describe == "" {
+		info.Git.Describe = runGit(GitCmdDescribe, GitArgTags)
+	}
+	if info.Git.RemoteURL == "" {
+		info.Git.RemoteURL = runGit(GitCmdConfig, GitArgGet, GitArgRemoteURL)
+	}
+	if info.Git.TreeState == DefaultGitTreeState {
+		info.Git.TreeState = getGitTreeState()
+	}
+}
+
+// finalizeGitInfo fills derived fields and sanitizes RemoteURL.
+func finalizeGitInfo(info *Info) {
+	if info.Git.ShortCommit == "" {
+		info.Git.ShortCommit = ShortCommit(info.Git.Commit)
+	}
+	if info.Git.Describe == "" && info.Git.Tag != "" {
+		// On a tagged commit, describe output is the tag itself
+		info.Git.Describe = info.Git.Tag
+	}
+	info.Git.RemoteURL = sanitizeRemoteURL(info.Git.RemoteURL)
+	if info.Git.TreeState == "" {
+		info.Git.TreeState = GitTreeStateUnknown
 	}
 }
 
+// ShortCommit abbreviates a commit hash to GitShortCommitLength characters,
+// as in GitInfo.ShortCommit. Returns "" if commit is not a hash (e.g. the
+// "dev" default).
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	version.ShortCommit("0123456789abcdef0123456789abcdef01234567") // "0123456"
+func ShortCommit(commit string) string {
+	if !isValidCommitHash(commit) {
+		return ""
+	}
+	if len(commit) > GitShortCommitLength {
+		return commit[:GitShortCommitLength]
+	}
+	return commit
+}
+
 // enrichWithBuildInfo enriches Info with build metadata.
 func enrichWithBuildInfo(info *Info) {
 	// Use injected ldflags
-	if BuildTime != DefaultBuildTime {
-		info.Build.Time = BuildTime
+	applyLdflagsBuildValues(info, injectedLdflags())
+
+	// Go version and platform are always available
+	info.Build.GoVersion = runtime.Version()
+	info.Build.GOOS = runtime.GOOS
+	info.Build.GOARCH = runtime.GOARCH
+
+	// Main module, build flags and dependencies from runtime/debug.BuildInfo.
+	// Build time is not recorded there and must be injected via ldflags.
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		applyBuildInfoSettings(info, buildInfo)
 	}
-	if BuildUser != DefaultBuildUser {
-		info.Build.User = BuildUser
+}
+
+// applyBuildInfoSettings copies the main package, main module, build
+// settings and module dependencies from buildInfo into info.
+func applyBuildInfoSettings(info *Info, buildInfo *debug.BuildInfo) {
+	info.Build.Path = buildInfo.Path
+	info.Build.MainModule = buildInfo.Main.Path
+	info.Build.MainVersion = buildInfo.Main.Version
+
+	for _, setting := range buildInfo.Settings {
+		switch setting.Key {
+		case BuildSettingGOOS:
+			info.Build.GOOS = setting.Value
+		case BuildSettingGOARCH:
+			info.Build.GOARCH = setting.Value
+		case BuildSettingCGOEnabled:
+			info.Build.CGOEnabled = setting.Value == BuildSettingValueEnabled
+		case BuildSettingTags:
+			info.Build.Tags = splitBuildTags(setting.Value)
+		case BuildSettingTrimpath:
+			info.Build.Trimpath = setting.Value == VCSValueTrue
+		}
 	}
 
-	// Go version is always available
-	info.Build.GoVersion = runtime.Version()
+	info.modules = modulesFromBuildInfo(buildInfo.Deps)
+}
+
+// splitBuildTags splits the comma-separated -tags build setting.
+func splitBuildTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
 
-	// Try runtime/debug.BuildInfo for build time if not injected
-	if info.Build.Time == DefaultBuildTime {
-		if _, ok := debug.ReadBuildInfo(); ok {
-			// BuildInfo doesn't have build time, but we can use current time as fallback
-			// In practice, build time should be injected via ldflags
-			_ = ok // Suppress unused variable warning
+// modulesFromBuildInfo converts debug.BuildInfo dependencies, sorted by path.
+// Returns nil if there are no dependencies.
+func modulesFromBuildInfo(deps []*debug.Module) []Module {
+	if len(deps) == 0 {
+		return nil
+	}
+	modules := make([]Module, 0, len(deps))
+	for _, dep := range deps {
+		if dep == nil {
+			continue
 		}
+		mod := Module{Path: dep.Path, Version: dep.Version, Sum: dep.Sum}
+		if dep.Replace != nil {
+			mod.Replace = &Module{Path: dep.Replace.Path, Version: dep.Replace.Version, Sum: dep.Replace.Sum}
+		}
+		modules = append(modules, mod)
 	}
+	sort.Slice(modules, func(a, b int) bool { return modules[a].Path < modules[b].Path })
+	return modules
 }
 
 // getGitBinary returns the git binary path after security validation.
@@ -383,31 +691,49 @@ func isValidCommitHash(hash string) bool {
 	return true
 }
 
-// getGitCommit tries to get the current git commit hash.
-// Returns empty string if git is not available or not in a git repo.
+// runGit executes git and returns its trimmed output.
+// Returns empty string if git is not available or the command fails.
 //
 // SECURITY:
 //   - Uses getGitBinary() to validate git binary location
 //   - Uses CommandContext with timeout to prevent hangs
-//   - Git command arguments are constants to prevent command injection
-//   - Validates output format with isValidCommitHash()
-func getGitCommit() string {
+//   - Callers pass constant arguments; values read from the repository (tag
+//     names) only follow GitArgEndOfOptions, so they are never parsed as options
+func runGit(args ...string) string {
+	output, _ := runGitOutput(args...)
+	return output
+}
+
+// runGitOutput is runGit for callers that report failures. The error names
+// the subcommand and carries git's own message (e.g. "not a git repository").
+func runGitOutput(args ...string) (string, error) {
 	// Validate git binary location first
 	gitBinary := getGitBinary()
 	if gitBinary == "" {
-		return ""
+		return "", errors.New(ErrMsgGitNotAvailable)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), gitCommandTimeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, gitBinary, GitCmdRevParse, GitArgHead)
+	cmd := exec.CommandContext(ctx, gitBinary, args...)
 	output, err := cmd.Output()
 	if err != nil {
-		return ""
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			err = errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
 	}
+	return strings.TrimSpace(string(output)), nil
+}
 
-	hash := strings.TrimSpace(string(output))
+// getGitCommit tries to get the current git commit hash.
+// Returns empty string if git is not available or not in a git repo.
+//
+// SECURITY: Validates output format with isValidCommitHash().
+func getGitCommit() string {
+	hash := runGit(GitCmdRevParse, GitArgHead)
 
 	// Validate the hash format
 	if !isValidCommitHash(hash) {
@@ -419,25 +745,37 @@ func getGitCommit() string {
 
 // getGitTag tries to get the current git tag.
 // Returns empty string if no tag or git is not available.
-//
-// SECURITY:
-//   - Uses getGitBinary() to validate git binary location
-//   - Uses CommandContext with timeout to prevent hangs
-//   - Git command arguments are constants to prevent command injection
 func getGitTag() string {
-	// Validate git binary location first
+	return runGit(GitCmdDescribe, GitArgTags, GitArgExactMatch)
+}
+
+// getGitBranch tries to get the current branch.
+// Returns empty string for a detached HEAD or if git is not available.
+func getGitBranch() string {
+	branch := runGit(GitCmdRevParse, GitArgAbbrevRef, GitArgHead)
+	if branch == GitArgHead {
+		return ""
+	}
+	return branch
+}
+
+// getGitTreeState reports "clean" or "dirty" from `git status --porcelain`.
+// Returns GitTreeStateUnknown if git is not available.
+func getGitTreeState() string {
 	gitBinary := getGitBinary()
 	if gitBinary == "" {
-		return ""
+		return GitTreeStateUnknown
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), gitCommandTimeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, gitBinary, GitCmdDescribe, GitArgTags, GitArgExactMatch)
-	output, err := cmd.Output()
+	output, err := exec.CommandContext(ctx, gitBinary, GitCmdStatus, GitArgPorcelain).Output()
 	if err != nil {
-		return ""
+		return GitTreeStateUnknown
+	}
+	if len(strings.TrimSpace(string(output))) == 0 {
+		return GitTreeStateClean
 	}
-	return strings.TrimSpace(string(output))
+	return GitTreeStateDirty
 }
@@ -0,0 +1,169 @@
+package version
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFormat identifies the encoding of a version manifest.
+type ManifestFormat string
+
+// Supported manifest formats
+const (
+	// ManifestFormatAuto detects the format from the file extension or content
+	ManifestFormatAuto ManifestFormat = ""
+
+	// ManifestFormatYAML is the YAML manifest format (versions.yaml, versions.yml)
+	ManifestFormatYAML ManifestFormat = "yaml"
+
+	// ManifestFormatJSON is the JSON manifest format (versions.json)
+	ManifestFormatJSON ManifestFormat = "json"
+
+	// ManifestFormatTOML is the TOML manifest format (versions.toml)
+	ManifestFormatTOML ManifestFormat = "toml"
+)
+
+// defaultManifestFilenames lists the manifest files tried, in order, when no
+// explicit path is configured with WithManifestPath.
+var defaultManifestFilenames = []string{
+	ManifestFilenameYAML,
+	ManifestFilenameYML,
+	ManifestFilenameJSON,
+	ManifestFilenameTOML,
+}
+
+// detectManifestFormat determines the manifest format.
+// An explicit format wins; otherwise the file extension is used, and finally
+// the content is sniffed.
+func detectManifestFormat(format ManifestFormat, path string, data []byte) ManifestFormat {
+	if format != ManifestFormatAuto {
+		return format
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ManifestFormatYAML
+	case ".json":
+		return ManifestFormatJSON
+	case ".toml":
+		return ManifestFormatTOML
+	}
+
+	return sniffManifestFormat(data)
+}
+
+// sniffManifestFormat guesses the manifest format from its first significant line.
+//
+// A manifest is always a mapping, so:
+//   - "{" starts a JSON object
+//   - "[table]" or "key = value" is TOML
+//   - anything else is treated as YAML
+func sniffManifestFormat(data []byte) ManifestFormat {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || line == "---" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "{"):
+			return ManifestFormatJSON
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			return ManifestFormatTOML
+		case isTOMLKeyValue(line):
+			return ManifestFormatTOML
+		}
+		return ManifestFormatYAML
+	}
+
+	return ManifestFormatYAML
+}
+
+// isTOMLKeyValue reports whether line looks like a TOML "key = value" pair
+func isTOMLKeyValue(line string) bool {
+	key, _, found := strings.Cut(line, "=")
+	if !found || strings.Contains(key, ":") {
+		return false
+	}
+	key = strings.TrimSpace(key)
+	return key != "" && !strings.ContainsAny(key, " \t")
+}
+
+// decodeManifest decodes manifest data in the given format.
+// Parse errors keep the manifest category and include line/column where the
+// format reports one.
+func decodeManifest(data []byte, format ManifestFormat) (*Manifest, error) {
+	var manifest Manifest
+
+	switch format {
+	case ManifestFormatJSON:
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, wrapError(jsonErrorPosition(data, err), CategoryManifest, ErrMsgParseJSON)
+		}
+	case ManifestFormatTOML:
+		if _, err := toml.Decode(string(data), &manifest); err != nil {
+			return nil, wrapError(tomlErrorPosition(err), CategoryManifest, ErrMsgParseTOML)
+		}
+	case ManifestFormatYAML:
+		// yaml.v3 already reports "line N" in its error messages
+		if err := yaml.Unmarshal(data, &manifest); err != nil {
+			return nil, wrapError(err, CategoryManifest, ErrMsgParseYAML)
+		}
+	default:
+		return nil, newCategoryErrorWithHint(CategoryManifest,
+			fmt.Sprintf(ErrFmtUnsupportedManifestFormat, format), ErrHintManifestFormat)
+	}
+
+	return &manifest, nil
+}
+
+// jsonErrorPosition annotates a JSON decoding error with line and column.
+func jsonErrorPosition(data []byte, err error) error {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return err
+	}
+
+	line, col := offsetToLineColumn(data, offset)
+	return fmt.Errorf(ErrFmtManifestPosition, line, col, err)
+}
+
+// tomlErrorPosition annotates a TOML decoding error with line and column.
+func tomlErrorPosition(err error) error {
+	var parseErr toml.ParseError
+	if errors.As(err, &parseErr) && parseErr.Position.Line > 0 {
+		return fmt.Errorf(ErrFmtManifestPosition, parseErr.Position.Line, parseErr.Position.Col, err)
+	}
+	return err
+}
+
+// offsetToLineColumn converts a byte offset into a 1-based line and column.
+func offsetToLineColumn(data []byte, offset int64) (line, col int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	line, col = 1, 1
+	for _, b := range data[:offset] {
+		if b == '\n' {
+			line++
+			col = 1
+			continue
+		}
+		col++
+	}
+	return line, col
+}
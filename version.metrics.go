@@ -0,0 +1,94 @@
+package version
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+)
+
+// metricLabel is a single label of a metric sample
+type metricLabel struct {
+	name, value string
+}
+
+// metricLabelEscaper escapes label values for the text exposition format
+var metricLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteMetrics writes the version information in the Prometheus text
+// exposition format (version 0.0.4), so it can be scraped without the
+// Prometheus client library:
+//
+//	# HELP build_info Build information of the running binary.
+//	# TYPE build_info gauge
+//	build_info{name="my-service",version="1.2.3",commit="abc123...",tag="v1.2.3",tree_state="clean",go_version="go1.24.6"} 1
+//	# HELP schema_version_info Schema versions declared in the version manifest.
+//	# TYPE schema_version_info gauge
+//	schema_version_info{name="postgres_main",version="45"} 1
+//
+// Each schema, API and component gets one schema_version_info,
+// api_version_info or component_version_info series, ordered by name;
+// families without entries are left out. All series have the value 1, so
+// versions can be joined onto other metrics with group_left.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	if err := version.MustGet().WriteMetrics(&buf); err != nil {
+//	    log.Fatal(err)
+//	}
+func (i *Info) WriteMetrics(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	writeMetricFamily(bw, MetricBuildInfo, "Build information of the running binary.", [][]metricLabel{{
+		{MetricLabelName, i.Project.Name},
+		{MetricLabelVersion, i.Project.Version},
+		{MetricLabelCommit, i.Git.Commit},
+		{MetricLabelTag, i.Git.Tag},
+		{MetricLabelTreeState, i.Git.TreeState},
+		{MetricLabelGoVersion, i.Build.GoVersion},
+	}})
+	writeMetricFamily(bw, MetricSchemaVersionInfo, "Schema versions declared in the version manifest.", dimensionSeries(i.GetSchemas()))
+	writeMetricFamily(bw, MetricAPIVersionInfo, "API versions declared in the version manifest.", dimensionSeries(i.GetAPIs()))
+	writeMetricFamily(bw, MetricComponentVersionInfo, "Component versions declared in the version manifest.", dimensionSeries(i.GetComponents()))
+
+	return bw.Flush()
+}
+
+// dimensionSeries returns the label sets of a dimension's series, ordered by name.
+func dimensionSeries(values map[string]string) [][]metricLabel {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	series := make([][]metricLabel, 0, len(names))
+	for _, name := range names {
+		series = append(series, []metricLabel{{MetricLabelName, name}, {MetricLabelVersion, values[name]}})
+	}
+	return series
+}
+
+// writeMetricFamily writes a gauge family whose series all have the value 1.
+// Families without series are skipped.
+func writeMetricFamily(w *bufio.Writer, name, help string, series [][]metricLabel) {
+	if len(series) == 0 {
+		return
+	}
+	w.WriteString("# HELP " + name + " " + help + "\n")
+	w.WriteString("# TYPE " + name + " gauge\n")
+	for _, labels := range series {
+		w.WriteString(name)
+		w.WriteByte('{')
+		for n, label := range labels {
+			if n > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(label.name + `="` + metricLabelEscaper.Replace(label.value) + `"`)
+		}
+		w.WriteString("} 1\n")
+	}
+}
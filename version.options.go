@@ -1,6 +1,9 @@
 package version
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Validator defines the interface for version validation.
 // Custom validators can be implemented to enforce version constraints.
@@ -13,15 +16,30 @@ type Validator interface {
 // LoadOptions contains configuration for loading version information.
 // Use the With* functions to configure options.
 type LoadOptions struct {
-	// manifestPath is the path to the versions.yaml file
+	// manifestPath is the path to the manifest file.
+	// If empty, the default filenames (versions.yaml, versions.yml,
+	// versions.json, versions.toml) are tried in order.
 	manifestPath string
 
 	// manifestEmbed contains embedded manifest data
 	manifestEmbed []byte
 
+	// manifestFormat forces the manifest format (auto-detected if empty)
+	manifestFormat ManifestFormat
+
+	// environment selects the environment overlay to merge over the manifest.
+	// If empty, the GOVERSION_ENV environment variable is used.
+	environment string
+
+	// envOverridePrefix enables environment variable overrides (disabled if empty)
+	envOverridePrefix string
+
 	// includeGit enables git information enrichment
 	includeGit bool
 
+	// gitSource selects the fallback git source (.git directory and/or binary)
+	gitSource GitSource
+
 	// includeBuild enables build information enrichment
 	includeBuild bool
 
@@ -34,23 +52,39 @@ type LoadOptions struct {
 	// ctx is the context for initialization and validation
 	// If nil, context.Background() is used
 	ctx context.Context
+
+	// reloadInterval enables polling for manifest changes (disabled if zero)
+	reloadInterval time.Duration
 }
 
 // defaultLoadOptions returns the default load options.
-// By default, loads from versions.yaml and includes git and build info.
+// By default, looks for versions.yaml, versions.yml, versions.json and
+// versions.toml (in that order) and includes git and build info.
 func defaultLoadOptions() *LoadOptions {
 	return &LoadOptions{
-		manifestPath: ManifestFilenameYAML,
 		includeGit:   true,
 		includeBuild: true,
 	}
 }
 
+// newLoadOptions returns the default load options with opts applied.
+func newLoadOptions(opts ...Option) *LoadOptions {
+	options := defaultLoadOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
 // Option is a functional option for configuring version loading.
 type Option func(*LoadOptions)
 
 // WithManifestPath sets the path to the version manifest file.
-// Default is "versions.yaml" in the current directory.
+// The format is detected from the extension (.yaml, .yml, .json, .toml) or,
+// for other extensions, from the content.
+//
+// By default, versions.yaml, versions.yml, versions.json and versions.toml
+// are tried in that order in the current directory.
 //
 // Example:
 //
@@ -76,6 +110,79 @@ func WithEmbedded(data []byte) Option {
 	}
 }
 
+// WithManifestFormat sets the manifest format explicitly.
+// This is mainly useful for embedded data, which has no file extension to
+// detect the format from. Without this option, embedded data is sniffed:
+// a leading "{" means JSON, "[table]" or "key = value" means TOML, and
+// anything else is parsed as YAML.
+//
+// The format applies to WithEmbedded data and the WithManifestPath file only.
+// Default manifest files (versions.yaml, versions.json, ...) and environment
+// overlay files are always detected from their extension.
+//
+// Example:
+//
+//	//go:embed versions.toml
+//	var versionsTOML []byte
+//
+//	info, err := version.New(
+//	    version.WithEmbedded(versionsTOML),
+//	    version.WithManifestFormat(version.ManifestFormatTOML),
+//	)
+func WithManifestFormat(format ManifestFormat) Option {
+	return func(o *LoadOptions) {
+		o.manifestFormat = format
+	}
+}
+
+// WithEnvironment selects the environment overlay to merge over the base manifest.
+//
+// Overlays come from the manifest's "environments" block and from a sibling
+// file next to the manifest (e.g. versions.prod.yaml for versions.yaml), in
+// that order. Maps are merged key by key and the merged manifest is validated
+// like any other. If no overlay exists for the environment, the base manifest
+// is used unchanged.
+//
+// If this option is not set, the GOVERSION_ENV environment variable is used.
+// Use Info.Environment() to see which overlay was applied.
+//
+// Example:
+//
+//	info, err := version.New(
+//	    version.WithManifestPath("./versions.yaml"),
+//	    version.WithEnvironment("staging"),
+//	)
+func WithEnvironment(name string) Option {
+	return func(o *LoadOptions) {
+		o.environment = name
+	}
+}
+
+// WithEnvOverrides enables overriding manifest values from environment variables.
+//
+// Overrides are applied after the manifest (and any environment overlay) is
+// loaded and before validators run. With prefix "GOVERSION":
+//   - GOVERSION_PROJECT_NAME and GOVERSION_PROJECT_VERSION set the project fields
+//   - GOVERSION_SCHEMAS_<NAME>, GOVERSION_APIS_<NAME> and GOVERSION_COMPONENTS_<NAME>
+//     set a single dimension entry (GOVERSION_SCHEMAS_POSTGRES_MAIN -> postgres_main)
+//   - GOVERSION_CUSTOM_<KEY> sets a custom value
+//
+// Names match existing keys case-insensitively, and since variable names
+// cannot contain '-', an '_' also matches a '-' in the key
+// (GOVERSION_COMPONENTS_BILLING_INTERNAL -> billing-internal). Unknown names
+// are added in lower case. Empty variables are ignored. The source of every applied override
+// is available via Info.GetOverrides() and the "overrides" JSON field.
+//
+// Example:
+//
+//	// GOVERSION_PROJECT_VERSION=1.2.4-hotfix ./my-service
+//	info, err := version.New(version.WithEnvOverrides(version.DefaultEnvOverridePrefix))
+func WithEnvOverrides(prefix string) Option {
+	return func(o *LoadOptions) {
+		o.envOverridePrefix = prefix
+	}
+}
+
 // WithGitInfo enables git information enrichment.
 // Git info includes commit hash, tag, tree state, and commit time.
 // This is enabled by default.
@@ -100,6 +207,24 @@ func WithoutGitInfo() Option {
 	}
 }
 
+// WithGitSource selects where git metadata is read from when it was not
+// injected via ldflags or found in runtime/debug.BuildInfo.
+//
+// By default (GitSourceAuto) the .git directory is read directly - HEAD, loose
+// refs, packed-refs and annotated tags - and the git binary is only executed for
+// anything still missing. GitSourceDirectory never executes a process, which
+// suits distroless or scratch images; GitSourceCommand restores the exec-only
+// behaviour. Any other value makes loading fail.
+//
+// Example:
+//
+//	info, err := version.New(version.WithGitSource(version.GitSourceDirectory))
+func WithGitSource(source GitSource) Option {
+	return func(o *LoadOptions) {
+		o.gitSource = source
+	}
+}
+
 // WithBuildInfo enables build information enrichment.
 // Build info includes build time, user, and Go version.
 // This is enabled by default.
@@ -198,3 +323,27 @@ func WithContext(ctx context.Context) Option {
 		o.ctx = ctx
 	}
 }
+
+// WithReload enables hot reload of the singleton when the manifest changes.
+//
+// Only honoured by Initialize(). A background watcher polls the manifest file
+// (and the environment overlay file, if any) every interval and re-runs the
+// full load - overlays, overrides, enrichment and validators - when the content
+// changes. The new Info is swapped in atomically and Subscribe() callbacks are
+// notified. Failed reloads keep the previous Info and report the error to the
+// callbacks instead.
+//
+// The watcher stops when the context passed via WithContext is cancelled, or
+// when StopReload() is called. Embedded manifests are never reloaded.
+//
+// Example:
+//
+//	err := version.Initialize(
+//	    version.WithManifestPath("/etc/my-app/versions.yaml"),
+//	    version.WithReload(30*time.Second),
+//	)
+func WithReload(interval time.Duration) Option {
+	return func(o *LoadOptions) {
+		o.reloadInterval = interval
+	}
+}
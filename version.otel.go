@@ -0,0 +1,139 @@
+package version
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ResourceAttribute is a single OpenTelemetry resource attribute.
+type ResourceAttribute struct {
+	// Key is the attribute key, e.g. "service.version"
+	Key string
+
+	// Value is the attribute value
+	Value string
+}
+
+// ResourceAttributes are OpenTelemetry resource attributes in a fixed order.
+// They carry no dependency on the OpenTelemetry SDK; convert them with
+// attribute.String(a.Key, a.Value) or pass String() as OTEL_RESOURCE_ATTRIBUTES.
+type ResourceAttributes []ResourceAttribute
+
+// ResourceAttributes maps the version information to OpenTelemetry resource
+// attributes following the semantic conventions:
+//
+//	service.name                 project name
+//	service.version              project version
+//	deployment.environment.name  applied environment overlay
+//	vcs.repository.ref.revision  git commit
+//	vcs.repository.ref.name      git tag, or the branch if HEAD is not tagged
+//	vcs.repository.ref.type      "tag" or "branch"
+//	vcs.repository.url.full      origin URL (credentials stripped)
+//	process.runtime.name         "go"
+//	process.runtime.version      Go version the binary was built with
+//
+// Schemas, APIs and components follow as <prefix>schema.<name>,
+// <prefix>api.<name> and <prefix>component.<name>, ordered by name. An empty
+// prefix selects DefaultResourceAttributePrefix ("app."). Empty values and
+// the placeholder commit of builds without git info are left out.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	attrs := version.MustGet().ResourceAttributes("")
+//	kvs := make([]attribute.KeyValue, 0, len(attrs))
+//	for _, a := range attrs {
+//	    kvs = append(kvs, attribute.String(a.Key, a.Value))
+//	}
+//	res := resource.NewWithAttributes(semconv.SchemaURL, kvs...)
+func (i *Info) ResourceAttributes(prefix string) ResourceAttributes {
+	if prefix == "" {
+		prefix = DefaultResourceAttributePrefix
+	}
+
+	var attrs ResourceAttributes
+	add := func(key, value string) {
+		if value != "" {
+			attrs = append(attrs, ResourceAttribute{Key: key, Value: value})
+		}
+	}
+
+	add(OTelAttrServiceName, i.Project.Name)
+	add(OTelAttrServiceVersion, i.Project.Version)
+	add(OTelAttrDeploymentEnvironment, i.environment)
+	if i.Git.Commit != DefaultGitCommit {
+		add(OTelAttrVCSRevision, i.Git.Commit)
+	}
+	switch {
+	case i.Git.Tag != "":
+		add(OTelAttrVCSRefName, i.Git.Tag)
+		add(OTelAttrVCSRefType, OTelRefTypeTag)
+	case i.Git.Branch != "":
+		add(OTelAttrVCSRefName, i.Git.Branch)
+		add(OTelAttrVCSRefType, OTelRefTypeBranch)
+	}
+	add(OTelAttrVCSRepositoryURL, i.Git.RemoteURL)
+	if i.Build.GoVersion != "" {
+		add(OTelAttrRuntimeName, OTelRuntimeNameGo)
+		add(OTelAttrRuntimeVersion, i.Build.GoVersion)
+	}
+
+	for _, dimension := range []struct {
+		prefix string
+		values map[string]string
+	}{
+		{OTelAttrSchemaPrefix, i.schemas},
+		{OTelAttrAPIPrefix, i.apis},
+		{OTelAttrComponentPrefix, i.components},
+	} {
+		names := make([]string, 0, len(dimension.values))
+		for name := range dimension.values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			add(prefix+dimension.prefix+name, dimension.values[name])
+		}
+	}
+	return attrs
+}
+
+// String renders the attributes in the OTEL_RESOURCE_ATTRIBUTES format:
+// comma-separated key=value pairs with characters outside the W3C Baggage
+// value set (spaces, commas, semicolons, quotes, backslashes and non-ASCII
+// bytes) as well as '%' and '=' percent-encoded.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	os.Setenv(version.EnvOTelResourceAttributes, info.ResourceAttributes("").String())
+func (a ResourceAttributes) String() string {
+	var b strings.Builder
+	for n, attr := range a {
+		if n > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(escapeResourceAttribute(attr.Key))
+		b.WriteByte('=')
+		b.WriteString(escapeResourceAttribute(attr.Value))
+	}
+	return b.String()
+}
+
+// escapeResourceAttribute percent-encodes bytes that are not W3C Baggage
+// octets, plus '%' and '=', so keys and values survive the SDK's parsing.
+func escapeResourceAttribute(s string) string {
+	var b strings.Builder
+	for idx := 0; idx < len(s); idx++ {
+		c := s[idx]
+		if c <= ' ' || c >= 0x7f || c == '"' || c == ',' || c == ';' || c == '\\' || c == '%' || c == '=' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,135 @@
+package version
+
+import (
+	"sort"
+	"strings"
+)
+
+// envOverrideSection maps an environment variable section to a manifest map.
+type envOverrideSection struct {
+	prefix    string // e.g. "SCHEMAS_"
+	dimension string // e.g. "schemas"
+}
+
+// envOverrideSections lists the map-valued manifest sections that can be overridden.
+var envOverrideSections = []envOverrideSection{
+	{prefix: EnvOverrideSchemasPrefix, dimension: DimensionSchemas},
+	{prefix: EnvOverrideAPIsPrefix, dimension: DimensionAPIs},
+	{prefix: EnvOverrideComponentsPrefix, dimension: DimensionComponents},
+	{prefix: EnvOverrideCustomPrefix, dimension: DimensionCustom},
+}
+
+// applyEnvOverrides applies environment variable overrides to the manifest.
+//
+// With prefix "GOVERSION", the following variables are recognised:
+//   - GOVERSION_PROJECT_NAME, GOVERSION_PROJECT_VERSION
+//   - GOVERSION_SCHEMAS_<NAME>, GOVERSION_APIS_<NAME>, GOVERSION_COMPONENTS_<NAME>
+//   - GOVERSION_CUSTOM_<KEY>
+//
+// <NAME> matches existing manifest keys case-insensitively (POSTGRES_MAIN
+// overrides postgres_main), with '_' also matching '-' (BILLING_INTERNAL
+// overrides billing-internal); unknown names are added in lower case. Empty values
+// are ignored. Each applied override is recorded in manifest.overrides as
+// "dimension.key" -> "env:VARIABLE".
+func applyEnvOverrides(manifest *Manifest, prefix string, environ []string) {
+	prefix = strings.TrimSuffix(strings.ToUpper(prefix), "_") + "_"
+
+	// Sort for deterministic results if two variables map to the same key
+	sorted := append([]string(nil), environ...)
+	sort.Strings(sorted)
+
+	for _, kv := range sorted {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || value == "" || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(name, prefix)
+
+		switch rest {
+		case EnvOverrideProjectName:
+			manifest.Project.Name = value
+			manifest.recordOverride(DimensionProject+".name", name)
+			continue
+		case EnvOverrideProjectVersion:
+			manifest.Project.Version = value
+			manifest.recordOverride(DimensionProject+".version", name)
+			continue
+		}
+
+		for _, section := range envOverrideSections {
+			if !strings.HasPrefix(rest, section.prefix) || len(rest) == len(section.prefix) {
+				continue
+			}
+			key := strings.TrimPrefix(rest, section.prefix)
+
+			switch section.dimension {
+			case DimensionSchemas:
+				manifest.Schemas, key = setStringOverride(manifest.Schemas, key, value)
+			case DimensionAPIs:
+				manifest.APIs, key = setStringOverride(manifest.APIs, key, value)
+			case DimensionComponents:
+				manifest.Components, key = setStringOverride(manifest.Components, key, value)
+			case DimensionCustom:
+				manifest.Custom, key = setCustomOverride(manifest.Custom, key, value)
+			}
+			manifest.recordOverride(section.dimension+"."+key, name)
+			break
+		}
+	}
+}
+
+// recordOverride notes that field was set from the environment variable name.
+func (m *Manifest) recordOverride(field, name string) {
+	if m.overrides == nil {
+		m.overrides = make(map[string]string)
+	}
+	m.overrides[field] = OverrideSourceEnvPrefix + name
+}
+
+// envKeyMatches reports whether envKey names the manifest key existing:
+// case-insensitively, with '_' standing for '-', which environment variable
+// names cannot contain (BILLING_INTERNAL names billing-internal).
+func envKeyMatches(existing, envKey string) bool {
+	return strings.EqualFold(strings.ReplaceAll(existing, "-", "_"), envKey)
+}
+
+// setStringOverride sets envKey in m, matching an existing key as described
+// for envKeyMatches. An exact case-insensitive match wins over a '-' match.
+// Returns the (possibly allocated) map and the manifest key that was set.
+func setStringOverride(m map[string]string, envKey, value string) (map[string]string, string) {
+	if m == nil {
+		m = make(map[string]string)
+	}
+	key, matched := strings.ToLower(envKey), false
+	for existing := range m {
+		if strings.EqualFold(existing, envKey) {
+			key = existing
+			break
+		}
+		if envKeyMatches(existing, envKey) && (!matched || existing < key) {
+			key, matched = existing, true
+		}
+	}
+	m[key] = value
+	return m, key
+}
+
+// setCustomOverride sets envKey in m, matching an existing key like setStringOverride.
+// Returns the (possibly allocated) map and the manifest key that was set.
+func setCustomOverride(m map[string]interface{}, envKey, value string) (map[string]interface{}, string) {
+	if m == nil {
+		m = make(map[string]interface{})
+	}
+	key, matched := strings.ToLower(envKey), false
+	for existing := range m {
+		if strings.EqualFold(existing, envKey) {
+			key = existing
+			break
+		}
+		if envKeyMatches(existing, envKey) && (!matched || existing < key) {
+			key, matched = existing, true
+		}
+	}
+	m[key] = value
+	return m, key
+}
@@ -0,0 +1,182 @@
+package version
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Registry owns a version Info and everything built on it: reloads,
+// subscriptions and the HTTP handlers and middleware.
+//
+// The package-level functions (Initialize, Get, Handler, Middleware, ...)
+// operate on a default Registry. Create additional registries with NewRegistry
+// when several applications share one process (multi-tenant or plugin hosts),
+// or in tests to avoid global state.
+//
+// The zero value is ready to use: the first Get() loads version info with
+// default options. A Registry must not be copied after first use.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	billing, err := version.NewRegistry(version.WithEmbedded(billingManifest))
+//	if err != nil {
+//	    return err
+//	}
+//	search, err := version.NewRegistry(version.WithEmbedded(searchManifest))
+//	if err != nil {
+//	    return err
+//	}
+//
+//	mux.Handle("/billing/version", billing.Handler())
+//	mux.Handle("/search/version", search.Handler())
+type Registry struct {
+	// instance holds the current Info for fast, lock-free reads
+	instance atomic.Pointer[Info]
+
+	// initOnce ensures initialization happens exactly once
+	initOnce sync.Once
+
+	// initError stores any error from initialization
+	initError error
+
+	// reloadMu serializes reloads
+	reloadMu sync.Mutex
+
+	// reloadOpts holds the options the registry was initialized with
+	reloadOpts []Option
+
+	// watcherMu protects watcher
+	watcherMu sync.Mutex
+
+	// watcher is the running reload watcher (nil if reload is disabled)
+	watcher *reloadWatcher
+
+	// subscribersMu protects subscribers and nextSubscriberID
+	subscribersMu sync.Mutex
+
+	// subscribers are notified after every reload attempt
+	subscribers []subscriber
+
+	// nextSubscriberID identifies the next subscriber
+	nextSubscriberID uint64
+}
+
+// NewRegistry creates a Registry and loads its version info immediately.
+//
+// Accepts the same options as Initialize, including WithReload. Returns the
+// load error (manifest, validation, ...) if loading fails.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	reg, err := version.NewRegistry(
+//	    version.WithManifestPath("./plugins/search/versions.yaml"),
+//	    version.WithoutGitInfo(),
+//	)
+//	if err != nil {
+//	    return err
+//	}
+//	defer reg.StopReload()
+func NewRegistry(opts ...Option) (*Registry, error) {
+	r := &Registry{}
+	if _, err := r.initialize(opts...); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// initialize loads version info with opts exactly once.
+// Reports whether this call performed the initialization.
+func (r *Registry) initialize(opts ...Option) (bool, error) {
+	didRun := false
+
+	r.initOnce.Do(func() {
+		didRun = true
+
+		options := newLoadOptions(opts...)
+		reload := options.reloadInterval > 0 && len(options.manifestEmbed) == 0
+		var fingerprint string
+		if reload {
+			fingerprint = manifestFingerprint(options)
+		}
+
+		var info *Info
+		info, r.initError = loadVersionInfo(opts...)
+		if r.initError == nil {
+			r.reloadOpts = opts
+			r.instance.Store(info)
+			if reload {
+				r.startWatcher(options, fingerprint)
+			}
+		}
+	})
+
+	return didRun, r.initError
+}
+
+// Get returns the registry's version info.
+//
+// A zero-value Registry auto-initializes with default options on first call.
+// Returns the initialization error if loading failed.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	info, err := reg.Get()
+//	if err != nil {
+//	    return err
+//	}
+//	log.Printf("Plugin version %s", info.Project.Version)
+func (r *Registry) Get() (*Info, error) {
+	// Fast path: already initialized
+	if info := r.instance.Load(); info != nil {
+		return info, nil
+	}
+
+	// Slow path: auto-initialize with defaults
+	if _, err := r.initialize(WithGitInfo(), WithBuildInfo()); err != nil {
+		return nil, err
+	}
+
+	info := r.instance.Load()
+	if info == nil {
+		// This should never happen, but handle it gracefully
+		return nil, ErrNotInitialized
+	}
+
+	return info, nil
+}
+
+// MustGet returns the registry's version info or panics if unavailable.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	logger = logger.With("version", reg.MustGet())
+func (r *Registry) MustGet() *Info {
+	info, err := r.Get()
+	if err != nil {
+		panic(fmt.Sprintf(ErrFmtMustGetPanic, err))
+	}
+	return info
+}
+
+// IsInitialized reports whether the registry holds version info.
+// Unlike Get(), it does NOT trigger auto-initialization.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	if reg.IsInitialized() {
+//	    log.Printf("Running version %s", reg.MustGet().Project.Version)
+//	}
+func (r *Registry) IsInitialized() bool {
+	return r.instance.Load() != nil
+}
@@ -0,0 +1,260 @@
+package version
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"os"
+	"sync"
+	"time"
+)
+
+// ChangeFunc is called after every reload attempt of a Registry.
+//
+// On success, old is the previous Info and new the one now returned by Get().
+// On failure, new is nil, err describes the parse or validation failure, and
+// old remains the active Info.
+type ChangeFunc func(old, new *Info, err error)
+
+// subscriber is a registered ChangeFunc
+type subscriber struct {
+	id uint64
+	fn ChangeFunc
+}
+
+// reloadWatcher polls the manifest for changes in a background goroutine
+type reloadWatcher struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Subscribe registers fn to be called after every reload of the singleton,
+// whether triggered by the WithReload watcher or by Reload().
+//
+// Callbacks run synchronously on the reloading goroutine, in subscription
+// order, and must not call Reload(). The returned function removes the
+// subscription.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	unsubscribe := version.Subscribe(func(old, new *version.Info, err error) {
+//	    if err != nil {
+//	        logger.Warn("version reload failed, keeping previous manifest", zap.Error(err))
+//	        return
+//	    }
+//	    logger.Info("version reloaded",
+//	        zap.String("from", old.Project.Version),
+//	        zap.String("to", new.Project.Version))
+//	})
+//	defer unsubscribe()
+func Subscribe(fn ChangeFunc) (unsubscribe func()) {
+	return defaultRegistry().Subscribe(fn)
+}
+
+// Subscribe registers fn to be called after every reload of the registry.
+// See the package-level Subscribe for details.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	unsubscribe := reg.Subscribe(func(old, new *version.Info, err error) {
+//	    // ...
+//	})
+//	defer unsubscribe()
+func (r *Registry) Subscribe(fn ChangeFunc) (unsubscribe func()) {
+	r.subscribersMu.Lock()
+	defer r.subscribersMu.Unlock()
+
+	r.nextSubscriberID++
+	id := r.nextSubscriberID
+	r.subscribers = append(r.subscribers, subscriber{id: id, fn: fn})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.subscribersMu.Lock()
+			defer r.subscribersMu.Unlock()
+			for i, s := range r.subscribers {
+				if s.id == id {
+					r.subscribers = append(r.subscribers[:i:i], r.subscribers[i+1:]...)
+					return
+				}
+			}
+		})
+	}
+}
+
+// Reload re-runs the full load with the options the singleton was initialized
+// with and atomically swaps the result in.
+//
+// If loading fails (parse error, validator failure, ...), the previous Info is
+// kept and the error is returned and passed to Subscribe() callbacks.
+// Returns ErrNotInitialized if the singleton has not been initialized.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	// Reload on SIGHUP
+//	signal.Notify(hup, syscall.SIGHUP)
+//	for range hup {
+//	    if err := version.Reload(); err != nil {
+//	        log.Printf("version reload failed: %v", err)
+//	    }
+//	}
+func Reload() error {
+	return defaultRegistry().Reload()
+}
+
+// Reload re-runs the full load with the options the registry was initialized
+// with. See the package-level Reload for details.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	if err := reg.Reload(); err != nil {
+//	    log.Printf("reload failed, keeping previous version info: %v", err)
+//	}
+func (r *Registry) Reload() error {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	old := r.instance.Load()
+	if old == nil {
+		return ErrNotInitialized
+	}
+
+	info, err := loadVersionInfo(r.reloadOpts...)
+	if err != nil {
+		r.notifySubscribers(old, nil, err)
+		return err
+	}
+
+	r.instance.Store(info)
+	r.notifySubscribers(old, info, nil)
+	return nil
+}
+
+// StopReload stops the WithReload watcher, if running, and waits for it to exit.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	defer version.StopReload()
+func StopReload() {
+	defaultRegistry().StopReload()
+}
+
+// StopReload stops the registry's WithReload watcher, if running, and waits
+// for it to exit.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	defer reg.StopReload()
+func (r *Registry) StopReload() {
+	r.watcherMu.Lock()
+	w := r.watcher
+	r.watcher = nil
+	r.watcherMu.Unlock()
+
+	if w != nil {
+		close(w.stop)
+		<-w.done
+	}
+}
+
+// notifySubscribers calls all subscribers with the result of a reload attempt.
+func (r *Registry) notifySubscribers(old, new *Info, err error) {
+	r.subscribersMu.Lock()
+	current := make([]subscriber, len(r.subscribers))
+	copy(current, r.subscribers)
+	r.subscribersMu.Unlock()
+
+	for _, s := range current {
+		s.fn(old, new, err)
+	}
+}
+
+// startWatcher starts polling the manifest every options.reloadInterval.
+// fingerprint is the manifest fingerprint taken before the initial load, so
+// changes made while loading are not missed.
+func (r *Registry) startWatcher(options *LoadOptions, fingerprint string) {
+	ctx := options.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	w := &reloadWatcher{stop: make(chan struct{}), done: make(chan struct{})}
+
+	r.watcherMu.Lock()
+	r.watcher = w
+	r.watcherMu.Unlock()
+
+	go func() {
+		defer close(w.done)
+
+		ticker := time.NewTicker(options.reloadInterval)
+		defer ticker.Stop()
+
+		last := fingerprint
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				current := manifestFingerprint(options)
+				if current == last {
+					continue
+				}
+				// Remember the new content even if the reload fails, so a broken
+				// manifest is reported once rather than on every tick
+				last = current
+				_ = r.Reload()
+			}
+		}
+	}()
+}
+
+// manifestFingerprint hashes the content of every file the loader could read:
+// the configured manifest (or all default filenames) and its environment
+// overlay sibling. Missing files are part of the fingerprint, so creating or
+// deleting a manifest counts as a change.
+func manifestFingerprint(options *LoadOptions) string {
+	paths := defaultManifestFilenames
+	if options.manifestPath != "" {
+		paths = []string{options.manifestPath}
+	}
+
+	env := options.resolveEnvironment()
+	h := sha256.New()
+	for _, path := range paths {
+		writeFileFingerprint(h, path)
+		if isValidEnvironmentName(env) {
+			writeFileFingerprint(h, environmentManifestPath(path, env))
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeFileFingerprint writes path and its content (or a missing marker) to h.
+func writeFileFingerprint(h hash.Hash, path string) {
+	h.Write([]byte(path))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		h.Write([]byte{0})
+		return
+	}
+	h.Write([]byte{1})
+	h.Write(data)
+}
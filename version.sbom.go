@@ -0,0 +1,468 @@
+package version
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SBOMFormat selects the software bill of materials format produced by Info.SBOM.
+type SBOMFormat string
+
+// Supported SBOM formats
+const (
+	// SBOMFormatCycloneDX produces a CycloneDX 1.5 JSON document
+	SBOMFormatCycloneDX SBOMFormat = "cyclonedx"
+
+	// SBOMFormatSPDX produces an SPDX 2.3 JSON document
+	SBOMFormatSPDX SBOMFormat = "spdx"
+)
+
+// ContentType returns the HTTP content type of documents in this format,
+// or "" for unsupported formats.
+//
+// Example:
+//
+//	w.Header().Set("Content-Type", version.SBOMFormatSPDX.ContentType())
+func (f SBOMFormat) ContentType() string {
+	switch f {
+	case SBOMFormatCycloneDX:
+		return HTTPContentTypeCycloneDX
+	case SBOMFormatSPDX:
+		return HTTPContentTypeSPDX
+	default:
+		return ""
+	}
+}
+
+// SBOM returns a software bill of materials for the binary described by Info.
+//
+// The document describes the project (name and version from the manifest,
+// main module and git commit from build info) and lists as its dependencies
+// every Go module compiled into the binary (see GetModules) and every
+// component declared in the manifest. Modules carry a pkg:golang package URL
+// and, where go.sum recorded one, their SHA-256 hash; manifest components
+// carry a pkg:generic package URL.
+//
+// Module dependencies are only present if build info was loaded (the default,
+// see WithBuildInfo). The output is deterministic for a given Info.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	sbom, err := version.MustGet().SBOM(version.SBOMFormatCycloneDX)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	os.WriteFile("sbom.cdx.json", sbom, 0o644)
+func (i *Info) SBOM(format SBOMFormat) ([]byte, error) {
+	var doc interface{}
+	switch format {
+	case SBOMFormatCycloneDX:
+		doc = i.cycloneDX()
+	case SBOMFormatSPDX:
+		doc = i.spdx()
+	default:
+		return nil, newCategoryErrorWithHint(CategoryBuildInfo,
+			fmt.Sprintf(ErrFmtUnsupportedSBOMFormat, format), ErrHintSBOMFormat)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, wrapError(err, CategoryBuildInfo, ErrMsgEncodeSBOM)
+	}
+	return append(data, '\n'), nil
+}
+
+// sbomPackage is the format-independent description of one SBOM entry.
+type sbomPackage struct {
+	name       string
+	version    string
+	purl       string
+	sha256     string
+	replacedBy string
+}
+
+// sbomMain describes the project itself.
+func (i *Info) sbomMain() sbomPackage {
+	main := sbomPackage{name: i.Project.Name, version: i.Project.Version}
+	if i.Build.MainModule != "" {
+		version := i.Build.MainVersion
+		if version == VCSVersionDevel {
+			version = ""
+		}
+		main.purl = golangPURL(i.Build.MainModule, version)
+	}
+	return main
+}
+
+// sbomDependencies lists Go modules (sorted by path) followed by manifest
+// components (sorted by name).
+func (i *Info) sbomDependencies() []sbomPackage {
+	deps := make([]sbomPackage, 0, len(i.modules)+len(i.components))
+
+	for _, mod := range i.modules {
+		pkg := sbomPackage{name: mod.Path, version: mod.Version, sha256: goSumToSHA256(mod.Sum)}
+		if mod.Replace != nil {
+			if mod.Replace.Version != "" {
+				// The replacement module is what was compiled in
+				pkg = sbomPackage{name: mod.Replace.Path, version: mod.Replace.Version, sha256: goSumToSHA256(mod.Replace.Sum)}
+			} else {
+				// Replaced by a local directory: the code has no module version
+				pkg = sbomPackage{name: mod.Path, replacedBy: mod.Replace.Path}
+			}
+		}
+		pkg.purl = golangPURL(pkg.name, pkg.version)
+		deps = append(deps, pkg)
+	}
+
+	names := make([]string, 0, len(i.components))
+	for name := range i.components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		version := i.components[name]
+		deps = append(deps, sbomPackage{name: name, version: version, purl: genericPURL(name, version)})
+	}
+
+	return deps
+}
+
+// sbomTimestamp returns the document creation time: when Info was loaded,
+// or now for Info values that were not produced by the loader.
+func (i *Info) sbomTimestamp() string {
+	ts := i.loadedAt
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	return ts.UTC().Format(time.RFC3339)
+}
+
+// sbomSerial derives a stable RFC 9562 version 8 UUID from the described
+// project and its dependencies, so regenerating the SBOM of the same binary
+// yields the same serial number.
+func (i *Info) sbomSerial(deps []sbomPackage) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\n", i.Project.Name, i.Project.Version, i.Git.Commit, i.Build.MainVersion)
+	for _, dep := range deps {
+		fmt.Fprintf(h, "%s\x00%s\x00%s\n", dep.purl, dep.sha256, dep.replacedBy)
+	}
+	sum := h.Sum(nil)
+	sum[6] = (sum[6] & 0x0f) | 0x80 // version 8
+	sum[8] = (sum[8] & 0x3f) | 0x80 // RFC 9562 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// golangPURL returns the package URL of a Go module. The version is omitted if empty.
+func golangPURL(path, version string) string {
+	purl := PURLPrefixGolang + escapePURLPath(path)
+	if version != "" {
+		purl += "@" + url.PathEscape(version)
+	}
+	return purl
+}
+
+// genericPURL returns the package URL of a manifest component.
+func genericPURL(name, version string) string {
+	purl := PURLPrefixGeneric + url.PathEscape(name)
+	if version != "" {
+		purl += "@" + url.PathEscape(version)
+	}
+	return purl
+}
+
+// escapePURLPath percent-encodes each segment of a slash-separated module path.
+func escapePURLPath(path string) string {
+	segments := strings.Split(path, "/")
+	for idx, segment := range segments {
+		segments[idx] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// goSumToSHA256 converts a go.sum "h1:" hash (base64 SHA-256) to hex.
+// Returns "" for missing or unrecognized hashes.
+func goSumToSHA256(sum string) string {
+	encoded, ok := strings.CutPrefix(sum, GoSumHashPrefix)
+	if !ok {
+		return ""
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) != sha256.Size {
+		return ""
+	}
+	return hex.EncodeToString(raw)
+}
+
+// CycloneDX 1.5 document types (only the fields produced are declared)
+type (
+	cdxDocument struct {
+		BOMFormat    string          `json:"bomFormat"`
+		SpecVersion  string          `json:"specVersion"`
+		SerialNumber string          `json:"serialNumber"`
+		Version      int             `json:"version"`
+		Metadata     cdxMetadata     `json:"metadata"`
+		Components   []cdxComponent  `json:"components"`
+		Dependencies []cdxDependency `json:"dependencies"`
+	}
+
+	cdxMetadata struct {
+		Timestamp string       `json:"timestamp"`
+		Tools     cdxTools     `json:"tools"`
+		Component cdxComponent `json:"component"`
+	}
+
+	cdxTools struct {
+		Components []cdxComponent `json:"components"`
+	}
+
+	cdxComponent struct {
+		BOMRef             string                 `json:"bom-ref,omitempty"`
+		Type               string                 `json:"type"`
+		Name               string                 `json:"name"`
+		Version            string                 `json:"version,omitempty"`
+		PURL               string                 `json:"purl,omitempty"`
+		Hashes             []cdxHash              `json:"hashes,omitempty"`
+		ExternalReferences []cdxExternalReference `json:"externalReferences,omitempty"`
+		Properties         []cdxProperty          `json:"properties,omitempty"`
+	}
+
+	cdxHash struct {
+		Algorithm string `json:"alg"`
+		Content   string `json:"content"`
+	}
+
+	cdxExternalReference struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	}
+
+	cdxProperty struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	cdxDependency struct {
+		Ref       string   `json:"ref"`
+		DependsOn []string `json:"dependsOn,omitempty"`
+	}
+)
+
+// cycloneDX builds the CycloneDX representation of Info.
+func (i *Info) cycloneDX() cdxDocument {
+	deps := i.sbomDependencies()
+
+	main := i.sbomMain()
+	mainComponent := cdxComponent{
+		BOMRef:  cdxRef(main),
+		Type:    CycloneDXTypeApplication,
+		Name:    main.name,
+		Version: main.version,
+		PURL:    main.purl,
+	}
+	if i.Git.RemoteURL != "" {
+		mainComponent.ExternalReferences = []cdxExternalReference{{Type: CycloneDXReferenceVCS, URL: i.Git.RemoteURL}}
+	}
+	if i.Git.Commit != "" && i.Git.Commit != DefaultGitCommit {
+		mainComponent.Properties = []cdxProperty{{Name: SBOMPropertyGitCommit, Value: i.Git.Commit}}
+	}
+
+	components := make([]cdxComponent, 0, len(deps))
+	refs := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		component := cdxComponent{
+			BOMRef:  cdxRef(dep),
+			Type:    CycloneDXTypeLibrary,
+			Name:    dep.name,
+			Version: dep.version,
+			PURL:    dep.purl,
+		}
+		if dep.sha256 != "" {
+			component.Hashes = []cdxHash{{Algorithm: CycloneDXHashSHA256, Content: dep.sha256}}
+		}
+		if dep.replacedBy != "" {
+			component.Properties = []cdxProperty{{Name: SBOMPropertyReplacedBy, Value: dep.replacedBy}}
+		}
+		components = append(components, component)
+		refs = append(refs, component.BOMRef)
+	}
+
+	dependencies := []cdxDependency{{Ref: mainComponent.BOMRef, DependsOn: refs}}
+	for _, ref := range refs {
+		dependencies = append(dependencies, cdxDependency{Ref: ref})
+	}
+
+	return cdxDocument{
+		BOMFormat:    CycloneDXBOMFormat,
+		SpecVersion:  CycloneDXSpecVersion,
+		SerialNumber: SBOMSerialPrefix + i.sbomSerial(deps),
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: i.sbomTimestamp(),
+			Tools: cdxTools{Components: []cdxComponent{
+				{Type: CycloneDXTypeApplication, Name: SBOMToolName},
+			}},
+			Component: mainComponent,
+		},
+		Components:   components,
+		Dependencies: dependencies,
+	}
+}
+
+// cdxRef returns the bom-ref of a package: its package URL, or its name if it has none.
+func cdxRef(pkg sbomPackage) string {
+	if pkg.purl != "" {
+		return pkg.purl
+	}
+	return pkg.name
+}
+
+// SPDX 2.3 document types (only the fields produced are declared)
+type (
+	spdxDocument struct {
+		SPDXVersion       string             `json:"spdxVersion"`
+		DataLicense       string             `json:"dataLicense"`
+		SPDXID            string             `json:"SPDXID"`
+		Name              string             `json:"name"`
+		DocumentNamespace string             `json:"documentNamespace"`
+		CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+		Packages          []spdxPackage      `json:"packages"`
+		Relationships     []spdxRelationship `json:"relationships"`
+	}
+
+	spdxCreationInfo struct {
+		Created  string   `json:"created"`
+		Creators []string `json:"creators"`
+	}
+
+	spdxPackage struct {
+		Name             string            `json:"name"`
+		SPDXID           string            `json:"SPDXID"`
+		VersionInfo      string            `json:"versionInfo,omitempty"`
+		DownloadLocation string            `json:"downloadLocation"`
+		FilesAnalyzed    bool              `json:"filesAnalyzed"`
+		Checksums        []spdxChecksum    `json:"checksums,omitempty"`
+		ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+		Comment          string            `json:"comment,omitempty"`
+	}
+
+	spdxChecksum struct {
+		Algorithm     string `json:"algorithm"`
+		ChecksumValue string `json:"checksumValue"`
+	}
+
+	spdxExternalRef struct {
+		ReferenceCategory string `json:"referenceCategory"`
+		ReferenceType     string `json:"referenceType"`
+		ReferenceLocator  string `json:"referenceLocator"`
+	}
+
+	spdxRelationship struct {
+		SPDXElementID      string `json:"spdxElementId"`
+		RelationshipType   string `json:"relationshipType"`
+		RelatedSPDXElement string `json:"relatedSpdxElement"`
+	}
+)
+
+// spdx builds the SPDX representation of Info.
+func (i *Info) spdx() spdxDocument {
+	deps := i.sbomDependencies()
+	ids := make(map[string]bool)
+
+	main := i.sbomMain()
+	mainPackage := spdxPackageFor(main, spdxID(SPDXIDPrefixPackage, main.name, ids))
+	if i.Git.RemoteURL != "" {
+		mainPackage.DownloadLocation = SPDXDownloadLocationGitPrefix + i.Git.RemoteURL
+		if i.Git.Commit != "" && i.Git.Commit != DefaultGitCommit {
+			mainPackage.DownloadLocation += "@" + i.Git.Commit
+		}
+	}
+
+	packages := []spdxPackage{mainPackage}
+	relationships := []spdxRelationship{{
+		SPDXElementID:      SPDXDocumentID,
+		RelationshipType:   SPDXRelationshipDescribes,
+		RelatedSPDXElement: mainPackage.SPDXID,
+	}}
+	for idx, dep := range deps {
+		prefix := SPDXIDPrefixModule
+		if idx >= len(i.modules) {
+			prefix = SPDXIDPrefixComponent
+		}
+		pkg := spdxPackageFor(dep, spdxID(prefix, dep.name, ids))
+		packages = append(packages, pkg)
+		relationships = append(relationships, spdxRelationship{
+			SPDXElementID:      mainPackage.SPDXID,
+			RelationshipType:   SPDXRelationshipDependsOn,
+			RelatedSPDXElement: pkg.SPDXID,
+		})
+	}
+
+	name := i.Project.Name + "-" + i.Project.Version
+	return spdxDocument{
+		SPDXVersion:       SPDXVersion,
+		DataLicense:       SPDXDataLicense,
+		SPDXID:            SPDXDocumentID,
+		Name:              name,
+		DocumentNamespace: SPDXNamespaceBase + url.PathEscape(name) + "-" + i.sbomSerial(deps),
+		CreationInfo: spdxCreationInfo{
+			Created:  i.sbomTimestamp(),
+			Creators: []string{SPDXCreatorToolPrefix + SBOMToolName},
+		},
+		Packages:      packages,
+		Relationships: relationships,
+	}
+}
+
+// spdxPackageFor converts a package to its SPDX representation.
+func spdxPackageFor(pkg sbomPackage, id string) spdxPackage {
+	result := spdxPackage{
+		Name:             pkg.name,
+		SPDXID:           id,
+		VersionInfo:      pkg.version,
+		DownloadLocation: SPDXNoAssertion,
+	}
+	if pkg.sha256 != "" {
+		result.Checksums = []spdxChecksum{{Algorithm: SPDXChecksumSHA256, ChecksumValue: pkg.sha256}}
+	}
+	if pkg.purl != "" {
+		result.ExternalRefs = []spdxExternalRef{{
+			ReferenceCategory: SPDXRefCategoryPackageManager,
+			ReferenceType:     SPDXRefTypePURL,
+			ReferenceLocator:  pkg.purl,
+		}}
+	}
+	if pkg.replacedBy != "" {
+		result.Comment = SPDXCommentReplacedBy + pkg.replacedBy
+	}
+	return result
+}
+
+// spdxID returns a unique SPDX identifier for name. SPDX identifiers may only
+// contain letters, digits, '.' and '-', so other characters become '-'.
+func spdxID(prefix, name string, used map[string]bool) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, r := range name {
+		if r < 0x80 && (r == '.' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('-')
+		}
+	}
+
+	id := b.String()
+	for n := 2; used[id]; n++ {
+		id = fmt.Sprintf("%s-%d", b.String(), n)
+	}
+	used[id] = true
+	return id
+}
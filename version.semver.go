@@ -36,6 +36,12 @@ type SemVer struct {
 //   - Build metadata: "1.2.3+build", "1.2.3+20230101"
 //   - Combined: "1.2.3-alpha+build"
 //
+// Parsing is lenient: a leading "v" and missing minor or patch components
+// ("1", "v2.1") are accepted, so schema numbers like "45" parse as "45.0.0".
+// Leading zeros, empty identifiers and characters outside [0-9A-Za-z-] in
+// prerelease or build identifiers are rejected. Use ParseSemVerStrict to
+// require the exact SemVer 2.0.0 grammar.
+//
 // Returns an error if the version string is not valid semver format.
 //
 // Thread-safe for concurrent use by multiple goroutines.
@@ -55,6 +61,29 @@ func ParseSemVer(s string) (*SemVer, error) {
 	return &SemVer{internal: internal}, nil
 }
 
+// ParseSemVerStrict parses a version string that must conform exactly to
+// the SemVer 2.0.0 grammar.
+//
+// Unlike ParseSemVer, it rejects a leading "v" and shortened forms such as
+// "1" or "1.2". Use it for release versions that are published to package
+// registries or compared against tags from other tooling.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	v, err := version.ParseSemVerStrict("1.0.0-rc.1+build.5")
+//	if err != nil {
+//	    return err
+//	}
+func ParseSemVerStrict(s string) (*SemVer, error) {
+	internal, err := semver.ParseStrict(s)
+	if err != nil {
+		return nil, err
+	}
+	return &SemVer{internal: internal}, nil
+}
+
 // MustParseSemVer parses a semantic version string or panics on error.
 //
 // Use this in initialization code where invalid versions should crash the application.
@@ -133,6 +162,8 @@ func (v *SemVer) Build() string {
 // Comparison follows semver.org specification:
 //   - Major, minor, patch are compared numerically
 //   - Prerelease versions have lower precedence than release versions
+//   - Prerelease identifiers are compared field by field, numeric ones
+//     numerically ("alpha.2" < "alpha.10") and before alphanumeric ones
 //   - Build metadata is ignored in comparison
 //
 // Thread-safe for concurrent use by multiple goroutines.
@@ -71,6 +71,18 @@ func TestParseSemVer(t *testing.T) {
 			input:       "abc",
 			expectError: true,
 		},
+		"leading_zero": {
+			input:       "1.02.3",
+			expectError: true,
+		},
+		"invalid_prerelease": {
+			input:       "1.2.3-alpha..1",
+			expectError: true,
+		},
+		"too_many_components": {
+			input:       "1.2.3.4",
+			expectError: true,
+		},
 	}
 
 	for name, tc := range tests {
@@ -202,6 +214,21 @@ func TestSemVerCompare(t *testing.T) {
 			v2:       "1.2.3-beta",
 			expected: -1,
 		},
+		"prerelease_numeric_identifiers": {
+			v1:       "1.0.0-alpha.10",
+			v2:       "1.0.0-alpha.2",
+			expected: 1,
+		},
+		"prerelease_rc_vs_beta": {
+			v1:       "1.0.0-rc.1",
+			v2:       "1.0.0-beta.11",
+			expected: 1,
+		},
+		"build_metadata_ignored": {
+			v1:       "1.0.0+build.1",
+			v2:       "1.0.0+build.2",
+			expected: 0,
+		},
 	}
 
 	for name, tc := range tests {
@@ -215,6 +242,32 @@ func TestSemVerCompare(t *testing.T) {
 	}
 }
 
+func TestParseSemVerStrict(t *testing.T) {
+	tests := map[string]struct {
+		input       string
+		expectError bool
+	}{
+		"full_version":       {input: "1.0.0-rc.1+build.5"},
+		"v_prefix_rejected":  {input: "v1.2.3", expectError: true},
+		"major_only":         {input: "1", expectError: true},
+		"major_minor":        {input: "1.2", expectError: true},
+		"prerelease_leading": {input: "1.2.3-01", expectError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := ParseSemVerStrict(tc.input)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, v)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.input, v.String())
+		})
+	}
+}
+
 func TestSemVerComparisonMethods(t *testing.T) {
 	v1 := MustParseSemVer("1.2.3")
 	v2 := MustParseSemVer("2.0.0")
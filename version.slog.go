@@ -0,0 +1,200 @@
+package version
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync/atomic"
+)
+
+// SlogAttrs returns the version info as grouped log/slog attributes: a
+// "project" group (name, version), the applied environment, a "git" group
+// (commit, tree state and, where known, short commit, tag, branch, describe
+// output and remote URL), a "build" group (time, Go version, user) and one
+// group per non-empty dimension ("schemas", "apis", "components") keyed by
+// name. Keys match the JSON output.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	logger := slog.Default().With(slog.Any("version", info))
+//	logger.Info("Application started")
+func (i *Info) SlogAttrs() []slog.Attr {
+	attrs := []slog.Attr{
+		slog.Group(DimensionProject,
+			slog.String(SlogKeyName, i.Project.Name),
+			slog.String(SlogKeyVersion, i.Project.Version),
+		),
+	}
+	if i.environment != "" {
+		attrs = append(attrs, slog.String(SlogKeyEnvironment, i.environment))
+	}
+
+	git := []any{slog.String(SlogKeyCommit, i.Git.Commit)}
+	for _, optional := range []slog.Attr{
+		slog.String(SlogKeyShortCommit, i.Git.ShortCommit),
+		slog.String(SlogKeyTag, i.Git.Tag),
+		slog.String(SlogKeyBranch, i.Git.Branch),
+		slog.String(SlogKeyDescribe, i.Git.Describe),
+		slog.String(SlogKeyRemoteURL, i.Git.RemoteURL),
+	} {
+		if optional.Value.String() != "" {
+			git = append(git, optional)
+		}
+	}
+	git = append(git, slog.String(SlogKeyTreeState, i.Git.TreeState))
+	attrs = append(attrs, slog.Group(SlogGroupGit, git...))
+
+	build := []any{slog.String(SlogKeyTime, i.Build.Time)}
+	if i.Build.User != "" {
+		build = append(build, slog.String(SlogKeyUser, i.Build.User))
+	}
+	build = append(build, slog.String(SlogKeyGoVersion, i.Build.GoVersion))
+	attrs = append(attrs, slog.Group(SlogGroupBuild, build...))
+
+	for _, dimension := range []struct {
+		name   string
+		values map[string]string
+	}{
+		{DimensionSchemas, i.schemas},
+		{DimensionAPIs, i.apis},
+		{DimensionComponents, i.components},
+	} {
+		if len(dimension.values) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(dimension.values))
+		for name := range dimension.values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		values := make([]any, 0, len(names))
+		for _, name := range names {
+			values = append(values, slog.String(name, dimension.values[name]))
+		}
+		attrs = append(attrs, slog.Group(dimension.name, values...))
+	}
+	return attrs
+}
+
+// LogValue implements slog.LogValuer, so an Info logged with slog.Any is
+// rendered as the group of SlogAttrs.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	slog.Info("Application started", "version", version.MustGet())
+//	// {"msg":"Application started","version":{"project":{"name":"my-service",...},"git":{...},...}}
+func (i *Info) LogValue() slog.Value {
+	return slog.GroupValue(i.SlogAttrs()...)
+}
+
+// SlogHandler wraps next so that every record carries the current version
+// info as a "version" group (see SlogAttrs). The group is added at the top
+// level, also for loggers derived with WithGroup, and follows reloads of the
+// singleton.
+//
+// Records are passed on without the group if version info is unavailable.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	logger := slog.New(version.SlogHandler(slog.NewJSONHandler(os.Stdout, nil)))
+//	logger.Info("request handled", "status", 200)
+//	// {"time":...,"msg":"request handled","version":{"project":{...},...},"status":200}
+func SlogHandler(next slog.Handler) slog.Handler {
+	return newSlogHandler(Get, next)
+}
+
+// SlogHandler wraps next so that every record carries the registry's version
+// info. See the package-level SlogHandler for details.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	logger := slog.New(reg.SlogHandler(slog.NewTextHandler(os.Stderr, nil)))
+func (r *Registry) SlogHandler(next slog.Handler) slog.Handler {
+	return newSlogHandler(r.Get, next)
+}
+
+// slogHandler adds the version group to next before the WithAttrs and
+// WithGroup calls made on the wrapper, which are replayed on top of it. The
+// resulting handler is cached until get returns a different Info, so
+// handlers that preformat attributes do so once per reload, not per record.
+type slogHandler struct {
+	// get returns the current Info
+	get func() (*Info, error)
+
+	// next is the wrapped handler, before any WithAttrs or WithGroup
+	next slog.Handler
+
+	// derive replays the WithAttrs and WithGroup calls made on the wrapper
+	derive []func(slog.Handler) slog.Handler
+
+	// cached is the derived handler for the most recent Info
+	cached atomic.Pointer[slogHandlerCache]
+}
+
+// slogHandlerCache is a derived handler and the Info it was built for
+type slogHandlerCache struct {
+	info    *Info
+	handler slog.Handler
+}
+
+func newSlogHandler(get func() (*Info, error), next slog.Handler) *slogHandler {
+	return &slogHandler{get: get, next: next}
+}
+
+// Enabled reports whether the wrapped handler handles records at level.
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle passes the record to the wrapped handler with the version group added.
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	info, err := h.get()
+	if err != nil {
+		info = nil
+	}
+
+	cached := h.cached.Load()
+	if cached == nil || cached.info != info {
+		handler := h.next
+		if info != nil {
+			handler = handler.WithAttrs([]slog.Attr{{Key: SlogGroupVersion, Value: info.LogValue()}})
+		}
+		for _, derive := range h.derive {
+			handler = derive(handler)
+		}
+		cached = &slogHandlerCache{info: info, handler: handler}
+		h.cached.Store(cached)
+	}
+	return cached.handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a wrapper whose records also carry attrs.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.with(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+}
+
+// WithGroup returns a wrapper that qualifies later attributes with name.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+// with returns a copy of h with one more derive step.
+func (h *slogHandler) with(derive func(slog.Handler) slog.Handler) *slogHandler {
+	steps := make([]func(slog.Handler) slog.Handler, 0, len(h.derive)+1)
+	steps = append(steps, h.derive...)
+	return &slogHandler{get: h.get, next: h.next, derive: append(steps, derive)}
+}
@@ -0,0 +1,265 @@
+package version
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// InfoSection selects one part of the version info, like the section flags
+// of the CLI (-git, -schemas, ...) and the ?section= parameter of Handler.
+type InfoSection string
+
+// Supported sections
+const (
+	// SectionGit is the git information
+	SectionGit InfoSection = FieldPathGit
+
+	// SectionBuild is the build information
+	SectionBuild InfoSection = FieldPathBuild
+
+	// SectionSchemas is the schema versions
+	SectionSchemas InfoSection = DimensionSchemas
+
+	// SectionAPIs is the API versions
+	SectionAPIs InfoSection = DimensionAPIs
+
+	// SectionComponents is the component versions
+	SectionComponents InfoSection = DimensionComponents
+
+	// SectionModules is the Go module dependencies
+	SectionModules InfoSection = DimensionModules
+)
+
+// infoSections lists the supported sections
+var infoSections = []InfoSection{SectionGit, SectionBuild, SectionSchemas, SectionAPIs, SectionComponents, SectionModules}
+
+// ParseInfoSection validates a section name.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	section, err := version.ParseInfoSection(r.URL.Query().Get("section"))
+func ParseInfoSection(name string) (InfoSection, error) {
+	for _, section := range infoSections {
+		if string(section) == name {
+			return section, nil
+		}
+	}
+	return "", newCategoryErrorWithHint(CategoryValidation, fmt.Sprintf(ErrFmtUnsupportedSection, name), ErrHintSection)
+}
+
+// textTemplates define the human-readable layout of WriteText. Template
+// lines start with "{{-" so that only explicit strings and row calls (one
+// "  label:\tvalue" line each) produce newlines; "\t" separates the columns
+// aligned by a tabwriter.
+var textTemplates = template.Must(template.New("text").Funcs(template.FuncMap{
+	"join": strings.Join,
+	"row": func(label string, value interface{}) string {
+		return fmt.Sprintf("  %s:\t%v\n", label, value)
+	},
+}).Parse(`
+{{- define "map"}}{{range $key, $value := .}}{{row $key $value}}{{end}}{{end}}
+
+{{- define "git-block"}}
+{{- "Git Information:\n"}}
+{{- row "Commit" .Commit}}
+{{- with .ShortCommit}}{{row "Short Commit" .}}{{end}}
+{{- with .Branch}}{{row "Branch" .}}{{end}}
+{{- with .Tag}}{{row "Tag" .}}{{end}}
+{{- with .Describe}}{{row "Describe" .}}{{end}}
+{{- row "Tree State" .TreeState}}
+{{- with .CommitTime}}{{row "Commit Time" .}}{{end}}
+{{- with .RemoteURL}}{{row "Remote URL" .}}{{end}}
+{{- end}}
+
+{{- define "build-block"}}
+{{- "Build Information:\n"}}
+{{- row "Time" .Time}}
+{{- with .User}}{{row "User" .}}{{end}}
+{{- row "Go Version" .GoVersion}}
+{{- with .Path}}{{row "Path" .}}{{end}}
+{{- if .MainModule}}{{row "Main Module" (print .MainModule " " .MainVersion)}}{{end}}
+{{- if or .GOOS .GOARCH}}{{row "Platform" (print .GOOS "/" .GOARCH)}}{{row "CGO Enabled" .CGOEnabled}}{{end}}
+{{- with .Tags}}{{row "Tags" (join . ",")}}{{end}}
+{{- if .Trimpath}}{{row "Trimpath" .Trimpath}}{{end}}
+{{- end}}
+
+{{- define "full"}}
+{{- "Project Information:\n"}}
+{{- row "Name" .Project.Name}}
+{{- row "Version" .Project.Version}}
+{{- "\n"}}
+{{- template "git-block" .Git}}
+{{- "\n"}}
+{{- template "build-block" .Build}}
+{{- "\n"}}
+{{- if .Schemas}}{{"Database Schemas:\n"}}{{template "map" .Schemas}}{{"\n"}}{{end}}
+{{- if .APIs}}{{"API Versions:\n"}}{{template "map" .APIs}}{{"\n"}}{{end}}
+{{- if .Components}}{{"Component Versions:\n"}}{{template "map" .Components}}{{"\n"}}{{end}}
+{{- if .Custom}}{{"Custom Metadata:\n"}}{{template "map" .Custom}}{{end}}
+{{- end}}
+
+{{- define "schemas"}}
+{{- if .Schemas}}{{"Database Schemas:\n"}}{{template "map" .Schemas}}
+{{- else}}{{"No database schemas defined\n"}}{{end}}
+{{- end}}
+
+{{- define "apis"}}
+{{- if .APIs}}{{"API Versions:\n"}}{{template "map" .APIs}}
+{{- else}}{{"No API versions defined\n"}}{{end}}
+{{- end}}
+
+{{- define "components"}}
+{{- if .Components}}{{"Component Versions:\n"}}{{template "map" .Components}}
+{{- else}}{{"No component versions defined\n"}}{{end}}
+{{- end}}
+
+{{- define "git"}}{{template "git-block" .Git}}{{end}}
+
+{{- define "build"}}{{template "build-block" .Build}}{{end}}
+
+{{- define "modules"}}
+{{- if .Modules}}{{"Modules:\n"}}
+{{- range .Modules}}
+{{- if .Replace}}{{row .Path (print .Version " => " .Replace.Path " " .Replace.Version)}}
+{{- else}}{{row .Path .Version}}{{end}}
+{{- end}}
+{{- else}}{{"No module dependencies recorded\n"}}{{end}}
+{{- end}}
+`))
+
+// textData is the value the text templates are executed with
+type textData struct {
+	Project    ProjectVersion
+	Git        GitInfo
+	Build      BuildInfo
+	Schemas    map[string]string
+	APIs       map[string]string
+	Components map[string]string
+	Custom     map[string]interface{}
+	Modules    []Module
+}
+
+// WriteText writes the version info in the human-readable layout of the
+// go-version CLI: project, git, build, schemas, APIs, components and custom
+// metadata with aligned values. A non-empty section writes only that part,
+// e.g. SectionGit for the "Git Information" block.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	_ = version.MustGet().WriteText(os.Stdout, "")
+//	// Project Information:
+//	//   Name:     my-service
+//	//   Version:  1.2.3
+//	// ...
+func (i *Info) WriteText(w io.Writer, section InfoSection) error {
+	name := "full"
+	if section != "" {
+		if _, err := ParseInfoSection(string(section)); err != nil {
+			return err
+		}
+		name = string(section)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if err := textTemplates.ExecuteTemplate(tw, name, textData{
+		Project:    i.Project,
+		Git:        i.Git,
+		Build:      i.Build,
+		Schemas:    i.GetSchemas(),
+		APIs:       i.GetAPIs(),
+		Components: i.GetComponents(),
+		Custom:     i.GetCustom(),
+		Modules:    i.GetModules(),
+	}); err != nil {
+		return err
+	}
+	return tw.Flush()
+}
+
+// sectionValue returns the value of one section for JSON and YAML output,
+// or the whole Info for an empty section. Empty dimensions encode as {}.
+func (i *Info) sectionValue(section InfoSection) interface{} {
+	nonNil := func(m map[string]string) map[string]string {
+		if m == nil {
+			return map[string]string{}
+		}
+		return m
+	}
+	switch section {
+	case SectionGit:
+		return i.Git
+	case SectionBuild:
+		return i.Build
+	case SectionSchemas:
+		return nonNil(i.GetSchemas())
+	case SectionAPIs:
+		return nonNil(i.GetAPIs())
+	case SectionComponents:
+		return nonNil(i.GetComponents())
+	case SectionModules:
+		if modules := i.GetModules(); modules != nil {
+			return modules
+		}
+		return []Module{}
+	}
+	return i
+}
+
+// jsonToYAML re-encodes JSON as block-style YAML, keeping the key order of
+// the JSON encoding.
+func jsonToYAML(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	clearYAMLStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// clearYAMLStyle drops the flow style of JSON-decoded nodes.
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
+
+// MarshalYAML encodes v as block-style YAML with the field names and key
+// order of its JSON encoding, as served by Handler for ?format=yaml and
+// printed by the go-version CLI.
+//
+// Thread-safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	data, err := version.MarshalYAML(version.MustGet().Git)
+//	// commit: abc1234...
+//	// tree_state: clean
+func MarshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return jsonToYAML(data)
+}
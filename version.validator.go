@@ -3,23 +3,30 @@ package version
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/itsatony/go-version/internal/semver"
 )
 
 // genericVersionValidator handles version validation for any dimension (schema, API, or component).
 // It uses a getter function to retrieve the version and error format strings for messages.
+//
+// A validator either enforces a minimum version (minVersion) or a constraint
+// (constraint, parsed by the constructor); constraint takes precedence when set.
 type genericVersionValidator struct {
-	dimensionType  string // "schema", "API", or "component"
-	name           string // specific item name (e.g., "postgres_main")
-	minVersion     string // minimum required version
-	getterFunc     func(*Info, string) (string, bool)
-	errNotFoundFmt string
-	errInvalidFmt  string
-	errTooOldFmt   string
+	dimensionType     string             // "schema", "API", or "component"
+	name              string             // specific item name (e.g., "postgres_main")
+	minVersion        string             // minimum required version
+	constraintExpr    string             // constraint expression (e.g., "^1.15" or ">=45 <60")
+	constraint        *semver.Constraint // parsed constraintExpr
+	getterFunc        func(*Info, string) (string, bool)
+	errNotFoundFmt    string
+	errInvalidFmt     string
+	errTooOldFmt      string
+	errUnsatisfiedFmt string
 }
 
-// Validate checks if the version meets the minimum requirement.
+// Validate checks if the version meets the minimum requirement or constraint.
 func (v *genericVersionValidator) Validate(ctx context.Context, info *Info) error {
 	actual, ok := v.getterFunc(info, v.name)
 	if !ok {
@@ -41,6 +48,10 @@ func (v *genericVersionValidator) Validate(ctx context.Context, info *Info) erro
 		return fmt.Errorf(v.errInvalidFmt, actual, v.name, err)
 	}
 
+	if v.constraint != nil {
+		return v.validateConstraint(actual, actualVer)
+	}
+
 	minVer, err := semver.Parse(v.minVersion)
 	if err != nil {
 		return fmt.Errorf(ErrFmtInvalidMinVersion, v.minVersion, err)
@@ -53,6 +64,28 @@ func (v *genericVersionValidator) Validate(ctx context.Context, info *Info) erro
 	return nil
 }
 
+// validateConstraint checks the parsed actual version against the constraint expression.
+func (v *genericVersionValidator) validateConstraint(actual string, actualVer *semver.Version) error {
+	if reasons := v.constraint.Explain(actualVer); len(reasons) > 0 {
+		return fmt.Errorf(v.errUnsatisfiedFmt+"\nHint: %s",
+			v.name, actual, v.constraintExpr, strings.Join(reasons, "; "), ErrHintConstraintUnsatisfied)
+	}
+
+	return nil
+}
+
+// withConstraint parses constraint and makes v enforce it.
+func (v *genericVersionValidator) withConstraint(constraint, errUnsatisfiedFmt string) (*genericVersionValidator, error) {
+	parsed, err := semver.ParseConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf(ErrFmtInvalidConstraint, constraint, err)
+	}
+	v.constraintExpr = constraint
+	v.constraint = parsed
+	v.errUnsatisfiedFmt = errUnsatisfiedFmt
+	return v, nil
+}
+
 // SchemaValidator validates that a database schema meets a minimum version requirement.
 // It compares the actual schema version from the Info against the required minimum version.
 //
@@ -91,6 +124,26 @@ func NewSchemaValidator(schemaName, minVersion string) *SchemaValidator {
 	}
 }
 
+// NewSchemaConstraintValidator creates a validator that enforces a schema version constraint.
+// The schemaName must match a key in the Info.Schemas map.
+// The constraint uses the syntax documented on Constraint (e.g., "^45" or ">=45 <60");
+// an invalid constraint is reported here rather than when validating.
+//
+// The error message names the clause that failed, for example:
+//
+//	schema 'postgres_main' version 44 does not satisfy constraint '>=45 <60' (>=45 <60 requires >=45.0.0)
+//
+// Example:
+//
+//	validator, err := version.NewSchemaConstraintValidator("postgres_main", ">=45 <60")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = version.Initialize(version.WithValidators(validator))
+func NewSchemaConstraintValidator(schemaName, constraint string) (*SchemaValidator, error) {
+	return NewSchemaValidator(schemaName, "").withConstraint(constraint, ErrFmtSchemaConstraintUnsatisfied)
+}
+
 // APIValidator validates that an API meets a minimum version requirement.
 // It compares the actual API version from the Info against the required minimum version.
 //
@@ -121,6 +174,22 @@ func NewAPIValidator(apiName, minVersion string) *APIValidator {
 	}
 }
 
+// NewAPIConstraintValidator creates a validator that enforces an API version constraint.
+// The apiName must match a key in the Info.APIs map.
+// The constraint uses the syntax documented on Constraint (e.g., "^1.15");
+// an invalid constraint is reported here rather than when validating.
+//
+// Example:
+//
+//	validator, err := version.NewAPIConstraintValidator("rest_v1", "^1.15 || ^2.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = version.Initialize(version.WithValidators(validator))
+func NewAPIConstraintValidator(apiName, constraint string) (*APIValidator, error) {
+	return NewAPIValidator(apiName, "").withConstraint(constraint, ErrFmtAPIConstraintUnsatisfied)
+}
+
 // ComponentValidator validates that a component meets a minimum version requirement.
 // It compares the actual component version from the Info against the required minimum version.
 //
@@ -150,6 +219,22 @@ func NewComponentValidator(componentName, minVersion string) *ComponentValidator
 	}
 }
 
+// NewComponentConstraintValidator creates a validator that enforces a component version constraint.
+// The componentName must match a key in the Info.Components map.
+// The constraint uses the syntax documented on Constraint (e.g., "~3.4.0");
+// an invalid constraint is reported here rather than when validating.
+//
+// Example:
+//
+//	validator, err := version.NewComponentConstraintValidator("aigentchat", "~3.4.0")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = version.Initialize(version.WithValidators(validator))
+func NewComponentConstraintValidator(componentName, constraint string) (*ComponentValidator, error) {
+	return NewComponentValidator(componentName, "").withConstraint(constraint, ErrFmtComponentConstraintUnsatisfied)
+}
+
 // ValidatorFunc is a function adapter that allows using functions as Validators.
 // This enables inline validator creation without defining new types.
 //
@@ -400,6 +400,116 @@ schemas:
 	assert.Equal(t, "new-validated", info.Project.Name)
 }
 
+func TestNew_ValidatorPrereleasePrecedence(t *testing.T) {
+	embeddedData := []byte(`
+manifest_version: "1.0"
+project:
+  name: "prerelease-app"
+  version: "1.0.0"
+apis:
+  rest_v1: "2.0.0-alpha.10"
+components:
+  engine: "1.0.0-beta.11"
+`)
+
+	_, err := New(
+		WithEmbedded(embeddedData),
+		WithValidators(
+			NewAPIValidator("rest_v1", "2.0.0-alpha.2"),
+		),
+	)
+	require.NoError(t, err, "alpha.10 must satisfy a minimum of alpha.2")
+
+	_, err = New(
+		WithEmbedded(embeddedData),
+		WithValidators(
+			NewComponentValidator("engine", "1.0.0-rc.1"),
+		),
+	)
+	require.Error(t, err, "beta.11 must not satisfy a minimum of rc.1")
+	assert.Contains(t, err.Error(), "less than required minimum")
+}
+
+func TestNew_WithConstraintValidators(t *testing.T) {
+	embeddedData := []byte(`
+manifest_version: "1.0"
+project:
+  name: "constraint-app"
+  version: "1.0.0"
+schemas:
+  postgres_main: "47"
+apis:
+  rest_v1: "1.14.2"
+components:
+  aigentchat: "3.4.1"
+`)
+
+	tests := map[string]struct {
+		newValidator func() (*genericVersionValidator, error)
+		expectError  string
+	}{
+		"schema_range_ok": {
+			newValidator: func() (*genericVersionValidator, error) {
+				return NewSchemaConstraintValidator("postgres_main", ">=45 <60")
+			},
+		},
+		"schema_range_too_new": {
+			newValidator: func() (*genericVersionValidator, error) {
+				return NewSchemaConstraintValidator("postgres_main", ">=40 <46")
+			},
+			expectError: "schema 'postgres_main' version 47 does not satisfy constraint '>=40 <46' (>=40 <46 requires <46.0.0)",
+		},
+		"api_caret_fails_with_clause": {
+			newValidator: func() (*genericVersionValidator, error) {
+				return NewAPIConstraintValidator("rest_v1", "^1.15 || ^2")
+			},
+			expectError: "^1.15 requires >=1.15.0; ^2 requires >=2.0.0",
+		},
+		"component_tilde_ok": {
+			newValidator: func() (*genericVersionValidator, error) {
+				return NewComponentConstraintValidator("aigentchat", "~3.4.0")
+			},
+		},
+		"missing_component": {
+			newValidator: func() (*genericVersionValidator, error) {
+				return NewComponentConstraintValidator("missing", "^1")
+			},
+			expectError: "component 'missing' not found in manifest",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			validator, err := tc.newValidator()
+			require.NoError(t, err)
+			_, err = New(WithEmbedded(embeddedData), WithValidators(validator))
+			if tc.expectError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectError)
+		})
+	}
+}
+
+func TestNewConstraintValidator_InvalidConstraint(t *testing.T) {
+	tests := map[string]func() (*genericVersionValidator, error){
+		"schema":    func() (*genericVersionValidator, error) { return NewSchemaConstraintValidator("postgres_main", ">>1") },
+		"api":       func() (*genericVersionValidator, error) { return NewAPIConstraintValidator("rest_v1", "^") },
+		"component": func() (*genericVersionValidator, error) { return NewComponentConstraintValidator("aigentchat", "") },
+	}
+
+	for name, newValidator := range tests {
+		t.Run(name, func(t *testing.T) {
+			validator, err := newValidator()
+			require.Error(t, err)
+			assert.Nil(t, validator)
+			assert.Contains(t, err.Error(), "invalid version constraint")
+		})
+	}
+}
+
 func TestInitialize_WithMultipleOptions(t *testing.T) {
 	Reset()
 	defer Reset()
@@ -607,7 +717,7 @@ project:
 	}
 }
 
-func BenchmarkInfo_LogFields(b *testing.B) {
+func BenchmarkInfo_SlogAttrs(b *testing.B) {
 	Reset()
 	defer Reset()
 
@@ -623,7 +733,7 @@ project:
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = info.LogFields()
+		_ = info.SlogAttrs()
 	}
 }
 
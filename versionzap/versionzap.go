@@ -0,0 +1,38 @@
+// Package versionzap provides zap logging fields for go-version's Info.
+//
+// It lives in its own package so that the core package does not depend on
+// zap; log/slog and logr users use Info.SlogAttrs, Info.LogValue and
+// Info.KeysAndValues instead.
+package versionzap
+
+import (
+	"github.com/itsatony/go-version"
+	"go.uber.org/zap"
+)
+
+// Fields returns structured logging fields for use with a zap logger. The
+// field names are the version.LogField* constants.
+//
+// Example:
+//
+//	logger := zap.Must(zap.NewProduction())
+//	logger = logger.With(versionzap.Fields(version.MustGet())...)
+//	logger.Info("Application started")
+//
+// Thread-safe for concurrent use by multiple goroutines.
+func Fields(info *version.Info) []zap.Field {
+	return []zap.Field{
+		zap.String(version.LogFieldProjectName, info.Project.Name),
+		zap.String(version.LogFieldProjectVersion, info.Project.Version),
+		zap.String(version.LogFieldGitCommit, info.Git.Commit),
+		zap.String(version.LogFieldGitTag, info.Git.Tag),
+		zap.String(version.LogFieldGitTreeState, info.Git.TreeState),
+		zap.String(version.LogFieldGitShortCommit, info.Git.ShortCommit),
+		zap.String(version.LogFieldGitBranch, info.Git.Branch),
+		zap.String(version.LogFieldGitDescribe, info.Git.Describe),
+		zap.String(version.LogFieldGitRemoteURL, info.Git.RemoteURL),
+		zap.String(version.LogFieldBuildTime, info.Build.Time),
+		zap.String(version.LogFieldBuildUser, info.Build.User),
+		zap.String(version.LogFieldGoVersion, info.Build.GoVersion),
+	}
+}
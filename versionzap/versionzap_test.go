@@ -0,0 +1,50 @@
+package versionzap
+
+import (
+	"testing"
+
+	"github.com/itsatony/go-version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFields(t *testing.T) {
+	info := &version.Info{
+		Project: version.ProjectVersion{Name: "test-app", Version: "1.2.3"},
+		Git: version.GitInfo{
+			Commit:     "abc123",
+			Tag:        "v1.2.3",
+			TreeState:  "clean",
+			CommitTime: "2025-10-11T10:00:00Z",
+		},
+		Build: version.BuildInfo{
+			Time:      "2025-10-11T12:00:00Z",
+			User:      "ci",
+			GoVersion: "go1.21.0",
+		},
+	}
+
+	fields := Fields(info)
+	require.Len(t, fields, 12)
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	zap.New(core).With(fields...).Info("started")
+
+	context := logs.All()[0].ContextMap()
+	assert.Equal(t, "test-app", context[version.LogFieldProjectName])
+	assert.Equal(t, "1.2.3", context[version.LogFieldProjectVersion])
+	assert.Equal(t, "v1.2.3", context[version.LogFieldGitTag])
+	assert.Equal(t, "ci", context[version.LogFieldBuildUser])
+}
+
+func BenchmarkFields(b *testing.B) {
+	info := &version.Info{Project: version.ProjectVersion{Name: "bench-app", Version: "1.2.3"}}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Fields(info)
+	}
+}